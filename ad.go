@@ -0,0 +1,291 @@
+package schemax
+
+/*
+ad.go contains facilities for the parsing and interrogation of attribute
+descriptions, which bear an attribute type alongside zero (0) or more
+attribute options, e.g.: "cn;lang-de".
+*/
+
+/*
+AttributeDescription implements the RFC 4512 Section 2.5 attribute
+description, being an [AttributeType] accompanied by zero (0) or more
+attribute options, e.g.: "cn;lang-de;binary".
+
+Instances of this type are produced by [Schema.ParseAttributeDescription].
+*/
+type AttributeDescription struct {
+	*attributeDescription
+}
+
+type attributeDescription struct {
+	at   AttributeType
+	opts []string // lowercase, as supplied order
+}
+
+/*
+ParseAttributeDescription returns an instance of [AttributeDescription]
+alongside an error following an attempt to parse raw, e.g.:
+
+	cn;lang-de;binary
+
+The base attribute type, whether expressed by name or numeric OID, is
+resolved through the receiver instance without regard for case, and
+must be known.  Options are recognized as follows:
+
+  - Language tag options, per RFC 3866, such as "lang-de" or "lang-en-us"
+  - The "binary" transfer option, per RFC 4522
+  - The "range=<low>-<high>" option used for ranged value retrieval, where high may be "*"
+  - All other options must consist of one (1) or more letters, digits and hyphens, per RFC 4512
+
+Options are compared without regard for case, and may not be repeated.
+*/
+func (r Schema) ParseAttributeDescription(raw string) (ad AttributeDescription, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	parts := split(raw, `;`)
+	if !isNumericOID(parts[0]) && !isDescriptor(parts[0]) {
+		err = wraperr(ErrInvalidSyntax, `: invalid attribute type '`+parts[0]+`'`)
+		return
+	}
+
+	_ad := &attributeDescription{at: r.AttributeTypes().get(parts[0])}
+	if _ad.at.IsZero() {
+		err = wraperr(ErrAttributeTypeNotFound, `: `+parts[0])
+		return
+	}
+
+	for _, opt := range parts[1:] {
+		opt = lc(opt)
+		if _, _, isRange := parseRangeOption(opt); !isRange && !isOption(opt) {
+			err = wraperr(ErrInvalidSyntax, `: invalid attribute option '`+opt+`'`)
+			return
+		} else if strInSlice(opt, _ad.opts) {
+			err = wraperr(ErrInvalidSyntax, `: duplicate attribute option '`+opt+`'`)
+			return
+		}
+		_ad.opts = append(_ad.opts, opt)
+	}
+
+	ad = AttributeDescription{_ad}
+
+	return
+}
+
+/*
+isOption returns a Boolean value indicative of opt being an RFC 4512
+option, which is one (1) or more keychars.
+*/
+func isOption(opt string) bool {
+	if len(opt) == 0 {
+		return false
+	}
+
+	for _, c := range opt {
+		if !isAlnum(c) && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+parseRangeOption returns the low and high bounds of the ranged retrieval
+option opt, e.g.: "range=0-1499".  A high bound of "*" is returned as -1.
+*/
+func parseRangeOption(opt string) (low, high int, ok bool) {
+	if !hasPfx(opt, `range=`) {
+		return
+	}
+
+	bounds := split(opt[6:], `-`)
+	if len(bounds) != 2 || !isDigits(bounds[0]) {
+		return
+	}
+
+	low, _ = atoi(bounds[0])
+	if high = -1; bounds[1] != `*` {
+		if !isDigits(bounds[1]) {
+			return
+		} else if high, _ = atoi(bounds[1]); high < low {
+			return
+		}
+	}
+	ok = true
+
+	return
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state.
+*/
+func (r AttributeDescription) IsZero() bool {
+	return r.attributeDescription == nil
+}
+
+/*
+AttributeType returns the base [AttributeType] of the receiver instance.
+*/
+func (r AttributeDescription) AttributeType() (at AttributeType) {
+	if !r.IsZero() {
+		at = r.attributeDescription.at
+	}
+
+	return
+}
+
+/*
+Options returns the lowercase attribute options of the receiver instance,
+in the order in which they were specified.
+*/
+func (r AttributeDescription) Options() (opts []string) {
+	if !r.IsZero() {
+		opts = append(opts, r.attributeDescription.opts...)
+	}
+
+	return
+}
+
+/*
+HasOption returns a Boolean value indicative of opt being present within
+the receiver instance.  Case is not significant in the matching process.
+*/
+func (r AttributeDescription) HasOption(opt string) bool {
+	return strInSlice(lc(opt), r.Options())
+}
+
+/*
+TaggingOptions returns the tagging options of the receiver instance. At
+present, these are the RFC 3866 language tag options, e.g.: "lang-de".
+*/
+func (r AttributeDescription) TaggingOptions() (opts []string) {
+	for _, opt := range r.Options() {
+		if isTaggingOption(opt) {
+			opts = append(opts, opt)
+		}
+	}
+
+	return
+}
+
+func isTaggingOption(opt string) bool {
+	return hasPfx(opt, `lang-`)
+}
+
+/*
+LanguageTags returns the RFC 3866 language tags present within the
+receiver instance, less their "lang-" prefixes, e.g.: "de" or "en-us".
+*/
+func (r AttributeDescription) LanguageTags() (tags []string) {
+	for _, opt := range r.TaggingOptions() {
+		tags = append(tags, opt[5:])
+	}
+
+	return
+}
+
+/*
+Binary returns a Boolean value indicative of the RFC 4522 "binary"
+transfer option being present within the receiver instance.
+*/
+func (r AttributeDescription) Binary() bool {
+	return r.HasOption(`binary`)
+}
+
+/*
+Range returns the low and high bounds of the "range=" option of the
+receiver instance, alongside a Boolean value indicative of its presence.
+A high bound of "*" -- meaning all remaining values -- is returned as -1.
+*/
+func (r AttributeDescription) Range() (low, high int, ok bool) {
+	for _, opt := range r.Options() {
+		if low, high, ok = parseRangeOption(opt); ok {
+			break
+		}
+	}
+
+	return
+}
+
+/*
+String returns the string representation of the receiver instance, in
+which the [AttributeType] is expressed through [AttributeType.OID] and
+the options appear in lowercase, e.g.: "cn;lang-de".
+*/
+func (r AttributeDescription) String() (s string) {
+	if !r.IsZero() {
+		s = join(append([]string{r.AttributeType().OID()}, r.Options()...), `;`)
+	}
+
+	return
+}
+
+/*
+IsSubTypeOf returns a Boolean value indicative of the receiver being a
+subtype of desc, which may be an [AttributeDescription], an [AttributeType]
+or a string attribute description resolved through the [Schema] of the
+receiver.
+
+Per RFC 4512 Section 2.5, the receiver is a subtype of desc should its
+[AttributeType] be, or be derived from (see [AttributeType.SuperChain]),
+the [AttributeType] of desc, and should its tagging options be a superset
+of those of desc.  For example, "cn;lang-de" is a subtype of "name", and
+of "name;lang-de", but not of "cn;lang-en".  Non-tagging options, such as
+"binary", are not considered.
+
+An [AttributeDescription] is considered a subtype of itself.
+*/
+func (r AttributeDescription) IsSubTypeOf(desc any) (is bool) {
+	if r.IsZero() {
+		return
+	}
+
+	var other AttributeDescription
+	switch tv := desc.(type) {
+	case AttributeDescription:
+		other = tv
+	case AttributeType:
+		if !tv.IsZero() {
+			other = AttributeDescription{&attributeDescription{at: tv}}
+		}
+	case string:
+		other, _ = r.AttributeType().schema().ParseAttributeDescription(tv)
+	}
+
+	if other.IsZero() || !r.AttributeType().isTypeOf(other.AttributeType()) {
+		return
+	}
+
+	tags := r.TaggingOptions()
+	for _, opt := range other.TaggingOptions() {
+		if !strInSlice(opt, tags) {
+			return
+		}
+	}
+	is = true
+
+	return
+}
+
+/*
+isTypeOf returns a Boolean value indicative of the receiver being, or
+being derived from, at.
+*/
+func (r AttributeType) isTypeOf(at AttributeType) bool {
+	oid := at.NumericOID()
+	if r.NumericOID() == oid {
+		return true
+	}
+
+	sups := r.SuperChain()
+	for i := 0; i < sups.Len(); i++ {
+		if sups.Index(i).NumericOID() == oid {
+			return true
+		}
+	}
+
+	return false
+}
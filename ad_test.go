@@ -0,0 +1,166 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the parsing of an attribute description and
+the subsequent interrogation of its options.
+*/
+func ExampleSchema_ParseAttributeDescription() {
+	sch := NewSchema()
+
+	ad, err := sch.ParseAttributeDescription(`CN;Lang-DE;binary`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(ad)
+	fmt.Println(ad.LanguageTags(), ad.Binary())
+	// Output:
+	// cn;lang-de;binary
+	// [de] true
+}
+
+/*
+This example demonstrates the use of an attribute description in a
+subtype check.
+*/
+func ExampleAttributeDescription_IsSubTypeOf() {
+	sch := NewSchema()
+
+	ad, _ := sch.ParseAttributeDescription(`cn;lang-de`)
+	fmt.Println(ad.IsSubTypeOf(`name`))
+	fmt.Println(ad.IsSubTypeOf(`name;lang-en`))
+	// Output:
+	// true
+	// false
+}
+
+func TestSchema_ParseAttributeDescription(t *testing.T) {
+	sch := NewSchema()
+
+	for idx, tc := range []struct {
+		raw      string
+		want     string
+		tagging  []string
+		binary   bool
+		low, hi  int
+		hasRange bool
+	}{
+		{`cn`, `cn`, nil, false, 0, 0, false},
+		{`2.5.4.3`, `cn`, nil, false, 0, 0, false},
+		{`commonName;LANG-EN-US`, `cn;lang-en-us`, []string{`lang-en-us`}, false, 0, 0, false},
+		{`userCertificate;binary`, `userCertificate;binary`, nil, true, 0, 0, false},
+		{`member;range=0-1499`, `member;range=0-1499`, nil, false, 0, 1499, true},
+		{`member;range=1500-*`, `member;range=1500-*`, nil, false, 1500, -1, true},
+		{`description;lang-de;x-custom`, `description;lang-de;x-custom`, []string{`lang-de`}, false, 0, 0, false},
+	} {
+		ad, err := sch.ParseAttributeDescription(tc.raw)
+		if err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+			continue
+		}
+
+		low, hi, hasRange := ad.Range()
+		if got := ad.String(); got != tc.want {
+			t.Errorf("%s[%d] failed: want %q, got %q", t.Name(), idx, tc.want, got)
+		} else if fmt.Sprint(ad.TaggingOptions()) != fmt.Sprint(tc.tagging) {
+			t.Errorf("%s[%d] failed: want tagging %v, got %v", t.Name(), idx, tc.tagging, ad.TaggingOptions())
+		} else if ad.Binary() != tc.binary {
+			t.Errorf("%s[%d] failed: want binary %t", t.Name(), idx, tc.binary)
+		} else if low != tc.low || hi != tc.hi || hasRange != tc.hasRange {
+			t.Errorf("%s[%d] failed: want range %d-%d (%t), got %d-%d (%t)",
+				t.Name(), idx, tc.low, tc.hi, tc.hasRange, low, hi, hasRange)
+		}
+	}
+
+	for idx, tc := range []struct {
+		raw string
+		err error
+	}{
+		{``, ErrInvalidSyntax},
+		{`c_n`, ErrInvalidSyntax},
+		{`cn;`, ErrInvalidSyntax},
+		{`cn;lang_de`, ErrInvalidSyntax},
+		{`cn;lang-de;LANG-DE`, ErrInvalidSyntax},
+		{`member;range=5-1`, ErrInvalidSyntax},
+		{`member;range=a-*`, ErrInvalidSyntax},
+		{`member;range=0-b`, ErrInvalidSyntax},
+		{`member;range=0`, ErrInvalidSyntax},
+		{`bogusType;lang-de`, ErrAttributeTypeNotFound},
+	} {
+		if _, err := sch.ParseAttributeDescription(tc.raw); !errors.Is(err, tc.err) {
+			t.Errorf("%s[%d] failed: expected %v for %q, got %v", t.Name(), idx, tc.err, tc.raw, err)
+		}
+	}
+}
+
+func TestAttributeDescription_IsSubTypeOf(t *testing.T) {
+	sch := NewSchema()
+
+	for idx, tc := range []struct {
+		ad   string
+		desc any
+		want bool
+	}{
+		{`cn`, `cn`, true},
+		{`cn;lang-de`, `cn`, true},
+		{`cn;lang-de`, `name`, true},
+		{`cn;lang-de`, `name;lang-de`, true},
+		{`cn;lang-de;lang-en`, `NAME;LANG-EN`, true},
+		{`cn;lang-de`, `cn;lang-en`, false},
+		{`cn`, `cn;lang-de`, false},
+		{`name`, `cn`, false},
+		{`cn`, `sn`, false},
+		{`userCertificate;binary`, `userCertificate`, true},
+		{`userCertificate`, `userCertificate;binary`, true},
+		{`cn`, `bogusType`, false},
+		{`cn`, sch.AttributeTypes().Get(`name`), true},
+		{`sn`, sch.AttributeTypes().Get(`cn`), false},
+		{`cn`, AttributeType{}, false},
+		{`cn`, 1, false},
+	} {
+		ad, err := sch.ParseAttributeDescription(tc.ad)
+		if err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+		} else if got := ad.IsSubTypeOf(tc.desc); got != tc.want {
+			t.Errorf("%s[%d] failed: want %t for %s and %v, got %t", t.Name(), idx, tc.want, tc.ad, tc.desc, got)
+		}
+	}
+
+	ad, _ := sch.ParseAttributeDescription(`cn`)
+	other, _ := sch.ParseAttributeDescription(`name`)
+	if !ad.IsSubTypeOf(other) {
+		t.Errorf("%s failed: cn is not a subtype of name", t.Name())
+	}
+}
+
+func TestAttributeDescription_codecov(t *testing.T) {
+	var ad AttributeDescription
+	_ = ad.IsZero()
+	_ = ad.AttributeType()
+	_ = ad.Options()
+	_ = ad.HasOption(`binary`)
+	_ = ad.LanguageTags()
+	_ = ad.String()
+	_, _, _ = ad.Range()
+	_ = ad.IsSubTypeOf(`cn`)
+
+	var sch Schema
+	if _, err := sch.ParseAttributeDescription(`cn`); !errors.Is(err, ErrNilReceiver) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilReceiver, err)
+	}
+
+	sch = NewSchema()
+	if at := sch.AttributeTypes().Get(`cn;lang-de`); at.NumericOID() != `2.5.4.3` {
+		t.Errorf("%s failed: attribute description lookup failed", t.Name())
+	}
+	if !sch.AttributeTypes().Contains(`2.5.4.3;binary`) {
+		t.Errorf("%s failed: attribute description containment check failed", t.Name())
+	}
+}
@@ -44,6 +44,7 @@ an [AttributeType] instance.
 func NewAttributeType() AttributeType {
 	at := AttributeType{newAttributeType()}
 	at.attributeType.Extensions.setDefinition(at)
+	at.attributeType.Name.setDefinition(at)
 	return at
 }
 
@@ -71,6 +72,10 @@ func (r AttributeType) Marshal(def any) error {
 		return err
 	}
 
+	// MUB depends upon the effective syntax,
+	// so we'll handle it once all else is set.
+	var mub any
+
 	for k, v := range m {
 		switch key := uc(k); key {
 		case `NAME`:
@@ -80,6 +85,8 @@ func (r AttributeType) Marshal(def any) error {
 			case []string:
 				r.SetName(tv...)
 			}
+		case `MUB`:
+			mub = v
 		case `NUMERICOID`, `DESC`:
 			z := map[string]func(string) AttributeType{
 				`DESC`:       r.SetDescription,
@@ -123,7 +130,11 @@ func (r AttributeType) Marshal(def any) error {
 		}
 	}
 
-	if !r.Compliant() {
+	if mub != nil {
+		r.marshalMUB(mub)
+	}
+
+	if !r.compliant() {
 		return ErrDefNonCompliant
 	}
 	r.SetStringer()
@@ -136,6 +147,9 @@ E returns the underlying error instance.
 */
 func (r AttributeType) E() (err error) {
 	if !r.IsZero() {
+		runlock := r.attributeType.schema.rlock()
+		defer runlock()
+
 		err = r.attributeType.err
 	} else {
 		err = ErrNilReceiver
@@ -155,6 +169,19 @@ func (r AttributeType) marshalExt(key string, v any) {
 	}
 }
 
+func (r AttributeType) marshalMUB(v any) {
+	switch tv := v.(type) {
+	case string:
+		if mub, ok := atoui(tv); ok {
+			r.SetMinimumUpperBounds(mub)
+		}
+	case []string:
+		r.marshalMUB(tv[0])
+	case int, uint:
+		r.SetMinimumUpperBounds(tv)
+	}
+}
+
 func (r AttributeType) marshalBoolean(v any, funk func() AttributeType) {
 	switch tv := v.(type) {
 	case string:
@@ -238,7 +265,7 @@ func (r AttributeType) Replace(x AttributeType) AttributeType {
 		return r
 	}
 
-	if !r.IsZero() && x.Compliant() {
+	if !r.IsZero() && x.compliant() {
 		r.attributeType.replace(x)
 	}
 
@@ -246,6 +273,15 @@ func (r AttributeType) Replace(x AttributeType) AttributeType {
 }
 
 func (r *attributeType) replace(x AttributeType) {
+	sch := r.schema
+	unlock, ok := sch.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if r.OID == `` {
 		r.err = ErrMissingNumericOID
 		return
@@ -257,6 +293,7 @@ func (r *attributeType) replace(x AttributeType) {
 	r.OID = x.attributeType.OID
 	r.Macro = x.attributeType.Macro
 	r.Name = x.attributeType.Name
+	r.Name.setDefinition(AttributeType{r})
 	r.Desc = x.attributeType.Desc
 	r.Obsolete = x.attributeType.Obsolete
 	r.MUB = x.attributeType.MUB
@@ -275,6 +312,43 @@ func (r *attributeType) replace(x AttributeType) {
 	r.stringer = x.attributeType.stringer
 	r.valQual = x.attributeType.valQual
 	r.data = x.attributeType.data
+
+	sch.reindex(AttributeType{r})
+}
+
+/*
+Clone returns a deep copy of the receiver instance.  Modification of
+the return instance -- such as by way of the [AttributeType.SetName]
+method -- shall not influence the receiver instance.
+
+Referenced definitions, such as the SUP, EQUALITY and SYNTAX values,
+are NOT cloned; use [Schema.Clone] to clone an entire [Schema] along
+with all internal references.
+
+Note that use of the [AttributeType.SetStringer] method may be needed
+following any changes to the return instance.
+*/
+func (r AttributeType) Clone() (c AttributeType) {
+	if r.IsZero() {
+		return
+	}
+
+	_c := *r.attributeType
+	c = AttributeType{&_c}
+	_c.Macro = cloneStrings(r.attributeType.Macro)
+	_c.Name = cloneName(r.attributeType.Name, c)
+	_c.Extensions = cloneExtensions(r.attributeType.Extensions, c)
+
+	return
+}
+
+func (r *attributeType) rewire(sch Schema) {
+	r.schema = sch
+	r.SuperType = r.SuperType.counterpart(sch)
+	r.Equality = r.Equality.counterpart(sch)
+	r.Ordering = r.Ordering.counterpart(sch)
+	r.Substring = r.Substring.counterpart(sch)
+	r.Syntax = r.Syntax.counterpart(sch)
 }
 
 /*
@@ -297,6 +371,14 @@ func (r AttributeType) SetSchema(schema Schema) AttributeType {
 }
 
 func (r *attributeType) setSchema(schema Schema) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -335,6 +417,14 @@ func (r AttributeType) SetData(x any) AttributeType {
 }
 
 func (r *attributeType) setData(x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.data = x
 }
 
@@ -462,6 +552,14 @@ func (r AttributeType) SetValueQualifier(function ValueQualifier) AttributeType
 }
 
 func (r *attributeType) setValueQualifier(function ValueQualifier) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.valQual = function
 }
 
@@ -598,8 +696,8 @@ func (r AttributeTypes) canPush(x ...any) (err error) {
 		if at, ok := instance.(AttributeType); !ok || at.IsZero() {
 			err = ErrTypeAssert
 		} else {
-			if tst := r.get(at.NumericOID()); !tst.IsZero() {
-				err = mkerr(ErrNotUnique.Error() + ": " + at.Type() + `, ` + at.NumericOID())
+			if tst := r.lookup(at.NumericOID()); !tst.IsZero() {
+				err = wraperr(ErrNotUnique, ": "+at.Type()+`, `+at.NumericOID())
 			}
 		}
 	}
@@ -625,6 +723,9 @@ func (r AttributeTypes) Len() int {
 }
 
 func (r AttributeTypes) len() int {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
 	return r.cast().Len()
 }
 
@@ -645,7 +746,17 @@ func (r AttributeTypes) Index(idx int) AttributeType {
 	return r.index(idx)
 }
 
-func (r AttributeTypes) index(idx int) (at AttributeType) {
+func (r AttributeTypes) index(idx int) AttributeType {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.at(idx)
+}
+
+/*
+at is the unlocked counterpart of index.
+*/
+func (r AttributeTypes) at(idx int) (at AttributeType) {
 	slice, found := r.cast().Index(idx)
 	if found {
 		if _at, ok := slice.(AttributeType); ok {
@@ -660,18 +771,22 @@ func (r AttributeTypes) index(idx int) (at AttributeType) {
 Push returns an error following an attempt to push an [AttributeType]
 into the receiver stack instance.
 */
-func (r AttributeTypes) Push(at any) error {
-	return r.push(at)
+func (r AttributeTypes) Push(at any) (err error) {
+	if err = r.push(at); err == nil {
+		pushed(r, at)
+	}
+
+	return
 }
 
 func (r AttributeTypes) push(x any) (err error) {
 	switch tv := x.(type) {
 	case AttributeType:
-		if !tv.Compliant() {
+		if !tv.compliant() {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		err = lockedPush(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -702,14 +817,41 @@ the principal identifier of an [AttributeType] and the provided id.
 The return instance is nil if no match was made.
 
 Case is not significant in the matching process.
+
+Should id be an attribute description bearing options, such as "cn;lang-de",
+the options are ignored.  See [Schema.ParseAttributeDescription] for a means
+of interrogating such options.
 */
 func (r AttributeTypes) Get(id string) AttributeType {
 	return r.get(id)
 }
 
-func (r AttributeTypes) get(id string) (at AttributeType) {
-	for i := 0; i < r.len() && at.IsZero(); i++ {
-		if _at := r.index(i); !_at.IsZero() {
+func (r AttributeTypes) get(id string) AttributeType {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.lookup(id)
+}
+
+/*
+lookup is the unlocked counterpart of get, for use where the lock is
+already held, such as during a push.
+*/
+func (r AttributeTypes) lookup(id string) (at AttributeType) {
+	if idx := stridx(id, `;`); idx != -1 {
+		id = id[:idx]
+	}
+
+	if slice, indexed := indexLookup(r.cast(), id, func(x any) bool {
+		_at, _ := x.(AttributeType)
+		return _at.IsIdentifiedAs(id)
+	}); indexed {
+		at, _ = slice.(AttributeType)
+		return
+	}
+
+	for i := 0; i < r.cast().Len() && at.IsZero(); i++ {
+		if _at := r.at(i); !_at.IsZero() {
 			if _at.attributeType.OID == id {
 				at = _at
 			} else if _at.attributeType.Name.contains(id) {
@@ -880,6 +1022,14 @@ func (r AttributeType) SetNumericOID(id string) AttributeType {
 }
 
 func (r *attributeType) setNumericOID(id string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
@@ -908,6 +1058,14 @@ func (r AttributeType) SetExtension(x string, xstrs ...string) AttributeType {
 }
 
 func (r *attributeType) setExtension(x string, xstrs ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -940,14 +1098,24 @@ func (r AttributeType) SetName(x ...string) AttributeType {
 }
 
 func (r *attributeType) setName(x ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	b4 := r.Name.Len()
 	for i := 0; i < len(x); i++ {
-		r.Name.Push(x[i])
+		r.Name.push(x[i])
 	}
 
 	if r.Name.Len()-len(x) != b4 {
 		r.err = ErrInvalidNames
 	}
+
+	r.schema.reindex(AttributeType{r})
 }
 
 /*
@@ -990,7 +1158,15 @@ func (r AttributeType) SetDescription(desc string) AttributeType {
 }
 
 func (r *attributeType) setDescription(desc string) {
-	if len(desc) < 3 {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if len(desc) == 0 {
 		return
 	}
 
@@ -998,7 +1174,7 @@ func (r *attributeType) setDescription(desc string) {
 		desc = desc[1:]
 	}
 
-	if rune(desc[len(desc)-1]) == rune(39) {
+	if len(desc) > 0 && rune(desc[len(desc)-1]) == rune(39) {
 		desc = desc[:len(desc)-1]
 	}
 
@@ -1045,7 +1221,7 @@ stringer.
 This is a fluent method and may be used multiple times.
 */
 func (r AttributeType) SetStringer(function ...Stringer) AttributeType {
-	if r.Compliant() {
+	if r.compliant() {
 		r.attributeType.setStringer(function...)
 	}
 
@@ -1058,23 +1234,29 @@ func (r *attributeType) setStringer(function ...Stringer) {
 		stringer = function[0]
 	}
 
+	var err error
 	if stringer == nil {
 		// no user provided closure means we
 		// defer to a general use stringer.
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a static value.
-				return str
-			}
-		} else {
-			r.err = err
+		var str string
+		str, err = r.prepareString() // perform one-time text/template op
+		stringer = func() string {
+			// Return a preserved value.
+			return str
 		}
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	} else if err != nil {
+		r.err = err
 		return
 	}
 
-	// assign user-provided closure
 	r.stringer = stringer
 }
 
@@ -1089,7 +1271,7 @@ func (r AttributeTypes) XOrigin(x string) (defs AttributeTypes) {
 		def := r.Index(i)
 		if xo, found := def.Extensions().Get(`X-ORIGIN`); found {
 			if xo.Contains(x) {
-				defs.push(def)
+				defs.cast().Push(def)
 			}
 		}
 	}
@@ -1187,6 +1369,14 @@ func (r AttributeType) SetMinimumUpperBounds(mub any) AttributeType {
 }
 
 func (r *attributeType) setMinimumUpperBounds(mub any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	switch tv := mub.(type) {
 	case int:
 		if tv >= 0 {
@@ -1254,7 +1444,17 @@ func (r *attributeType) setSyntax(x any) {
 		def = tv
 	}
 
-	if def.Compliant() {
+	valid := def.compliant()
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if valid {
 		r.Syntax = def
 	} else {
 		r.err = ErrInvalidSyntax
@@ -1330,7 +1530,17 @@ func (r *attributeType) setEquality(x any) {
 		def = tv
 	}
 
-	if def.Compliant() {
+	valid := def.compliant()
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if valid {
 		r.Equality = def
 	} else {
 		r.err = ErrEqualityRuleNotFound
@@ -1406,7 +1616,17 @@ func (r *attributeType) setSubstring(x any) {
 		def = tv
 	}
 
-	if def.Compliant() {
+	valid := def.compliant()
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if valid {
 		r.Substring = def
 	} else {
 		r.err = ErrSubstringRuleNotFound
@@ -1482,7 +1702,17 @@ func (r *attributeType) setOrdering(x any) {
 		def = tv
 	}
 
-	if def.Compliant() {
+	valid := def.compliant()
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if valid {
 		r.Ordering = def
 	} else {
 		r.err = ErrOrderingRuleNotFound
@@ -1542,12 +1772,16 @@ func (r AttributeType) SuperChain() (sups AttributeTypes) {
 }
 
 func (r AttributeType) superChain() (sups AttributeTypes) {
+	// sups is private to the caller, thus it
+	// is populated and read without locking.
 	sups = NewAttributeTypes()
-	sups.Push(r)
+	if r.compliant() {
+		sups.cast().Push(r)
+	}
 	if !r.SuperType().IsZero() {
 		x := r.SuperType().SuperChain()
-		for i := 0; i < x.Len(); i++ {
-			sups.Push(x.Index(i))
+		for i := 0; i < x.cast().Len(); i++ {
+			sups.cast().Push(x.at(i))
 		}
 	}
 	return
@@ -1579,7 +1813,17 @@ func (r *attributeType) setSuperType(x any) {
 		def = tv
 	}
 
-	if def.Compliant() {
+	valid := def.compliant()
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if valid {
 		r.SuperType = def
 	} else {
 		r.err = ErrSuperTypeNotFound
@@ -1659,6 +1903,13 @@ func (r AttributeType) SetObsolete() AttributeType {
 }
 
 func (r *attributeType) setBoolean(t string, x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
 
 	var Bool bool
 	switch tv := x.(type) {
@@ -1713,6 +1964,18 @@ func (r AttributeType) SetUsage(u any) AttributeType {
 }
 
 func (r *attributeType) setUsage(u any) {
+	if tv, isUint := u.(uint); isUint {
+		u = int(tv)
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	switch tv := u.(type) {
 	case string:
 		switch lc(tv) {
@@ -1725,8 +1988,6 @@ func (r *attributeType) setUsage(u any) {
 		default:
 			r.Usage = UserApplicationsUsage
 		}
-	case uint:
-		r.setUsage(int(tv))
 	case int:
 		switch tv {
 		case 1:
@@ -1813,7 +2074,22 @@ shall not be both COLLECTIVE and SINGLE-VALUE'd.
 
 [§ 4.1.2 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.2
 */
-func (r AttributeType) Compliant() bool {
+func (r AttributeType) Compliant() (ok bool) {
+	if ok = r.compliant(); ok {
+		unlock, _ := r.attributeType.schema.lock()
+		defer unlock()
+
+		r.attributeType.err = nil
+	}
+
+	return
+}
+
+/*
+compliant is the side-effect free counterpart of Compliant, for use
+upon paths which merely read the receiver.
+*/
+func (r AttributeType) compliant() bool {
 	if r.IsZero() {
 		return false
 	}
@@ -1823,7 +2099,7 @@ func (r AttributeType) Compliant() bool {
 	}
 
 	syn := r.schema().LDAPSyntaxes().get(r.Syntax().NumericOID())
-	if !syn.IsZero() && !syn.Compliant() {
+	if !syn.IsZero() && !syn.compliant() {
 		return false
 	}
 
@@ -1832,7 +2108,7 @@ func (r AttributeType) Compliant() bool {
 		r.schema().MatchingRules().get(r.Ordering().NumericOID()),
 		r.schema().MatchingRules().get(r.Substring().NumericOID()),
 	} {
-		if !mr.IsZero() && !mr.Compliant() {
+		if !mr.IsZero() && !mr.compliant() {
 			return false
 		}
 	}
@@ -1846,19 +2122,14 @@ func (r AttributeType) Compliant() bool {
 			return false
 		}
 
-		if !sup.Compliant() {
+		if !sup.compliant() {
 			return false
 		}
 	}
 
 	// Any combination of SV/C is permitted
 	// EXCEPT for BOTH.  See RFC 3671.
-	ok := !(r.SingleValue() && collective)
-	if ok {
-		r.attributeType.err = nil
-	}
-
-	return ok
+	return !(r.SingleValue() && collective)
 }
 
 /*
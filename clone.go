@@ -0,0 +1,406 @@
+package schemax
+
+/*
+clone.go contains facilities for producing deep copies of Schema instances
+and of the definitions therein.
+*/
+
+/*
+Clone returns a fully independent (deep) copy of the receiver instance.
+
+All definitions are cloned, and all internal references -- such as those
+expressed through SUP, MUST, MAY, SYNTAX, EQUALITY, OC, FORM and APPLIES
+clauses -- are rewired to point to their cloned peers within the return
+instance.  The [Options], [Macros] and DN of the receiver are copied as
+well.
+
+Modifications made to the return instance, or to any [Definition] therein,
+will not influence the receiver instance in any way, and vice versa.  This
+is useful for "what if" scenarios, such as testing [AllowOverride]-based
+replacements, without corrupting a shared baseline [Schema].
+
+The receiver is read-locked for the duration of the operation, thus the
+return instance represents a consistent point-in-time copy.  See also the
+[Schema.Snapshot] method.
+
+Note that closures -- such as custom [Stringer], [SyntaxQualifier],
+[ValueQualifier] and [AssertionMatcher] instances -- as well as any
+user-assigned data, are copied by reference.
+*/
+func (r Schema) Clone() (c Schema) {
+	if r.IsZero() {
+		return
+	}
+
+	runlock := r.rlock()
+	defer runlock()
+
+	c = r.clone()
+
+	return
+}
+
+/*
+clone is the unlocked counterpart of Clone, for use where the lock of the
+receiver is already held.
+*/
+func (r Schema) clone() (c Schema) {
+	c = initSchema()
+	r.copySettings(c)
+
+	// First pass: clone each definition as-is, meaning
+	// all references still point to the receiver's own
+	// definitions.
+	for _, pair := range [][2]Definitions{
+		{r.LDAPSyntaxes(), c.LDAPSyntaxes()},
+		{r.MatchingRules(), c.MatchingRules()},
+		{r.AttributeTypes(), c.AttributeTypes()},
+		{r.MatchingRuleUses(), c.MatchingRuleUses()},
+		{r.ObjectClasses(), c.ObjectClasses()},
+		{r.DITContentRules(), c.DITContentRules()},
+		{r.NameForms(), c.NameForms()},
+		{r.DITStructureRules(), c.DITStructureRules()},
+	} {
+		for _, def := range collectionDefinitions(pair[0]) {
+			pair[1].cast().Push(cloneDefinition(def))
+		}
+	}
+
+	// Second pass: rewire all references such that
+	// they point to the newly cloned definitions. We
+	// do this separately, as definitions need not be
+	// ordered by dependency (e.g.: following use of
+	// the Replace method).
+	for _, def := range c.collectionMembers() {
+		rewireDefinition(def, c)
+	}
+
+	return
+}
+
+/*
+copySettings copies the [Options], [Macros] and DN of the receiver into c.
+*/
+func (r Schema) copySettings(c Schema) {
+	c.Options().cast().Shift(r.Options().cast().Int())
+	c.SetDN(r.DN())
+
+	macros := r.Macros()
+	for _, k := range macros.Keys() {
+		v, _ := macros.Resolve(k)
+		c.Macros().Set(k, v)
+	}
+}
+
+/*
+collectionMembers returns all definitions of all types present within
+the receiver instance.
+*/
+func (r Schema) collectionMembers() (defs []Definition) {
+	for _, coll := range []Definitions{
+		r.LDAPSyntaxes(),
+		r.MatchingRules(),
+		r.AttributeTypes(),
+		r.MatchingRuleUses(),
+		r.ObjectClasses(),
+		r.DITContentRules(),
+		r.NameForms(),
+		r.DITStructureRules(),
+	} {
+		defs = append(defs, collectionDefinitions(coll)...)
+	}
+
+	return
+}
+
+/*
+cloneDefinition returns a clone of def by way of its type-specific
+Clone method.
+*/
+func cloneDefinition(def Definition) (c Definition) {
+	switch tv := def.(type) {
+	case LDAPSyntax:
+		c = tv.Clone()
+	case MatchingRule:
+		c = tv.Clone()
+	case AttributeType:
+		c = tv.Clone()
+	case MatchingRuleUse:
+		c = tv.Clone()
+	case ObjectClass:
+		c = tv.Clone()
+	case DITContentRule:
+		c = tv.Clone()
+	case NameForm:
+		c = tv.Clone()
+	case DITStructureRule:
+		c = tv.Clone()
+	}
+
+	return
+}
+
+/*
+rewireDefinition associates def with sch, and points all references held
+by def to their counterparts within sch.
+*/
+func rewireDefinition(def Definition, sch Schema) {
+	switch tv := def.(type) {
+	case LDAPSyntax:
+		tv.lDAPSyntax.schema = sch
+	case MatchingRule:
+		tv.matchingRule.rewire(sch)
+	case AttributeType:
+		tv.attributeType.rewire(sch)
+	case MatchingRuleUse:
+		tv.matchingRuleUse.rewire(sch)
+	case ObjectClass:
+		tv.objectClass.rewire(sch)
+	case DITContentRule:
+		tv.dITContentRule.rewire(sch)
+	case NameForm:
+		tv.nameForm.rewire(sch)
+	case DITStructureRule:
+		tv.dITStructureRule.rewire(sch)
+	}
+}
+
+/*
+bindDefinition associates def with sch, leaving all references held by
+def as they are.
+*/
+func bindDefinition(def Definition, sch Schema) {
+	switch tv := def.(type) {
+	case LDAPSyntax:
+		tv.lDAPSyntax.schema = sch
+	case MatchingRule:
+		tv.matchingRule.schema = sch
+	case AttributeType:
+		tv.attributeType.schema = sch
+	case MatchingRuleUse:
+		tv.matchingRuleUse.schema = sch
+	case ObjectClass:
+		tv.objectClass.schema = sch
+	case DITContentRule:
+		tv.dITContentRule.schema = sch
+	case NameForm:
+		tv.nameForm.schema = sch
+	case DITStructureRule:
+		tv.dITStructureRule.schema = sch
+	}
+}
+
+/*
+cloneStrings returns an independent copy of x.
+*/
+func cloneStrings(x []string) (c []string) {
+	if x != nil {
+		c = make([]string, len(x))
+		copy(c, x)
+	}
+
+	return
+}
+
+/*
+cloneName returns an independent copy of x, assigned to def.
+*/
+func cloneName(x QuotedDescriptorList, def Definition) (c QuotedDescriptorList) {
+	c = NewName().setDefinition(def)
+	for i := 0; i < x.len(); i++ {
+		c.cast().Push(x.index(i))
+	}
+
+	return
+}
+
+/*
+cloneExtensions returns an independent copy of x, assigned to def.
+*/
+func cloneExtensions(x Extensions, def Definition) (c Extensions) {
+	c = NewExtensions()
+	c.setDefinition(def)
+	for i := 0; i < x.len(); i++ {
+		ext := x.index(i)
+		if ext.IsZero() {
+			continue
+		}
+
+		_ext := *ext.extension
+		_ext.Values = newQStringList(`extensions`)
+		for j := 0; j < ext.Values.len(); j++ {
+			_ext.Values.cast().Push(ext.Values.index(j))
+		}
+		c.cast().Push(Extension{&_ext})
+	}
+
+	return
+}
+
+/*
+cloneOIDList returns an independent copy of the receiver, which
+must be an OID list (e.g.: a MUST clause).  Members are NOT cloned.
+*/
+func (r AttributeTypes) cloneOIDList() (c AttributeTypes) {
+	c = NewAttributeTypeOIDList(r.cast().ID())
+	for i := 0; i < r.cast().Len(); i++ {
+		c.cast().Push(r.at(i))
+	}
+
+	return
+}
+
+/*
+cloneOIDList returns an independent copy of the receiver, which
+must be an OID list (e.g.: a SUP clause).  Members are NOT cloned.
+*/
+func (r ObjectClasses) cloneOIDList() (c ObjectClasses) {
+	c = NewObjectClassOIDList(r.cast().ID())
+	for i := 0; i < r.cast().Len(); i++ {
+		c.cast().Push(r.at(i))
+	}
+
+	return
+}
+
+/*
+cloneIDList returns an independent copy of the receiver, which must
+be a rule ID list (e.g.: a SUP clause).  Members are NOT cloned.
+*/
+func (r DITStructureRules) cloneIDList() (c DITStructureRules) {
+	c = NewDITStructureRuleIDList()
+	for i := 0; i < r.cast().Len(); i++ {
+		c.cast().Push(r.at(i))
+	}
+
+	return
+}
+
+/*
+counterpart returns the [LDAPSyntax] within sch bearing the same numeric
+OID as the receiver. If not found, or if the receiver is zero, the receiver
+is returned as-is.
+*/
+func (r LDAPSyntax) counterpart(sch Schema) LDAPSyntax {
+	if !r.IsZero() {
+		if ls := sch.LDAPSyntaxes().lookup(r.NumericOID()); !ls.IsZero() {
+			return ls
+		}
+	}
+
+	return r
+}
+
+/*
+counterpart returns the [MatchingRule] within sch bearing the same numeric
+OID as the receiver. If not found, or if the receiver is zero, the receiver
+is returned as-is.
+*/
+func (r MatchingRule) counterpart(sch Schema) MatchingRule {
+	if !r.IsZero() {
+		if mr := sch.MatchingRules().lookup(r.NumericOID()); !mr.IsZero() {
+			return mr
+		}
+	}
+
+	return r
+}
+
+/*
+counterpart returns the [AttributeType] within sch bearing the same numeric
+OID as the receiver. If not found, or if the receiver is zero, the receiver
+is returned as-is.
+*/
+func (r AttributeType) counterpart(sch Schema) AttributeType {
+	if !r.IsZero() {
+		if at := sch.AttributeTypes().lookup(r.NumericOID()); !at.IsZero() {
+			return at
+		}
+	}
+
+	return r
+}
+
+/*
+counterpart returns the [ObjectClass] within sch bearing the same numeric
+OID as the receiver. If not found, or if the receiver is zero, the receiver
+is returned as-is.
+*/
+func (r ObjectClass) counterpart(sch Schema) ObjectClass {
+	if !r.IsZero() {
+		if oc := sch.ObjectClasses().lookup(r.NumericOID()); !oc.IsZero() {
+			return oc
+		}
+	}
+
+	return r
+}
+
+/*
+counterpart returns the [NameForm] within sch bearing the same numeric
+OID as the receiver. If not found, or if the receiver is zero, the receiver
+is returned as-is.
+*/
+func (r NameForm) counterpart(sch Schema) NameForm {
+	if !r.IsZero() {
+		if nf := sch.NameForms().lookup(r.NumericOID()); !nf.IsZero() {
+			return nf
+		}
+	}
+
+	return r
+}
+
+/*
+counterpart returns the [DITStructureRule] within sch bearing the same rule
+ID as the receiver. If not found, or if the receiver is zero, the receiver
+is returned as-is.
+*/
+func (r DITStructureRule) counterpart(sch Schema) DITStructureRule {
+	if !r.IsZero() {
+		if ds := sch.DITStructureRules().lookup(r.RuleID()); !ds.IsZero() {
+			return ds
+		}
+	}
+
+	return r
+}
+
+/*
+counterparts returns a new OID list bearing the same label as the receiver,
+populated with the counterparts of each member found within sch.
+*/
+func (r AttributeTypes) counterparts(sch Schema) (c AttributeTypes) {
+	c = NewAttributeTypeOIDList(r.cast().ID())
+	for i := 0; i < r.cast().Len(); i++ {
+		c.cast().Push(r.at(i).counterpart(sch))
+	}
+
+	return
+}
+
+/*
+counterparts returns a new OID list bearing the same label as the receiver,
+populated with the counterparts of each member found within sch.
+*/
+func (r ObjectClasses) counterparts(sch Schema) (c ObjectClasses) {
+	c = NewObjectClassOIDList(r.cast().ID())
+	for i := 0; i < r.cast().Len(); i++ {
+		c.cast().Push(r.at(i).counterpart(sch))
+	}
+
+	return
+}
+
+/*
+counterparts returns a new rule ID list populated with the counterparts
+of each member found within sch.
+*/
+func (r DITStructureRules) counterparts(sch Schema) (c DITStructureRules) {
+	c = NewDITStructureRuleIDList()
+	for i := 0; i < r.cast().Len(); i++ {
+		c.cast().Push(r.at(i).counterpart(sch))
+	}
+
+	return
+}
@@ -0,0 +1,153 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the independence of a cloned [Schema] with
+respect to its source.
+*/
+func ExampleSchema_Clone() {
+	sch := NewSchema()
+	clone := sch.Clone()
+
+	clone.ObjectClasses().Get(`person`).SetMay(`mail`).SetStringer()
+
+	fmt.Println(sch.ObjectClasses().Get(`person`).May().Contains(`mail`))
+	fmt.Println(clone.ObjectClasses().Get(`person`).May().Contains(`mail`))
+	// Output:
+	// false
+	// true
+}
+
+/*
+This example demonstrates the independence of a cloned [AttributeType]
+with respect to its source.
+*/
+func ExampleAttributeType_Clone() {
+	sch := NewSchema()
+	name := sch.AttributeTypes().Get(`name`)
+	clone := name.Clone().SetName(`fakeName`)
+
+	fmt.Println(name.Names().Len(), clone.Names().Len())
+	// Output: 1 2
+}
+
+func TestSchema_Clone(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+	sch.Macros().Set(`1.3.6.1.4.1.56521.999`, `fakeMacro`)
+	sch.SetDN(`cn=subSchema`)
+
+	clone := sch.Clone()
+	if diff := sch.Diff(clone); !diff.IsZero() {
+		t.Errorf("%s failed: unexpected differences:\n%s", t.Name(), diff)
+		return
+	}
+
+	if got := sch.Counters(); got != clone.Counters() {
+		t.Errorf("%s failed: counters mismatch; want %v, got %v",
+			t.Name(), got, clone.Counters())
+		return
+	}
+
+	if clone.DN() != `cn=subSchema` {
+		t.Errorf("%s failed: unexpected DN %s", t.Name(), clone.DN())
+		return
+	}
+
+	if !clone.Options().Positive(AllowOverride) {
+		t.Errorf("%s failed: options not copied", t.Name())
+		return
+	}
+
+	if _, found := clone.Macros().Resolve(`1.3.6.1.4.1.56521.999`); !found {
+		t.Errorf("%s failed: macros not copied", t.Name())
+		return
+	}
+
+	// References must point to the cloned peers
+	cn := clone.AttributeTypes().Get(`cn`)
+	if cn.SuperType().attributeType != clone.AttributeTypes().Get(`name`).attributeType {
+		t.Errorf("%s failed: SUP not rewired", t.Name())
+		return
+	} else if cn.Schema().cast() != clone.cast() {
+		t.Errorf("%s failed: schema not rewired", t.Name())
+		return
+	}
+
+	person := clone.ObjectClasses().Get(`person`)
+	if person.Must().Index(0).attributeType == sch.AttributeTypes().Get(`sn`).attributeType {
+		t.Errorf("%s failed: MUST not rewired", t.Name())
+		return
+	}
+
+	for _, ds := range []DITStructureRules{clone.DITStructureRules()} {
+		for i := 0; i < ds.Len(); i++ {
+			if form := ds.Index(i).Form(); form.nameForm != clone.NameForms().Get(form.NumericOID()).nameForm {
+				t.Errorf("%s failed: FORM not rewired", t.Name())
+				return
+			}
+		}
+	}
+
+	mu := clone.MatchingRuleUses().Index(0)
+	if mu.matchingRuleUse.OID.matchingRule != clone.MatchingRules().Get(mu.NumericOID()).matchingRule {
+		t.Errorf("%s failed: MRU not rewired", t.Name())
+		return
+	}
+
+	// Modifications to the clone must not leak
+	clone.AttributeTypes().Get(`cn`).SetExtension(`X-ORIGIN`, `FAKE`)
+	if xo, _ := sch.AttributeTypes().Get(`cn`).Extensions().Get(`X-ORIGIN`); xo.Contains(`FAKE`) {
+		t.Errorf("%s failed: extension modification leaked", t.Name())
+		return
+	}
+
+	if err := clone.Remove(clone.ObjectClasses().Get(`applicationProcess`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if !sch.ObjectClasses().Contains(`applicationProcess`) {
+		t.Errorf("%s failed: removal leaked", t.Name())
+		return
+	}
+}
+
+func TestClone_codecov(t *testing.T) {
+	var sch Schema
+	_ = sch.Clone()
+
+	_ = LDAPSyntax{}.Clone()
+	_ = MatchingRule{}.Clone()
+	_ = AttributeType{}.Clone()
+	_ = MatchingRuleUse{}.Clone()
+	_ = ObjectClass{}.Clone()
+	_ = DITContentRule{}.Clone()
+	_ = NameForm{}.Clone()
+	_ = DITStructureRule{}.Clone()
+
+	sch = NewSchema()
+	if err := sch.ParseDITContentRule(`( 2.5.6.6
+		NAME 'personContentRule'
+		AUX simpleSecurityObject
+		NOT telephoneNumber )`); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	clone := sch.Clone()
+	dc := clone.DITContentRules().Get(`2.5.6.6`)
+	if dc.IsZero() || dc.dITContentRule.OID.objectClass != clone.ObjectClasses().Get(`person`).objectClass {
+		t.Errorf("%s failed: DCR not rewired", t.Name())
+	}
+	_ = cloneDefinition(nil)
+	rewireDefinition(nil, clone)
+
+	_ = LDAPSyntax{}.counterpart(clone)
+	_ = MatchingRule{}.counterpart(clone)
+	_ = AttributeType{}.counterpart(clone)
+	_ = ObjectClass{}.counterpart(clone)
+	_ = NameForm{}.counterpart(clone)
+	_ = DITStructureRule{}.counterpart(clone)
+}
@@ -0,0 +1,827 @@
+package schemax
+
+/*
+compliance.go contains facilities for the detailed reporting of the
+manners in which definitions fail to comply with RFC 4512 and related
+standards.
+*/
+
+/*
+Compliance violation codes.  These values are stable: codes are never
+renumbered or reassigned, and new codes are only ever added within the
+range allotted to the relevant definition type.
+*/
+const (
+	NilDefinitionViolation           uint = 1  // definition is nil or uninitialized
+	NumericOIDViolation              uint = 2  // numeric OID is missing or invalid
+	ReferenceViolation               uint = 3  // referenced definition is not compliant
+	UnknownReferenceViolation        uint = 4  // referenced definition is unknown or unset
+	NameFormConflictViolation        uint = 5  // NameForm MUST clause type precluded by DITContentRule NOT clause
+	MissingSyntaxViolation           uint = 20 // neither SUP nor SYNTAX clause is specified
+	CollectiveSuperTypeViolation     uint = 21 // non-COLLECTIVE type derived from COLLECTIVE super type
+	CollectiveSingleValueViolation   uint = 22 // COLLECTIVE type is SINGLE-VALUE
+	CollectiveUsageViolation         uint = 23 // COLLECTIVE type is operational
+	NoUserModificationUsageViolation uint = 24 // NO-USER-MODIFICATION type is not operational
+	UpperBoundsViolation             uint = 25 // minimum upper bounds on non-string syntax
+	CollectiveClauseViolation        uint = 30 // COLLECTIVE type in MUST or MAY clause
+	AbstractSuperClassViolation      uint = 31 // ABSTRACT class with non-ABSTRACT superclass
+	AuxiliarySuperClassViolation     uint = 32 // AUXILIARY class with STRUCTURAL superclass
+	StructuralSuperClassViolation    uint = 33 // STRUCTURAL class with AUXILIARY superclass
+	ContentRuleClassViolation        uint = 40 // DITContentRule OID is not a STRUCTURAL class
+	ContentRuleAuxiliaryViolation    uint = 41 // AUX clause class is not AUXILIARY
+	ContentRuleMustViolation         uint = 42 // MUST clause type not permitted by governed classes
+	ContentRuleMayViolation          uint = 43 // MAY clause type not permitted by governed classes
+	ContentRuleRequiredMayViolation  uint = 44 // MAY clause type already required by governed classes
+	ContentRuleNotViolation          uint = 45 // NOT clause precludes a required type
+	NameFormClassViolation           uint = 50 // OC clause is not a STRUCTURAL class
+	NameFormMustViolation            uint = 51 // MUST clause is empty
+)
+
+var complianceViolationLabels map[uint]string = map[uint]string{
+	NilDefinitionViolation:           `definition is nil or uninitialized`,
+	NumericOIDViolation:              `numeric OID is missing or invalid`,
+	ReferenceViolation:               `referenced definition is not compliant`,
+	UnknownReferenceViolation:        `referenced definition is unknown or unset`,
+	NameFormConflictViolation:        `NameForm MUST clause type precluded by DITContentRule NOT clause`,
+	MissingSyntaxViolation:           `neither SUP nor SYNTAX clause specified`,
+	CollectiveSuperTypeViolation:     `non-COLLECTIVE type with COLLECTIVE super type`,
+	CollectiveSingleValueViolation:   `COLLECTIVE type is SINGLE-VALUE`,
+	CollectiveUsageViolation:         `COLLECTIVE type is operational`,
+	NoUserModificationUsageViolation: `NO-USER-MODIFICATION type is not operational`,
+	UpperBoundsViolation:             `MUB on non-string syntax`,
+	CollectiveClauseViolation:        `COLLECTIVE type in MUST or MAY clause`,
+	AbstractSuperClassViolation:      `ABSTRACT class with non-ABSTRACT superclass`,
+	AuxiliarySuperClassViolation:     `AUXILIARY class with STRUCTURAL superclass`,
+	StructuralSuperClassViolation:    `STRUCTURAL class with AUXILIARY superclass`,
+	ContentRuleClassViolation:        `DITContentRule OID is not a STRUCTURAL class`,
+	ContentRuleAuxiliaryViolation:    `AUX clause class is not AUXILIARY`,
+	ContentRuleMustViolation:         `MUST clause type not permitted by governed classes`,
+	ContentRuleMayViolation:          `MAY clause type not permitted by governed classes`,
+	ContentRuleRequiredMayViolation:  `MAY clause type already required by governed classes`,
+	ContentRuleNotViolation:          `NOT clause precludes a required type`,
+	NameFormClassViolation:           `OC clause is not a STRUCTURAL class`,
+	NameFormMustViolation:            `MUST clause is empty`,
+}
+
+/*
+complianceViolationSections contains the standard sections relevant to
+codes which are specific to a particular rule.  Codes absent from this
+map fall back to the section describing the definition type.
+*/
+var complianceViolationSections map[uint]string = map[uint]string{
+	NameFormConflictViolation:        `RFC 4512 § 4.1.6`,
+	CollectiveSuperTypeViolation:     `RFC 3671`,
+	CollectiveSingleValueViolation:   `RFC 3671`,
+	CollectiveUsageViolation:         `RFC 3671`,
+	NoUserModificationUsageViolation: `RFC 4512 § 4.1.2`,
+	UpperBoundsViolation:             `RFC 4512 § 4.1.2`,
+	CollectiveClauseViolation:        `RFC 3671`,
+	AbstractSuperClassViolation:      `RFC 4512 § 2.4.1`,
+	StructuralSuperClassViolation:    `RFC 4512 § 2.4.2`,
+	AuxiliarySuperClassViolation:     `RFC 4512 § 2.4.3`,
+}
+
+var definitionSections map[string]string = map[string]string{
+	`ldapSyntax`:       `RFC 4512 § 4.1.5`,
+	`matchingRule`:     `RFC 4512 § 4.1.3`,
+	`attributeType`:    `RFC 4512 § 4.1.2`,
+	`matchingRuleUse`:  `RFC 4512 § 4.1.4`,
+	`objectClass`:      `RFC 4512 § 4.1.1`,
+	`dITContentRule`:   `RFC 4512 § 4.1.6`,
+	`nameForm`:         `RFC 4512 § 4.1.7.2`,
+	`dITStructureRule`: `RFC 4512 § 4.1.7.1`,
+}
+
+/*
+lengthBoundedSyntaxes contains the numeric OIDs of the RFC 4517 syntaxes
+for which a minimum upper bounds -- a limit on the number of characters
+or octets -- is meaningful.
+*/
+var lengthBoundedSyntaxes []string = []string{
+	`1.3.6.1.4.1.1466.115.121.1.4`,  // Audio
+	`1.3.6.1.4.1.1466.115.121.1.5`,  // Binary
+	`1.3.6.1.4.1.1466.115.121.1.6`,  // Bit String
+	`1.3.6.1.4.1.1466.115.121.1.11`, // Country String
+	`1.3.6.1.4.1.1466.115.121.1.15`, // Directory String
+	`1.3.6.1.4.1.1466.115.121.1.22`, // Facsimile Telephone Number
+	`1.3.6.1.4.1.1466.115.121.1.23`, // Fax
+	`1.3.6.1.4.1.1466.115.121.1.26`, // IA5 String
+	`1.3.6.1.4.1.1466.115.121.1.28`, // JPEG
+	`1.3.6.1.4.1.1466.115.121.1.36`, // Numeric String
+	`1.3.6.1.4.1.1466.115.121.1.39`, // Other Mailbox
+	`1.3.6.1.4.1.1466.115.121.1.40`, // Octet String
+	`1.3.6.1.4.1.1466.115.121.1.41`, // Postal Address
+	`1.3.6.1.4.1.1466.115.121.1.44`, // Printable String
+	`1.3.6.1.4.1.1466.115.121.1.50`, // Telephone Number
+	`1.3.6.1.4.1.1466.115.121.1.52`, // Telex Number
+	`1.3.6.1.4.1.1466.115.121.1.58`, // Substring Assertion
+}
+
+/*
+isLengthBounded returns a Boolean value indicative of a minimum upper
+bounds being meaningful for the syntax bearing numeric OID noid.  Only
+the syntaxes of RFC 4517 are judged; all others are given the benefit
+of the doubt.
+*/
+func isLengthBounded(noid string) bool {
+	return !hasPfx(noid, `1.3.6.1.4.1.1466.115.121.1.`) ||
+		strInSlice(noid, lengthBoundedSyntaxes)
+}
+
+/*
+ComplianceReport implements slices of [ComplianceViolation], collectively
+describing the manners in which one or more definitions fail to comply
+with RFC 4512 and related standards.  Instances of this type are produced
+by the Check methods extended by all definition types, their collections
+and [Schema].
+
+A zero [ComplianceReport] indicates that no violations were found.
+
+Note that Check is more thorough than Compliant: a definition judged
+compliant by its Compliant method may nonetheless be reported upon for
+rules which Compliant does not enforce, such as those which govern the
+kinds of superclasses an [ObjectClass] may bear.
+*/
+type ComplianceReport []ComplianceViolation
+
+/*
+ComplianceViolation describes a single manner in which a definition fails
+to comply with RFC 4512 and related standards.
+
+Code is one of the stable *Violation constants, such as [ReferenceViolation]
+or [AuxiliarySuperClassViolation].  Type and Definition contain the type
+(see the Type method of any definition) and principal identifier of the
+offending definition.  Clause contains the offending clause, such as "SUP"
+or "MUST", if applicable.  Value contains the offending clause value, such
+as the identifier of a superclass, if applicable.
+*/
+type ComplianceViolation struct {
+	Code       uint
+	Type       string
+	Definition string
+	Clause     string
+	Value      string
+}
+
+/*
+Message returns the human-readable description of the receiver's code,
+e.g.: "AUXILIARY class with STRUCTURAL superclass".
+*/
+func (r ComplianceViolation) Message() string {
+	return complianceViolationLabels[r.Code]
+}
+
+/*
+Section returns the standard and section -- e.g.: "RFC 4512 § 2.4.3" --
+which describes the rule violated by the receiver instance.
+*/
+func (r ComplianceViolation) Section() (s string) {
+	var found bool
+	if s, found = complianceViolationSections[r.Code]; !found {
+		s = definitionSections[r.Type]
+	}
+
+	return
+}
+
+/*
+Error returns the string representation of the receiver instance, e.g.:
+
+	objectClass 'myAux': SUP 'person': AUXILIARY class with STRUCTURAL superclass (RFC 4512 § 2.4.3)
+
+This method satisfies the error interface.
+*/
+func (r ComplianceViolation) Error() (s string) {
+	s = r.Type
+	if len(r.Definition) > 0 {
+		s += ` '` + r.Definition + `'`
+	}
+	if len(r.Clause) > 0 {
+		s += `: ` + r.Clause
+		if len(r.Value) > 0 {
+			s += ` '` + r.Value + `'`
+		}
+	}
+	s += `: ` + r.Message()
+	if sect := r.Section(); len(sect) > 0 {
+		s += ` (` + sect + `)`
+	}
+
+	return
+}
+
+/*
+Unwrap returns [ErrDefNonCompliant], allowing the receiver instance to be
+matched through [errors.Is].
+*/
+func (r ComplianceViolation) Unwrap() error {
+	return ErrDefNonCompliant
+}
+
+/*
+Len returns the integer length of the receiver instance.
+*/
+func (r ComplianceReport) Len() int {
+	return len(r)
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state, which
+indicates that no violations were found.
+*/
+func (r ComplianceReport) IsZero() bool {
+	return r.Len() == 0
+}
+
+/*
+Code returns all [ComplianceViolation] slices bearing the specified code.
+*/
+func (r ComplianceReport) Code(code uint) (v ComplianceReport) {
+	for i := 0; i < r.Len(); i++ {
+		if r[i].Code == code {
+			v = append(v, r[i])
+		}
+	}
+
+	return
+}
+
+/*
+Err returns all slices within the receiver instance in the form of a
+single error instance, or nil if the receiver is zero.
+*/
+func (r ComplianceReport) Err() error {
+	var errs []error
+	for i := 0; i < r.Len(); i++ {
+		errs = append(errs, r[i])
+	}
+
+	return joinErr(errs...)
+}
+
+/*
+String returns the string representation of the receiver instance, with
+each slice appearing on its own line.
+*/
+func (r ComplianceReport) String() string {
+	var lines []string
+	for i := 0; i < r.Len(); i++ {
+		lines = append(lines, r[i].Error())
+	}
+
+	return join(lines, string(rune(10)))
+}
+
+/*
+add appends a new [ComplianceViolation] describing def to the receiver.
+*/
+func (r *ComplianceReport) add(def Definition, code uint, clause, value string) {
+	*r = append(*r, ComplianceViolation{
+		Code:       code,
+		Type:       def.Type(),
+		Definition: def.Identifier(),
+		Clause:     clause,
+		Value:      value,
+	})
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the definitions of the receiver instance fail to comply with RFC 4512
+and related standards.  Collections are checked in the following order:
+
+  - [LDAPSyntaxes]
+  - [MatchingRules]
+  - [AttributeTypes]
+  - [MatchingRuleUses]
+  - [ObjectClasses]
+  - [DITContentRules]
+  - [NameForms]
+  - [DITStructureRules]
+
+A zero [ComplianceReport] is returned if the receiver is nil.
+*/
+func (r Schema) Check() (report ComplianceReport) {
+	if r.IsZero() {
+		return
+	}
+
+	for _, defs := range []Checker{
+		r.LDAPSyntaxes(),
+		r.MatchingRules(),
+		r.AttributeTypes(),
+		r.MatchingRuleUses(),
+		r.ObjectClasses(),
+		r.DITContentRules(),
+		r.NameForms(),
+		r.DITStructureRules(),
+	} {
+		report = append(report, defs.Check()...)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the [LDAPSyntax] slices of the receiver instance fail to comply. See
+[LDAPSyntax.Check] for details.
+*/
+func (r LDAPSyntaxes) Check() (report ComplianceReport) {
+	for i := 0; i < r.Len(); i++ {
+		report = append(report, r.Index(i).Check()...)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the receiver instance fails to comply with [§ 4.1.5 of RFC 4512].
+See [LDAPSyntax.Compliant] for the rules enforced.
+
+[§ 4.1.5 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.5
+*/
+func (r LDAPSyntax) Check() (report ComplianceReport) {
+	if r.IsZero() {
+		report.add(r, NilDefinitionViolation, ``, ``)
+	} else if !isNumericOID(r.NumericOID()) {
+		report.add(r, NumericOIDViolation, `NUMERICOID`, r.NumericOID())
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the [MatchingRule] slices of the receiver instance fail to comply.
+See [MatchingRule.Check] for details.
+*/
+func (r MatchingRules) Check() (report ComplianceReport) {
+	for i := 0; i < r.Len(); i++ {
+		report = append(report, r.Index(i).Check()...)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the receiver instance fails to comply with [§ 4.1.3 of RFC 4512].
+See [MatchingRule.Compliant] for the rules enforced.
+
+[§ 4.1.3 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.3
+*/
+func (r MatchingRule) Check() (report ComplianceReport) {
+	if r.IsZero() {
+		report.add(r, NilDefinitionViolation, ``, ``)
+		return
+	}
+
+	if !isNumericOID(r.NumericOID()) {
+		report.add(r, NumericOIDViolation, `NUMERICOID`, r.NumericOID())
+	}
+
+	if syn := r.Syntax(); syn.IsZero() {
+		report.add(r, UnknownReferenceViolation, `SYNTAX`, ``)
+	} else if !syn.compliant() {
+		report.add(r, ReferenceViolation, `SYNTAX`, syn.NumericOID())
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the [AttributeType] slices of the receiver instance fail to comply.
+See [AttributeType.Check] for details.
+*/
+func (r AttributeTypes) Check() (report ComplianceReport) {
+	for i := 0; i < r.Len(); i++ {
+		report = append(report, r.Index(i).Check()...)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the receiver instance fails to comply with [§ 4.1.2 of RFC 4512] and
+RFC 3671.  In addition to the rules enforced by [AttributeType.Compliant],
+the following are verified:
+
+  - At least one (1) of the SUP or SYNTAX clauses must be specified
+  - COLLECTIVE types must bear the userApplications USAGE
+  - NO-USER-MODIFICATION types must bear an operational USAGE
+  - Minimum upper bounds may only accompany a SYNTAX for which a length limit is meaningful
+
+[§ 4.1.2 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.2
+*/
+func (r AttributeType) Check() (report ComplianceReport) {
+	if r.IsZero() {
+		report.add(r, NilDefinitionViolation, ``, ``)
+		return
+	}
+
+	if !isNumericOID(r.NumericOID()) {
+		report.add(r, NumericOIDViolation, `NUMERICOID`, r.NumericOID())
+	}
+
+	syn, sup := r.Syntax(), r.SuperType()
+	if syn.IsZero() && sup.IsZero() {
+		report.add(r, MissingSyntaxViolation, `SYNTAX`, ``)
+	} else if !syn.IsZero() {
+		if !syn.compliant() {
+			report.add(r, ReferenceViolation, `SYNTAX`, syn.NumericOID())
+		}
+		if mub := r.MinimumUpperBounds(); mub > 0 && !isLengthBounded(syn.NumericOID()) {
+			report.add(r, UpperBoundsViolation, `SYNTAX`,
+				syn.NumericOID()+`{`+itoa(int(mub))+`}`)
+		}
+	}
+
+	for i, mr := range []MatchingRule{
+		r.Equality(),
+		r.Ordering(),
+		r.Substring(),
+	} {
+		if !mr.IsZero() && !mr.compliant() {
+			clause := []string{`EQUALITY`, `ORDERING`, `SUBSTR`}[i]
+			report.add(r, ReferenceViolation, clause, mr.OID())
+		}
+	}
+
+	collective := r.Collective()
+	if !sup.IsZero() {
+		if sup.Collective() && !collective {
+			report.add(r, CollectiveSuperTypeViolation, `SUP`, sup.OID())
+		}
+		if !sup.compliant() {
+			report.add(r, ReferenceViolation, `SUP`, sup.OID())
+		}
+	}
+
+	operational := len(r.Usage()) > 0
+	if collective && r.SingleValue() {
+		report.add(r, CollectiveSingleValueViolation, `COLLECTIVE`, ``)
+	}
+	if collective && operational {
+		report.add(r, CollectiveUsageViolation, `USAGE`, r.Usage())
+	}
+	if r.NoUserModification() && !operational {
+		report.add(r, NoUserModificationUsageViolation, `NO-USER-MODIFICATION`, ``)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the [MatchingRuleUse] slices of the receiver instance fail to comply.
+See [MatchingRuleUse.Check] for details.
+*/
+func (r MatchingRuleUses) Check() (report ComplianceReport) {
+	for i := 0; i < r.Len(); i++ {
+		report = append(report, r.Index(i).Check()...)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the receiver instance fails to comply with [§ 4.1.4 of RFC 4512].
+See [MatchingRuleUse.Compliant] for the rules enforced.
+
+[§ 4.1.4 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.4
+*/
+func (r MatchingRuleUse) Check() (report ComplianceReport) {
+	if r.IsZero() {
+		report.add(r, NilDefinitionViolation, ``, ``)
+		return
+	}
+
+	if !isNumericOID(r.NumericOID()) {
+		report.add(r, NumericOIDViolation, `NUMERICOID`, r.NumericOID())
+	} else if r.Schema().MatchingRules().get(r.NumericOID()).IsZero() {
+		report.add(r, UnknownReferenceViolation, `NUMERICOID`, r.NumericOID())
+	}
+
+	appl := r.Applies()
+	for i := 0; i < appl.Len(); i++ {
+		if at := appl.Index(i); !at.compliant() {
+			report.add(r, ReferenceViolation, `APPLIES`, at.OID())
+		}
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the [ObjectClass] slices of the receiver instance fail to comply.
+See [ObjectClass.Check] for details.
+*/
+func (r ObjectClasses) Check() (report ComplianceReport) {
+	for i := 0; i < r.Len(); i++ {
+		report = append(report, r.Index(i).Check()...)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the receiver instance fails to comply with [§ 4.1.1 of RFC 4512] and
+RFC 3671.  In addition to the rules enforced by [ObjectClass.Compliant],
+the following are verified per [§ 2.4 of RFC 4512]:
+
+  - Superclasses must be COMPLIANT
+  - ABSTRACT classes may only extend from ABSTRACT classes
+  - AUXILIARY classes may not extend from STRUCTURAL classes
+  - STRUCTURAL classes may not extend from AUXILIARY classes
+
+[§ 4.1.1 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.1
+[§ 2.4 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-2.4
+*/
+func (r ObjectClass) Check() (report ComplianceReport) {
+	if r.IsZero() {
+		report.add(r, NilDefinitionViolation, ``, ``)
+		return
+	}
+
+	if !isNumericOID(r.NumericOID()) {
+		report.add(r, NumericOIDViolation, `NUMERICOID`, r.NumericOID())
+	}
+
+	kind := r.Kind()
+	sups := r.SuperClasses()
+	for i := 0; i < sups.Len(); i++ {
+		sup := sups.Index(i)
+		if !sup.compliant() {
+			report.add(r, ReferenceViolation, `SUP`, sup.OID())
+		}
+
+		switch sk := sup.Kind(); {
+		case kind == AbstractKind && sk != AbstractKind:
+			report.add(r, AbstractSuperClassViolation, `SUP`, sup.OID())
+		case kind == AuxiliaryKind && sk == StructuralKind:
+			report.add(r, AuxiliarySuperClassViolation, `SUP`, sup.OID())
+		case kind == StructuralKind && sk == AuxiliaryKind:
+			report.add(r, StructuralSuperClassViolation, `SUP`, sup.OID())
+		}
+	}
+
+	for j, types := range []AttributeTypes{r.Must(), r.May()} {
+		clause := []string{`MUST`, `MAY`}[j]
+		for i := 0; i < types.Len(); i++ {
+			at := types.Index(i)
+			if !at.compliant() {
+				report.add(r, ReferenceViolation, clause, at.OID())
+			}
+			if at.Collective() {
+				report.add(r, CollectiveClauseViolation, clause, at.OID())
+			}
+		}
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the [DITContentRule] slices of the receiver instance fail to comply.
+See [DITContentRule.Check] for details.
+*/
+func (r DITContentRules) Check() (report ComplianceReport) {
+	for i := 0; i < r.Len(); i++ {
+		report = append(report, r.Index(i).Check()...)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the receiver instance fails to comply with [§ 4.1.6 of RFC 4512].
+See [DITContentRule.Compliant] for the rules enforced.
+
+[§ 4.1.6 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.6
+*/
+func (r DITContentRule) Check() (report ComplianceReport) {
+	if r.IsZero() {
+		report.add(r, NilDefinitionViolation, ``, ``)
+		return
+	}
+
+	structural := r.StructuralClass()
+	if structural.IsZero() {
+		report.add(r, UnknownReferenceViolation, `NUMERICOID`, ``)
+		return
+	} else if structural.Kind() != StructuralKind {
+		report.add(r, ContentRuleClassViolation, `NUMERICOID`, structural.OID())
+	} else if !structural.compliant() {
+		report.add(r, ReferenceViolation, `NUMERICOID`, structural.OID())
+	}
+
+	musts, mays := r.permitted(structural)
+
+	aux := r.Aux()
+	for i := 0; i < aux.Len(); i++ {
+		if aoc := aux.Index(i); aoc.Kind() != AuxiliaryKind {
+			report.add(r, ContentRuleAuxiliaryViolation, `AUX`, aoc.OID())
+		} else if !aoc.compliant() {
+			report.add(r, ReferenceViolation, `AUX`, aoc.OID())
+		}
+	}
+
+	report = append(report, r.checkClauses(musts, mays)...)
+
+	dsr := r.Schema().DITStructureRules()
+	for i := 0; i < dsr.Len(); i++ {
+		form := dsr.Index(i).Form()
+		if form.OC().NumericOID() == structural.NumericOID() {
+			clause := form.Must()
+			for j := 0; j < clause.Len(); j++ {
+				if at := clause.Index(j); r.Not().Contains(at.OID()) {
+					report.add(r, NameFormConflictViolation, `NOT`, at.OID())
+				}
+			}
+			break // per X.501, only one rule applies per schema
+		}
+	}
+
+	return
+}
+
+/*
+permitted returns the [AttributeType] instances required and allowed by
+structural, its ABSTRACT superclasses and the AUXILIARY classes of the
+receiver instance.
+*/
+func (r DITContentRule) permitted(structural ObjectClass) (musts, mays AttributeTypes) {
+	musts = NewAttributeTypeOIDList()
+	mays = NewAttributeTypeOIDList()
+
+	classes := []ObjectClass{structural}
+	abstracts := structural.SuperClasses()
+	for i := 0; i < abstracts.Len(); i++ {
+		if abs := abstracts.Index(i); abs.Kind() == AbstractKind {
+			classes = append(classes, abs)
+		}
+	}
+
+	aux := r.Aux()
+	for i := 0; i < aux.Len(); i++ {
+		classes = append(classes, aux.Index(i))
+	}
+
+	for _, oc := range classes {
+		must, may := oc.Must(), oc.May()
+		for j := 0; j < must.Len(); j++ {
+			musts.Push(must.Index(j))
+		}
+		for j := 0; j < may.Len(); j++ {
+			mays.Push(may.Index(j))
+		}
+	}
+
+	return
+}
+
+/*
+checkClauses returns an instance of [ComplianceReport] describing the MUST,
+MAY and NOT clause members of the receiver which conflict with musts and
+mays.  COLLECTIVE types are exempt.
+*/
+func (r DITContentRule) checkClauses(musts, mays AttributeTypes) (report ComplianceReport) {
+	not := r.Not()
+	for i := 0; i < not.Len(); i++ {
+		if no := not.Index(i); !no.Collective() &&
+			musts.Contains(no.OID()) && !mays.Contains(no.OID()) {
+			report.add(r, ContentRuleNotViolation, `NOT`, no.OID())
+		}
+	}
+
+	must := r.Must()
+	for i := 0; i < must.Len(); i++ {
+		if at := must.Index(i); !at.Collective() &&
+			!musts.Contains(at.OID()) && !mays.Contains(at.OID()) {
+			report.add(r, ContentRuleMustViolation, `MUST`, at.OID())
+		}
+	}
+
+	may := r.May()
+	for i := 0; i < may.Len(); i++ {
+		if at := may.Index(i); at.Collective() {
+			continue
+		} else if musts.Contains(at.OID()) {
+			report.add(r, ContentRuleRequiredMayViolation, `MAY`, at.OID())
+		} else if !mays.Contains(at.OID()) {
+			report.add(r, ContentRuleMayViolation, `MAY`, at.OID())
+		}
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the [NameForm] slices of the receiver instance fail to comply. See
+[NameForm.Check] for details.
+*/
+func (r NameForms) Check() (report ComplianceReport) {
+	for i := 0; i < r.Len(); i++ {
+		report = append(report, r.Index(i).Check()...)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the receiver instance fails to comply with [§ 4.1.7.2 of RFC 4512].
+In addition to the rules enforced by [NameForm.Compliant], the OC clause
+is verified as referencing a STRUCTURAL [ObjectClass].
+
+[§ 4.1.7.2 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.7.2
+*/
+func (r NameForm) Check() (report ComplianceReport) {
+	if r.IsZero() {
+		report.add(r, NilDefinitionViolation, ``, ``)
+		return
+	}
+
+	if !isNumericOID(r.NumericOID()) {
+		report.add(r, NumericOIDViolation, `NUMERICOID`, r.NumericOID())
+	}
+
+	if oc := r.OC(); oc.IsZero() {
+		report.add(r, UnknownReferenceViolation, `OC`, ``)
+	} else if oc.Kind() != StructuralKind {
+		report.add(r, NameFormClassViolation, `OC`, oc.OID())
+	} else if !oc.compliant() {
+		report.add(r, ReferenceViolation, `OC`, oc.OID())
+	}
+
+	if r.Must().Len() == 0 {
+		report.add(r, NameFormMustViolation, `MUST`, ``)
+	}
+
+	for j, types := range []AttributeTypes{r.Must(), r.May()} {
+		clause := []string{`MUST`, `MAY`}[j]
+		for i := 0; i < types.Len(); i++ {
+			if at := types.Index(i); !at.compliant() {
+				report.add(r, ReferenceViolation, clause, at.OID())
+			}
+		}
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the [DITStructureRule] slices of the receiver instance fail to comply.
+See [DITStructureRule.Check] for details.
+*/
+func (r DITStructureRules) Check() (report ComplianceReport) {
+	for i := 0; i < r.Len(); i++ {
+		report = append(report, r.Index(i).Check()...)
+	}
+
+	return
+}
+
+/*
+Check returns an instance of [ComplianceReport] describing the manners in
+which the receiver instance fails to comply with [§ 4.1.7.1 of RFC 4512].
+See [DITStructureRule.Compliant] for the rules enforced.
+
+[§ 4.1.7.1 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.7.1
+*/
+func (r DITStructureRule) Check() (report ComplianceReport) {
+	if r.IsZero() {
+		report.add(r, NilDefinitionViolation, ``, ``)
+		return
+	}
+
+	form := r.Form()
+	if form.IsZero() {
+		report.add(r, UnknownReferenceViolation, `FORM`, ``)
+		return
+	} else if !form.compliant() {
+		report.add(r, ReferenceViolation, `FORM`, form.OID())
+	}
+
+	if dc := r.Schema().DITContentRules().Get(form.OC().OID()); !dc.IsZero() {
+		clause := form.Must()
+		for i := 0; i < clause.Len(); i++ {
+			if at := clause.Index(i); dc.Not().Contains(at.OID()) {
+				report.add(r, NameFormConflictViolation, `FORM`, at.OID())
+			}
+		}
+	}
+
+	return
+}
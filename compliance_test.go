@@ -0,0 +1,210 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the use of [ObjectClass.Check] to learn why a
+definition fails to comply with RFC 4512.
+*/
+func ExampleObjectClass_Check() {
+	sch := NewSchema()
+	if err := sch.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.88.1
+		NAME 'badAux'
+		SUP person
+		AUXILIARY )`); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	report := sch.ObjectClasses().Get(`badAux`).Check()
+	fmt.Println(report[0].Code, report[0].Clause, report[0].Value)
+	fmt.Println(report[0].Message())
+	fmt.Println(report[0].Section())
+	// Output:
+	// 32 SUP person
+	// AUXILIARY class with STRUCTURAL superclass
+	// RFC 4512 § 2.4.3
+}
+
+/*
+This example demonstrates the use of [Schema.Check] to verify that all
+definitions within a [Schema] comply with RFC 4512.
+*/
+func ExampleSchema_Check() {
+	sch := NewSchema()
+	fmt.Println(sch.Check().IsZero())
+	// Output: true
+}
+
+func TestSchema_Check(t *testing.T) {
+	sch := NewSchema()
+	if report := sch.Check(); !report.IsZero() {
+		t.Errorf("%s failed: unexpected violations:\n%s", t.Name(), report)
+	}
+
+	for idx, tc := range []struct {
+		parse  func(string) error
+		def    string
+		get    func() Checker
+		code   uint
+		clause string
+		value  string
+	}{
+		{
+			sch.ParseAttributeType,
+			`( 1.3.6.1.4.1.56521.999.88.10 NAME 'mubInteger' SYNTAX 1.3.6.1.4.1.1466.115.121.1.27{32} )`,
+			func() Checker { return sch.AttributeTypes().Get(`mubInteger`) },
+			UpperBoundsViolation, `SYNTAX`, `1.3.6.1.4.1.1466.115.121.1.27{32}`,
+		},
+		{
+			sch.ParseAttributeType,
+			`( 1.3.6.1.4.1.56521.999.88.11 NAME 'userNoMod' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 NO-USER-MODIFICATION )`,
+			func() Checker { return sch.AttributeTypes().Get(`userNoMod`) },
+			NoUserModificationUsageViolation, `NO-USER-MODIFICATION`, ``,
+		},
+		{
+			sch.ParseAttributeType,
+			`( 1.3.6.1.4.1.56521.999.88.12 NAME 'opCollective' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 COLLECTIVE USAGE dSAOperation )`,
+			func() Checker { return sch.AttributeTypes().Get(`opCollective`) },
+			CollectiveUsageViolation, `USAGE`, `dSAOperation`,
+		},
+		{
+			sch.ParseObjectClass,
+			`( 1.3.6.1.4.1.56521.999.88.20 NAME 'badStructural' SUP posixAccount STRUCTURAL )`,
+			func() Checker { return sch.ObjectClasses().Get(`badStructural`) },
+			StructuralSuperClassViolation, `SUP`, `posixAccount`,
+		},
+		{
+			sch.ParseObjectClass,
+			`( 1.3.6.1.4.1.56521.999.88.21 NAME 'badAbstract' SUP person ABSTRACT )`,
+			func() Checker { return sch.ObjectClasses().Get(`badAbstract`) },
+			AbstractSuperClassViolation, `SUP`, `person`,
+		},
+		{
+			sch.ParseNameForm,
+			`( 1.3.6.1.4.1.56521.999.88.30 NAME 'auxForm' OC posixAccount MUST uid )`,
+			func() Checker { return sch.NameForms().Get(`auxForm`) },
+			NameFormClassViolation, `OC`, `posixAccount`,
+		},
+	} {
+		if err := tc.parse(tc.def); err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+			continue
+		}
+
+		report := tc.get().Check().Code(tc.code)
+		if report.Len() != 1 {
+			t.Errorf("%s[%d] failed: want code %d, got:\n%s", t.Name(), idx, tc.code, tc.get().Check())
+		} else if report[0].Clause != tc.clause || report[0].Value != tc.value {
+			t.Errorf("%s[%d] failed: want %s '%s', got %s '%s'",
+				t.Name(), idx, tc.clause, tc.value, report[0].Clause, report[0].Value)
+		} else if !errors.Is(report.Err(), ErrDefNonCompliant) {
+			t.Errorf("%s[%d] failed: %v is not %v", t.Name(), idx, report.Err(), ErrDefNonCompliant)
+		}
+	}
+
+	dc := sch.NewDITContentRule().
+		SetNumericOID(`2.5.6.6`).
+		SetAux(`posixAccount`).
+		SetMay(`sn`, `mail`).
+		SetNot(`cn`)
+	dc.dITContentRule.Aux.push(sch.ObjectClasses().Get(`account`))
+	dc.dITContentRule.Must.push(sch.AttributeTypes().Get(`mobile`))
+	for _, code := range []uint{
+		ContentRuleAuxiliaryViolation,
+		ContentRuleMustViolation,
+		ContentRuleMayViolation,
+		ContentRuleRequiredMayViolation,
+		ContentRuleNotViolation,
+	} {
+		if report := dc.Check(); report.Code(code).Len() != 1 {
+			t.Errorf("%s failed: want code %d, got:\n%s", t.Name(), code, report)
+		}
+	}
+
+	if report := sch.Check(); report.Len() != 6 {
+		t.Errorf("%s failed: want 6 violations, got:\n%s", t.Name(), report)
+	}
+}
+
+func TestComplianceReport_codecov(t *testing.T) {
+	var report ComplianceReport
+	_ = report.Err()
+	_ = report.String()
+
+	var sch Schema
+	if !sch.Check().IsZero() {
+		t.Errorf("%s failed: unexpected violations for nil Schema", t.Name())
+	}
+
+	for _, def := range []Definition{
+		LDAPSyntax{},
+		MatchingRule{},
+		AttributeType{},
+		MatchingRuleUse{},
+		ObjectClass{},
+		DITContentRule{},
+		NameForm{},
+		DITStructureRule{},
+	} {
+		if report = def.(Checker).Check(); report.Code(NilDefinitionViolation).Len() != 1 {
+			t.Errorf("%s failed: want nil violation for %s, got %v", t.Name(), def.Type(), report)
+		}
+	}
+
+	v := ComplianceViolation{
+		Code:       ReferenceViolation,
+		Type:       `attributeType`,
+		Definition: `cn`,
+		Clause:     `SUP`,
+		Value:      `name`,
+	}
+	want := `attributeType 'cn': SUP 'name': referenced definition is not compliant (RFC 4512 § 4.1.2)`
+	if got := v.Error(); got != want {
+		t.Errorf("%s failed:\nwant: %s\ngot:  %s", t.Name(), want, got)
+	}
+
+	sch = NewSchema()
+	sch.MatchingRuleUses().Check()
+	sch.DITStructureRules().Check()
+	sch.LDAPSyntaxes().Check()
+	sch.MatchingRules().Check()
+	sch.NameForms().Check()
+
+	ls := sch.NewLDAPSyntax().SetNumericOID(`bogus`)
+	if ls.Check().Code(NumericOIDViolation).IsZero() {
+		t.Errorf("%s failed: want numeric OID violation", t.Name())
+	}
+
+	mr := sch.NewMatchingRule().SetNumericOID(`1.3.6.1.4.1.56521.999.88.40`)
+	if mr.Check().Code(UnknownReferenceViolation).IsZero() {
+		t.Errorf("%s failed: want unknown syntax violation", t.Name())
+	}
+
+	at := sch.NewAttributeType().SetNumericOID(`1.3.6.1.4.1.56521.999.88.41`)
+	if at.Check().Code(MissingSyntaxViolation).IsZero() {
+		t.Errorf("%s failed: want missing syntax violation", t.Name())
+	}
+
+	nf := sch.NewNameForm().SetNumericOID(`1.3.6.1.4.1.56521.999.88.42`)
+	if nf.Check().Code(NameFormMustViolation).IsZero() {
+		t.Errorf("%s failed: want empty MUST violation", t.Name())
+	}
+
+	dc := DITContentRule{&dITContentRule{
+		OID:    sch.ObjectClasses().Get(`posixAccount`),
+		schema: sch,
+	}}
+	if dc.Check().Code(ContentRuleClassViolation).IsZero() {
+		t.Errorf("%s failed: want non-STRUCTURAL class violation", t.Name())
+	}
+
+	ds := sch.NewDITStructureRule()
+	if ds.Check().Code(UnknownReferenceViolation).IsZero() {
+		t.Errorf("%s failed: want unknown FORM violation", t.Name())
+	}
+}
@@ -27,6 +27,9 @@ E returns the underlying error instance.
 */
 func (r DITContentRule) E() (err error) {
 	if !r.IsZero() {
+		runlock := r.dITContentRule.schema.rlock()
+		defer runlock()
+
 		err = r.dITContentRule.err
 	} else {
 		err = ErrNilReceiver
@@ -89,6 +92,7 @@ a [DITContentRule] instance.
 func NewDITContentRule() DITContentRule {
 	dc := DITContentRule{newDITContentRule()}
 	dc.dITContentRule.Extensions.setDefinition(dc)
+	dc.dITContentRule.Name.setDefinition(dc)
 	return dc
 }
 
@@ -119,6 +123,14 @@ func (r DITContentRule) SetData(x any) DITContentRule {
 }
 
 func (r *dITContentRule) setData(x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.data = x
 }
 
@@ -154,6 +166,14 @@ func (r DITContentRule) SetSchema(schema Schema) DITContentRule {
 }
 
 func (r *dITContentRule) setSchema(schema Schema) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -286,6 +306,9 @@ func (r DITContentRules) Len() int {
 }
 
 func (r DITContentRules) len() int {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
 	return r.cast().Len()
 }
 
@@ -313,7 +336,17 @@ func (r DITContentRules) Index(idx int) DITContentRule {
 	return r.index(idx)
 }
 
-func (r DITContentRules) index(idx int) (dc DITContentRule) {
+func (r DITContentRules) index(idx int) DITContentRule {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.at(idx)
+}
+
+/*
+at is the unlocked counterpart of index.
+*/
+func (r DITContentRules) at(idx int) (dc DITContentRule) {
 	slice, found := r.cast().Index(idx)
 	if found {
 		if _dc, ok := slice.(DITContentRule); ok {
@@ -429,6 +462,11 @@ func (r DITContentRule) SetAux(m ...any) DITContentRule {
 }
 
 func (r *dITContentRule) setAux(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var oc ObjectClass
@@ -447,7 +485,7 @@ func (r *dITContentRule) setAux(m ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -493,13 +531,28 @@ compliant per the required clauses of [§ 4.1.6 of RFC 4512]:
 
 [§ 4.1.6 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.6
 */
-func (r DITContentRule) Compliant() bool {
+func (r DITContentRule) Compliant() (ok bool) {
+	if ok = r.compliant(); ok {
+		unlock, _ := r.dITContentRule.schema.lock()
+		defer unlock()
+
+		r.dITContentRule.err = nil
+	}
+
+	return
+}
+
+/*
+compliant is the side-effect free counterpart of Compliant, for use
+upon paths which merely read the receiver.
+*/
+func (r DITContentRule) compliant() bool {
 	if r.IsZero() {
 		return false
 	}
 
 	structural := r.StructuralClass()
-	if !structural.Compliant() || structural.Kind() != StructuralKind {
+	if !structural.compliant() || structural.Kind() != StructuralKind {
 		return false
 	}
 
@@ -546,12 +599,7 @@ func (r DITContentRule) Compliant() bool {
 	// if any dITStructureRule definitions exist,
 	// make sure they don't produce a MUST/NOT
 	// conflict.
-	ok := r.dsrComply(structural)
-	if ok {
-		r.dITContentRule.err = nil
-	}
-
-	return ok
+	return r.dsrComply(structural)
 }
 
 func (r DITContentRule) dsrComply(structural ObjectClass) bool {
@@ -583,7 +631,7 @@ func (r DITContentRule) auxComply(must, may AttributeTypes) bool {
 	var aux ObjectClasses = r.Aux()
 	for i := 0; i < aux.Len(); i++ {
 		aoc := aux.Index(i)
-		if !aoc.Compliant() || aoc.Kind() != AuxiliaryKind {
+		if !aoc.compliant() || aoc.Kind() != AuxiliaryKind {
 			return false
 		}
 
@@ -695,7 +743,7 @@ func (r DITContentRule) Marshal(def any) error {
 		}
 	}
 
-	if !r.Compliant() {
+	if !r.compliant() {
 		return ErrDefNonCompliant
 	}
 	r.SetStringer()
@@ -811,14 +859,24 @@ func (r DITContentRule) SetName(x ...string) DITContentRule {
 }
 
 func (r *dITContentRule) setName(x ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	b4 := r.Name.Len()
 	for i := 0; i < len(x); i++ {
-		r.Name.Push(x[i])
+		r.Name.push(x[i])
 	}
 
 	if r.Name.Len()-len(x) != b4 {
 		r.err = ErrInvalidNames
 	}
+
+	r.schema.reindex(DITContentRule{r})
 }
 
 /*
@@ -835,6 +893,14 @@ func (r DITContentRule) SetObsolete() DITContentRule {
 }
 
 func (r *dITContentRule) setObsolete() {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -856,7 +922,15 @@ func (r DITContentRule) SetDescription(desc string) DITContentRule {
 }
 
 func (r *dITContentRule) setDescription(desc string) {
-	if len(desc) < 3 {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if len(desc) == 0 {
 		return
 	}
 
@@ -864,7 +938,7 @@ func (r *dITContentRule) setDescription(desc string) {
 		desc = desc[1:]
 	}
 
-	if rune(desc[len(desc)-1]) == rune(39) {
+	if len(desc) > 0 && rune(desc[len(desc)-1]) == rune(39) {
 		desc = desc[:len(desc)-1]
 	}
 
@@ -888,6 +962,14 @@ func (r DITContentRule) SetExtension(x string, xstrs ...string) DITContentRule {
 }
 
 func (r *dITContentRule) setExtension(x string, xstrs ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -909,11 +991,23 @@ func (r DITContentRule) SetNumericOID(id string) DITContentRule {
 }
 
 func (r *dITContentRule) setNumericOID(id string) {
+	var oc ObjectClass
+	if isNumericOID(id) {
+		oc = r.schema.ObjectClasses().Get(id)
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
 		if r.OID.IsZero() {
-			oc := r.schema.ObjectClasses().Get(id)
 			if oc.Kind() == StructuralKind {
 				r.OID = oc
 			} else {
@@ -954,6 +1048,11 @@ func (r DITContentRule) SetMust(m ...any) DITContentRule {
 }
 
 func (r *dITContentRule) setMust(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -972,7 +1071,7 @@ func (r *dITContentRule) setMust(m ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -1003,6 +1102,11 @@ func (r DITContentRule) SetMay(m ...any) DITContentRule {
 }
 
 func (r *dITContentRule) setMay(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -1021,7 +1125,7 @@ func (r *dITContentRule) setMay(m ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -1052,6 +1156,11 @@ func (r DITContentRule) SetNot(m ...any) DITContentRule {
 }
 
 func (r *dITContentRule) setNot(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -1070,7 +1179,7 @@ func (r *dITContentRule) setNot(m ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -1112,7 +1221,7 @@ stringer.
 This is a fluent method and may be used multiple times.
 */
 func (r DITContentRule) SetStringer(function ...Stringer) DITContentRule {
-	if r.Compliant() {
+	if r.compliant() {
 		r.dITContentRule.setStringer(function...)
 	}
 
@@ -1125,17 +1234,26 @@ func (r *dITContentRule) setStringer(function ...Stringer) {
 		stringer = function[0]
 	}
 
+	var err error
 	if stringer == nil {
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		} else {
-			r.err = err
+		// no user provided closure means we
+		// defer to a general use stringer.
+		var str string
+		str, err = r.prepareString() // perform one-time text/template op
+		stringer = func() string {
+			// Return a preserved value.
+			return str
 		}
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	} else if err != nil {
+		r.err = err
 		return
 	}
 
@@ -1153,7 +1271,7 @@ func (r DITContentRules) XOrigin(x string) (defs DITContentRules) {
 		def := r.Index(i)
 		if xo, found := def.Extensions().Get(`X-ORIGIN`); found {
 			if xo.Contains(x) {
-				defs.push(def)
+				defs.cast().Push(def)
 			}
 		}
 	}
@@ -1287,18 +1405,22 @@ func (r DITContentRule) setOID(_ string) {}
 Push returns an error following an attempt to push a [DITContentRule]
 into the receiver stack instance.
 */
-func (r DITContentRules) Push(dc any) error {
-	return r.push(dc)
+func (r DITContentRules) Push(dc any) (err error) {
+	if err = r.push(dc); err == nil {
+		pushed(r, dc)
+	}
+
+	return
 }
 
 func (r DITContentRules) push(x any) (err error) {
 	switch tv := x.(type) {
 	case DITContentRule:
-		if !tv.Compliant() {
+		if !tv.compliant() {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		err = lockedPush(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -1341,9 +1463,28 @@ func (r DITContentRules) Get(id string) DITContentRule {
 	return r.get(id)
 }
 
-func (r DITContentRules) get(id string) (dc DITContentRule) {
-	for i := 0; i < r.len() && dc.IsZero(); i++ {
-		if _dc := r.index(i); !_dc.IsZero() {
+func (r DITContentRules) get(id string) DITContentRule {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.lookup(id)
+}
+
+/*
+lookup is the unlocked counterpart of get, for use where the lock is
+already held, such as during a push.
+*/
+func (r DITContentRules) lookup(id string) (dc DITContentRule) {
+	if slice, indexed := indexLookup(r.cast(), id, func(x any) bool {
+		_dc, _ := x.(DITContentRule)
+		return _dc.IsIdentifiedAs(id)
+	}); indexed {
+		dc, _ = slice.(DITContentRule)
+		return
+	}
+
+	for i := 0; i < r.cast().Len() && dc.IsZero(); i++ {
+		if _dc := r.at(i); !_dc.IsZero() {
 			if _dc.NumericOID() == id {
 				dc = _dc
 			} else if _dc.dITContentRule.Name.contains(id) {
@@ -1365,8 +1506,8 @@ func (r DITContentRules) canPush(x ...any) (err error) {
 		dc, ok := x[i].(DITContentRule)
 		if !ok || dc.IsZero() {
 			err = ErrTypeAssert
-		} else if tst := r.get(dc.NumericOID()); !tst.IsZero() {
-			err = mkerr(ErrNotUnique.Error() + ": " + dc.Type() + `, ` + dc.NumericOID())
+		} else if tst := r.lookup(dc.NumericOID()); !tst.IsZero() {
+			err = wraperr(ErrNotUnique, ": "+dc.Type()+`, `+dc.NumericOID())
 		}
 	}
 
@@ -1393,7 +1534,7 @@ func (r DITContentRule) Replace(x DITContentRule) DITContentRule {
 		return r
 	}
 
-	if !r.IsZero() && x.Compliant() {
+	if !r.IsZero() && x.compliant() {
 		r.dITContentRule.replace(x)
 	}
 
@@ -1401,6 +1542,15 @@ func (r DITContentRule) Replace(x DITContentRule) DITContentRule {
 }
 
 func (r *dITContentRule) replace(x DITContentRule) {
+	sch := r.schema
+	unlock, ok := sch.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if r.OID.NumericOID() == `` {
 		r.err = ErrMissingNumericOID
 		return
@@ -1411,6 +1561,7 @@ func (r *dITContentRule) replace(x DITContentRule) {
 
 	r.OID = x.dITContentRule.OID
 	r.Name = x.dITContentRule.Name
+	r.Name.setDefinition(DITContentRule{r})
 	r.Desc = x.dITContentRule.Desc
 	r.Obsolete = x.dITContentRule.Obsolete
 	r.Must = x.dITContentRule.Must
@@ -1422,4 +1573,45 @@ func (r *dITContentRule) replace(x DITContentRule) {
 	r.schema = x.dITContentRule.schema
 	r.stringer = x.dITContentRule.stringer
 	r.data = x.dITContentRule.data
+
+	sch.reindex(DITContentRule{r})
+}
+
+/*
+Clone returns a deep copy of the receiver instance.  Modification of
+the return instance -- such as by way of the [DITContentRule.SetMust]
+method -- shall not influence the receiver instance.
+
+Referenced definitions, such as the structural [ObjectClass] as well
+as the AUX, MUST, MAY and NOT values, are NOT cloned; use [Schema.Clone]
+to clone an entire [Schema] along with all internal references.
+
+Note that use of the [DITContentRule.SetStringer] method may be needed
+following any changes to the return instance.
+*/
+func (r DITContentRule) Clone() (c DITContentRule) {
+	if r.IsZero() {
+		return
+	}
+
+	_c := *r.dITContentRule
+	c = DITContentRule{&_c}
+	_c.Macro = cloneStrings(r.dITContentRule.Macro)
+	_c.Name = cloneName(r.dITContentRule.Name, c)
+	_c.Aux = r.dITContentRule.Aux.cloneOIDList()
+	_c.Must = r.dITContentRule.Must.cloneOIDList()
+	_c.May = r.dITContentRule.May.cloneOIDList()
+	_c.Not = r.dITContentRule.Not.cloneOIDList()
+	_c.Extensions = cloneExtensions(r.dITContentRule.Extensions, c)
+
+	return
+}
+
+func (r *dITContentRule) rewire(sch Schema) {
+	r.schema = sch
+	r.OID = r.OID.counterpart(sch)
+	r.Aux = r.Aux.counterparts(sch)
+	r.Must = r.Must.counterparts(sch)
+	r.May = r.May.counterparts(sch)
+	r.Not = r.Not.counterparts(sch)
 }
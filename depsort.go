@@ -0,0 +1,392 @@
+package schemax
+
+/*
+depsort.go contains facilities for the dependency-based (topological)
+ordering of definitions prior to their incorporation into a Schema.
+*/
+
+import "github.com/JesseCoretta/go-antlr4512"
+
+/*
+dependencyNode describes a single definition in terms of the identifiers
+by which it may be referenced (e.g.: numeric OID and names), as well as
+the superior definitions of the same type upon which it depends.
+*/
+type dependencyNode struct {
+	label string   // used for error reporting
+	keys  []string // numeric OID (or rule ID) and names
+	refs  []string // references to superior definitions
+}
+
+/*
+sortDependencies returns the indices of nodes, ordered such that every
+node appears after all of the nodes upon which it depends.  Independent
+nodes retain their relative order of appearance.
+
+The known closure is used to determine whether a reference which is not
+satisfied by any member of nodes is satisfied by a definition already
+present within the Schema in question.  Unsatisfiable references result
+in an error based upon notFound.
+
+Circular dependencies result in an [ErrCircularDependency] error for each
+node involved.
+
+Nodes for which an error was produced are absent from the return order.
+Errors are keyed by node index.
+*/
+func sortDependencies(nodes []dependencyNode, known func(string) bool, notFound error) (order []int, errs map[int]error) {
+	errs = make(map[int]error)
+
+	keymap := make(map[string]int, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		// earliest incarnation wins
+		for _, key := range nodes[i].keys {
+			if len(key) > 0 {
+				keymap[lc(key)] = i
+			}
+		}
+	}
+
+	const (
+		unvisited int = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(nodes))
+	var path []int
+
+	var visit func(int)
+	visit = func(i int) {
+		switch state[i] {
+		case visited:
+			return
+		case visiting:
+			// back edge: every node from i onward
+			// within the current path is part of
+			// the cycle.
+			var start int
+			for start = len(path) - 1; path[start] != i; start-- {
+			}
+
+			var labels []string
+			for _, j := range path[start:] {
+				labels = append(labels, nodes[j].label)
+			}
+			labels = append(labels, nodes[i].label)
+
+			err := wraperr(ErrCircularDependency, `(`+join(labels, ` -> `)+`)`)
+			for _, j := range path[start:] {
+				errs[j] = err
+			}
+			return
+		}
+
+		state[i] = visiting
+		path = append(path, i)
+
+		for _, ref := range nodes[i].refs {
+			if j, found := keymap[lc(ref)]; found {
+				visit(j)
+			} else if !known(ref) {
+				errs[i] = wraperr(notFound, `(`+ref+
+					` referenced by `+nodes[i].label+`)`)
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[i] = visited
+
+		if _, failed := errs[i]; !failed {
+			order = append(order, i)
+		}
+	}
+
+	for i := 0; i < len(nodes); i++ {
+		visit(i)
+	}
+
+	return
+}
+
+/*
+firstError returns the error within errs bearing the lowest index, or nil
+if errs is empty.
+*/
+func firstError(errs map[int]error) (err error) {
+	idx := -1
+	for i, e := range errs {
+		if idx < 0 || i < idx {
+			idx, err = i, e
+		}
+	}
+
+	return
+}
+
+/*
+dependencyLabel returns the principal name of a definition, or its
+identifier if no names are present.
+*/
+func dependencyLabel(id string, names []string) string {
+	if len(names) > 0 {
+		return names[0]
+	}
+
+	return id
+}
+
+/*
+attributeTypeOrder returns the indices of s ordered such that each
+supertype precedes its subtypes, alongside any errors encountered.
+*/
+func (r Schema) attributeTypeOrder(s antlr4512.AttributeTypes) ([]int, map[int]error) {
+	nodes := make([]dependencyNode, len(s))
+	for i, def := range s {
+		oid := handleMacro(r, def.Macro, def.OID)
+		nodes[i] = dependencyNode{
+			label: dependencyLabel(oid, def.Name),
+			keys:  append([]string{oid}, def.Name...),
+		}
+		if len(def.SuperType) > 0 {
+			nodes[i].refs = []string{def.SuperType}
+		}
+	}
+
+	return sortDependencies(nodes, func(ref string) bool {
+		return !r.AttributeTypes().get(ref).IsZero()
+	}, ErrAttributeTypeNotFound)
+}
+
+/*
+objectClassOrder returns the indices of s ordered such that each
+superclass precedes its subclasses, alongside any errors encountered.
+*/
+func (r Schema) objectClassOrder(s antlr4512.ObjectClasses) ([]int, map[int]error) {
+	nodes := make([]dependencyNode, len(s))
+	for i, def := range s {
+		oid := handleMacro(r, def.Macro, def.OID)
+		nodes[i] = dependencyNode{
+			label: dependencyLabel(oid, def.Name),
+			keys:  append([]string{oid}, def.Name...),
+			refs:  def.SuperClasses,
+		}
+	}
+
+	return sortDependencies(nodes, func(ref string) bool {
+		return !r.ObjectClasses().get(ref).IsZero()
+	}, ErrObjectClassNotFound)
+}
+
+/*
+dITStructureRuleOrder returns the indices of s ordered such that each
+superior rule precedes its subordinate rules, alongside any errors
+encountered.
+
+Note that a rule which names itself as a superior rule is not considered
+circular for the purposes of ordering.
+*/
+func (r Schema) dITStructureRuleOrder(s antlr4512.DITStructureRules) ([]int, map[int]error) {
+	nodes := make([]dependencyNode, len(s))
+	for i, def := range s {
+		nodes[i] = dependencyNode{
+			label: dependencyLabel(def.ID, def.Name),
+			keys:  append([]string{def.ID}, def.Name...),
+		}
+		for _, sup := range def.SuperRules {
+			if sup != def.ID {
+				nodes[i].refs = append(nodes[i].refs, sup)
+			}
+		}
+	}
+
+	return sortDependencies(nodes, func(ref string) bool {
+		return !r.DITStructureRules().get(ref).IsZero()
+	}, ErrDITStructureRuleNotFound)
+}
+
+/*
+orderDefinitions returns an error following an attempt to reorder the
+attribute types, object classes and DIT structure rules within s such
+that superior definitions precede their subordinates.
+*/
+func (r Schema) orderDefinitions(s *antlr4512.Schema) (err error) {
+	order, errs := r.attributeTypeOrder(s.AT)
+	if err = firstError(errs); err != nil {
+		return
+	}
+	at := make(antlr4512.AttributeTypes, len(order))
+	for i, idx := range order {
+		at[i] = s.AT[idx]
+	}
+	s.AT = at
+
+	order, errs = r.objectClassOrder(s.OC)
+	if err = firstError(errs); err != nil {
+		return
+	}
+	oc := make(antlr4512.ObjectClasses, len(order))
+	for i, idx := range order {
+		oc[i] = s.OC[idx]
+	}
+	s.OC = oc
+
+	order, errs = r.dITStructureRuleOrder(s.DS)
+	if err = firstError(errs); err != nil {
+		return
+	}
+	ds := make(antlr4512.DITStructureRules, len(order))
+	for i, idx := range order {
+		ds[i] = s.DS[idx]
+	}
+	s.DS = ds
+
+	return
+}
+
+/*
+orderRawDefinitions returns defs, all of which must be of the specified
+type, ordered by dependency.  Any definitions which could not be ordered
+are absent from the return slice, and their errors are keyed by index.
+
+Definitions which cannot be pre-parsed for dependency analysis bear no
+dependencies, and shall fail during the actual parse.  Definitions of
+types not subject to ordering are returned as-is.
+*/
+func (r Schema) orderRawDefinitions(typ string, defs []rawDefinition) (ordered []rawDefinition, errs map[int]error) {
+	var order []int
+	switch typ {
+	case `attributeType`:
+		s := make(antlr4512.AttributeTypes, len(defs))
+		for i, def := range defs {
+			s[i], _ = parseAT(def.Text)
+		}
+		order, errs = r.attributeTypeOrder(s)
+	case `objectClass`:
+		s := make(antlr4512.ObjectClasses, len(defs))
+		for i, def := range defs {
+			s[i], _ = parseOC(def.Text)
+		}
+		order, errs = r.objectClassOrder(s)
+	case `dITStructureRule`:
+		s := make(antlr4512.DITStructureRules, len(defs))
+		for i, def := range defs {
+			s[i], _ = parseDS(def.Text)
+		}
+		order, errs = r.dITStructureRuleOrder(s)
+	default:
+		ordered = defs
+		return
+	}
+
+	for _, idx := range order {
+		ordered = append(ordered, defs[idx])
+	}
+
+	return
+}
+
+/*
+layoutOrder returns the indices of layouts, all of which must be object
+layouts of the specified type, ordered by dependency.  Any layouts which
+could not be ordered are absent from the return order, and their errors
+are keyed by index.  Layouts of types not subject to ordering are returned
+in order of appearance.
+
+This is used in the ordering of both JSON and YAML input.
+*/
+func (r Schema) layoutOrder(typ string, layouts []definitionLayout) (order []int, errs map[int]error) {
+	switch typ {
+	case `attributeType`:
+		s := make(antlr4512.AttributeTypes, len(layouts))
+		for i, layout := range layouts {
+			l := layout.(*attributeTypeLayout)
+			s[i] = antlr4512.AttributeType{
+				OID:       r.layoutOID(l.OID),
+				Name:      l.Names,
+				SuperType: l.Sup,
+			}
+		}
+		order, errs = r.attributeTypeOrder(s)
+	case `objectClass`:
+		s := make(antlr4512.ObjectClasses, len(layouts))
+		for i, layout := range layouts {
+			l := layout.(*objectClassLayout)
+			s[i] = antlr4512.ObjectClass{
+				OID:          r.layoutOID(l.OID),
+				Name:         l.Names,
+				SuperClasses: l.Sup,
+			}
+		}
+		order, errs = r.objectClassOrder(s)
+	case `dITStructureRule`:
+		s := make(antlr4512.DITStructureRules, len(layouts))
+		for i, layout := range layouts {
+			l := layout.(*dITStructureRuleLayout)
+			s[i] = antlr4512.DITStructureRule{
+				ID:   uitoa(l.RuleID),
+				Name: l.Names,
+			}
+			for _, sup := range l.Sup {
+				s[i].SuperRules = append(s[i].SuperRules, uitoa(sup))
+			}
+		}
+		order, errs = r.dITStructureRuleOrder(s)
+	default:
+		for i := range layouts {
+			order = append(order, i)
+		}
+	}
+
+	return
+}
+
+/*
+definitionOrder returns the indices of defs, all of which must be of the
+same type, ordered such that each superior definition precedes its
+subordinates.  Independent definitions retain their relative order of
+appearance.
+
+Unlike the parse-time orderings above, references to definitions absent
+from defs are assumed to be satisfied.  The indices of definitions which
+could not be ordered, such as those involved in a circular dependency,
+are appended in order of appearance.
+*/
+func definitionOrder(defs []Definition) (order []int) {
+	nodes := make([]dependencyNode, len(defs))
+	for i, def := range defs {
+		id := defIdentity(def)
+		nodes[i] = dependencyNode{label: id, keys: []string{id}}
+
+		switch tv := def.(type) {
+		case AttributeType:
+			nodes[i].keys = append(nodes[i].keys, tv.Names().List()...)
+			if sup := tv.SuperType(); !sup.IsZero() {
+				nodes[i].refs = []string{sup.NumericOID()}
+			}
+		case ObjectClass:
+			nodes[i].keys = append(nodes[i].keys, tv.Names().List()...)
+			sups := tv.SuperClasses()
+			for j := 0; j < sups.Len(); j++ {
+				nodes[i].refs = append(nodes[i].refs, sups.Index(j).NumericOID())
+			}
+		case DITStructureRule:
+			nodes[i].keys = append(nodes[i].keys, tv.Names().List()...)
+			sups := tv.SuperRules()
+			for j := 0; j < sups.Len(); j++ {
+				if sup := sups.Index(j).ID(); sup != id {
+					nodes[i].refs = append(nodes[i].refs, sup)
+				}
+			}
+		}
+	}
+
+	order, errs := sortDependencies(nodes, func(string) bool { return true }, ErrNilDef)
+	for i := range defs {
+		if _, failed := errs[i]; failed {
+			order = append(order, i)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,124 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+This example demonstrates the parsing of definitions which are not
+ordered by dependency.  Subordinate definitions may appear before
+their superiors.
+*/
+func ExampleSchema_ParseRaw_outOfOrder() {
+	sch := NewSchema()
+
+	raw := []byte(`objectClass ( 1.3.6.1.4.1.56521.999.87.3
+	NAME 'fakeSubClass'
+	SUP fakeClass
+	MAY fakeSubAttr )
+
+attributeType ( 1.3.6.1.4.1.56521.999.87.2
+	NAME 'fakeSubAttr'
+	SUP fakeAttr )
+
+objectClass ( 1.3.6.1.4.1.56521.999.87.4
+	NAME 'fakeClass'
+	SUP top AUXILIARY
+	MAY fakeAttr )
+
+attributeType ( 1.3.6.1.4.1.56521.999.87.1
+	NAME 'fakeAttr'
+	SUP name )`)
+
+	if err := sch.ParseRaw(raw); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(sch.AttributeTypes().Get(`fakeSubAttr`).SuperType().Name())
+	fmt.Println(sch.ObjectClasses().Get(`fakeSubClass`).SuperClasses().Index(0).Name())
+	// Output:
+	// fakeAttr
+	// fakeClass
+}
+
+func TestSchema_ParseDirectory_outOfOrder(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "schemax_depsort")
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+
+	// File names are lexically ordered in a manner
+	// contrary to the dependencies within.
+	for name, content := range map[string]string{
+		`a.schema`: `dITStructureRule ( 31 NAME 'fakeSubRule' FORM fakeNameForm SUP 30 )`,
+		`b.schema`: `nameForm ( 1.3.6.1.4.1.56521.999.87.6 NAME 'fakeNameForm' OC fakeClass MUST cn )
+dITStructureRule ( 30 NAME 'fakeRule' FORM fakeNameForm )`,
+		`c.schema`: `objectClass ( 1.3.6.1.4.1.56521.999.87.5 NAME 'fakeClass' SUP fakeAbstractClass STRUCTURAL MUST cn MAY fakeAttr )`,
+		`d.schema`: `objectClass ( 1.3.6.1.4.1.56521.999.87.4 NAME 'fakeAbstractClass' SUP top ABSTRACT )
+attributeType ( 1.3.6.1.4.1.56521.999.87.2 NAME 'fakeAttr' SUP fakeSuperAttr )
+attributeType ( 1.3.6.1.4.1.56521.999.87.1 NAME 'fakeSuperAttr' SUP name )`,
+	} {
+		if err = os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Errorf("%s failed: %v", t.Name(), err)
+			return
+		}
+	}
+
+	for _, opt := range []Option{0, ContinueOnError} {
+		sch := NewSchema(opt)
+		if err = sch.ParseDirectory(tempDir); err != nil {
+			t.Errorf("%s failed [opt:%d]: %v", t.Name(), opt, err)
+			return
+		}
+
+		sub := sch.DITStructureRules().Get(`31`)
+		if sub.IsZero() || sub.SuperRules().Index(0).RuleID() != 30 {
+			t.Errorf("%s failed [opt:%d]: structure rule not ordered", t.Name(), opt)
+			return
+		}
+	}
+}
+
+func TestSchema_ParseRaw_dependencyErrors(t *testing.T) {
+	for idx, raw := range []string{
+		`attributeType ( 1.3.6.1.4.1.56521.999.87.1 NAME 'fakeAttr' SUP fakeOtherAttr )
+attributeType ( 1.3.6.1.4.1.56521.999.87.2 NAME 'fakeOtherAttr' SUP fakeAttr )`,
+		`objectClass ( 1.3.6.1.4.1.56521.999.87.3 NAME 'fakeClass' SUP fakeClass AUXILIARY )`,
+		`dITStructureRule ( 32 NAME 'fakeRule' FORM domainNameForm SUP 33 )
+dITStructureRule ( 33 NAME 'fakeOtherRule' FORM domainNameForm SUP 32 )`,
+	} {
+		for _, opt := range []Option{0, ContinueOnError} {
+			err := NewSchema(opt).ParseRaw([]byte(raw))
+			if !errors.Is(err, ErrCircularDependency) {
+				t.Errorf("%s[%d] failed [opt:%d]: expected circular dependency error, got %v",
+					t.Name(), idx, opt, err)
+				return
+			}
+		}
+	}
+
+	for idx, pair := range []struct {
+		raw string
+		err error
+	}{
+		{`attributeType ( 1.3.6.1.4.1.56521.999.87.1 NAME 'fakeAttr' SUP bogusAttr )`, ErrAttributeTypeNotFound},
+		{`objectClass ( 1.3.6.1.4.1.56521.999.87.3 NAME 'fakeClass' SUP bogusClass AUXILIARY )`, ErrObjectClassNotFound},
+		{`dITStructureRule ( 32 NAME 'fakeRule' FORM domainNameForm SUP 99 )`, ErrDITStructureRuleNotFound},
+	} {
+		if err := NewSchema().ParseRaw([]byte(pair.raw)); !errors.Is(err, pair.err) {
+			t.Errorf("%s[%d] failed: expected %v, got %v", t.Name(), idx, pair.err, err)
+			return
+		}
+	}
+
+	if err := firstError(nil); err != nil {
+		t.Errorf("%s failed: unexpected error: %v", t.Name(), err)
+	}
+}
@@ -0,0 +1,364 @@
+package schemax
+
+/*
+diff.go contains the structural comparison facilities of two (2) Schema
+instances, based upon the DefinitionMap output of each Definition.
+*/
+
+import "sort"
+
+const (
+	AddedDiff   uint = iota // definition present only in the newer Schema
+	RemovedDiff             // definition present only in the older Schema
+	ChangedDiff             // definition present in both, but with differing clauses
+)
+
+/*
+SchemaDiff implements slices of [DefinitionDiff], collectively describing
+the differences between two (2) [Schema] instances.  Instances of this type
+are produced by the [Schema.Diff] method.
+
+Slices are ordered by definition type per RFC 4512 dependency order, namely:
+
+  - [LDAPSyntax]
+  - [MatchingRule]
+  - [AttributeType]
+  - [MatchingRuleUse]
+  - [ObjectClass]
+  - [DITContentRule]
+  - [NameForm]
+  - [DITStructureRule]
+
+Within each type, slices follow the order in which the definitions reside
+within their respective [Schema] instance.
+*/
+type SchemaDiff []DefinitionDiff
+
+/*
+DefinitionDiff describes the difference between two (2) incarnations of
+a single [Definition], as identified by its numeric OID, or by its rule
+ID in the case of a [DITStructureRule].
+
+Action is one of [AddedDiff], [RemovedDiff] or [ChangedDiff]. Old is nil
+when a definition was added, while New is nil when a definition was removed.
+
+Clauses is only populated when Action is [ChangedDiff].
+*/
+type DefinitionDiff struct {
+	Type    string
+	ID      string
+	Action  uint
+	Old     Definition
+	New     Definition
+	Clauses []ClauseDiff
+}
+
+/*
+ClauseDiff describes the difference between two (2) incarnations of a
+single clause -- such as NAME, SYNTAX, MAY or X-ORIGIN -- within a given
+[Definition].
+
+Old and New contain the respective clause values, if any. Added contains
+values present only within New, while Removed contains values present only
+within Old.  Thus a changed SYNTAX bears one (1) removed value and one (1)
+added value, whereas a MAY clause which gained "mail" bears a single added
+value.
+
+Boolean clauses, such as OBSOLETE, are expressed using the string values
+"true" and "false".
+*/
+type ClauseDiff struct {
+	Clause  string
+	Old     []string
+	New     []string
+	Added   []string
+	Removed []string
+}
+
+/*
+Diff returns an instance of [SchemaDiff] describing the differences between
+the receiver instance (the older [Schema]) and other (the newer [Schema]).
+
+Definitions are paired by numeric OID, or by rule ID in the case of a
+[DITStructureRule], across all eight (8) definition types.  Paired
+definitions are compared on a per-clause basis using the output of
+their respective Map methods.  Identifying and derived keys, such as
+"RAW" and "TYPE", are not considered.
+
+A zero length return value indicates the two [Schema] instances are
+equivalent in terms of definition content.
+*/
+func (r Schema) Diff(other Schema) (diff SchemaDiff) {
+	if r.IsZero() && other.IsZero() {
+		return
+	}
+
+	for _, pair := range [][2]Definitions{
+		{r.LDAPSyntaxes(), other.LDAPSyntaxes()},
+		{r.MatchingRules(), other.MatchingRules()},
+		{r.AttributeTypes(), other.AttributeTypes()},
+		{r.MatchingRuleUses(), other.MatchingRuleUses()},
+		{r.ObjectClasses(), other.ObjectClasses()},
+		{r.DITContentRules(), other.DITContentRules()},
+		{r.NameForms(), other.NameForms()},
+		{r.DITStructureRules(), other.DITStructureRules()},
+	} {
+		diff = append(diff, diffDefinitions(pair[0], pair[1])...)
+	}
+
+	return
+}
+
+/*
+Len returns the integer length of the receiver instance.
+*/
+func (r SchemaDiff) Len() int {
+	return len(r)
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state, which
+also means no differences were found.
+*/
+func (r SchemaDiff) IsZero() bool {
+	return r.Len() == 0
+}
+
+/*
+Added returns all [DefinitionDiff] slices bearing the [AddedDiff] action.
+*/
+func (r SchemaDiff) Added() SchemaDiff {
+	return r.filter(AddedDiff)
+}
+
+/*
+Removed returns all [DefinitionDiff] slices bearing the [RemovedDiff] action.
+*/
+func (r SchemaDiff) Removed() SchemaDiff {
+	return r.filter(RemovedDiff)
+}
+
+/*
+Changed returns all [DefinitionDiff] slices bearing the [ChangedDiff] action.
+*/
+func (r SchemaDiff) Changed() SchemaDiff {
+	return r.filter(ChangedDiff)
+}
+
+func (r SchemaDiff) filter(action uint) (diff SchemaDiff) {
+	for i := 0; i < r.Len(); i++ {
+		if r[i].Action == action {
+			diff = append(diff, r[i])
+		}
+	}
+
+	return
+}
+
+/*
+String returns a human-readable, line-delimited representation of the
+receiver instance suitable for review purposes, e.g.:
+
+	~ attributeType 1.3.6.1.4.1.56521.999.3 (someAttr)
+	    SYNTAX: +1.3.6.1.4.1.1466.115.121.1.15 -1.3.6.1.4.1.1466.115.121.1.26
+	+ attributeType 1.3.6.1.4.1.56521.999.1 (newAttr)
+	- objectClass 1.3.6.1.4.1.56521.999.2 (oldClass)
+	~ objectClass 2.5.6.6 (person)
+	    MAY: +mail
+*/
+func (r SchemaDiff) String() string {
+	var lines []string
+	for i := 0; i < r.Len(); i++ {
+		lines = append(lines, r[i].String())
+	}
+
+	return join(lines, string(rune(10)))
+}
+
+/*
+String returns a human-readable representation of the receiver instance.
+See [SchemaDiff.String] for an example.
+*/
+func (r DefinitionDiff) String() string {
+	sym := `~`
+	switch r.Action {
+	case AddedDiff:
+		sym = `+`
+	case RemovedDiff:
+		sym = `-`
+	}
+
+	line := sym + ` ` + r.Type + ` ` + r.ID
+	if name := r.name(); len(name) > 0 {
+		line += ` (` + name + `)`
+	}
+
+	for _, clause := range r.Clauses {
+		line += string(rune(10)) + `    ` + clause.String()
+	}
+
+	return line
+}
+
+func (r DefinitionDiff) name() (name string) {
+	if r.New != nil {
+		name = r.New.Name()
+	} else if r.Old != nil {
+		name = r.Old.Name()
+	}
+
+	return
+}
+
+/*
+String returns a human-readable representation of the receiver instance,
+e.g.: "MAY: +mail -fax".
+*/
+func (r ClauseDiff) String() string {
+	var vals []string
+	for _, v := range r.Added {
+		vals = append(vals, `+`+v)
+	}
+	for _, v := range r.Removed {
+		vals = append(vals, `-`+v)
+	}
+
+	return r.Clause + `: ` + join(vals, ` `)
+}
+
+/*
+diffDefinitions compares the contents of two like collections.
+*/
+func diffDefinitions(older, newer Definitions) (diff SchemaDiff) {
+	var olds, news []Definition
+	if !older.IsZero() {
+		olds = collectionDefinitions(older)
+	}
+	if !newer.IsZero() {
+		news = collectionDefinitions(newer)
+	}
+
+	newIdx := make(map[string]Definition, len(news))
+	for _, def := range news {
+		newIdx[defIdentity(def)] = def
+	}
+
+	oldIdx := make(map[string]Definition, len(olds))
+	for _, def := range olds {
+		id := defIdentity(def)
+		oldIdx[id] = def
+
+		if nd, found := newIdx[id]; !found {
+			diff = append(diff, DefinitionDiff{
+				Type:   def.Type(),
+				ID:     id,
+				Action: RemovedDiff,
+				Old:    def,
+			})
+		} else if clauses := diffClauses(def.Map(), nd.Map()); len(clauses) > 0 {
+			diff = append(diff, DefinitionDiff{
+				Type:    def.Type(),
+				ID:      id,
+				Action:  ChangedDiff,
+				Old:     def,
+				New:     nd,
+				Clauses: clauses,
+			})
+		}
+	}
+
+	for _, def := range news {
+		if id := defIdentity(def); oldIdx[id] == nil {
+			diff = append(diff, DefinitionDiff{
+				Type:   def.Type(),
+				ID:     id,
+				Action: AddedDiff,
+				New:    def,
+			})
+		}
+	}
+
+	return
+}
+
+/*
+collectionDefinitions returns all members of defs as slices of Definition.
+*/
+func collectionDefinitions(defs Definitions) (list []Definition) {
+	stk := defs.cast()
+	for i := 0; i < stk.Len(); i++ {
+		slice, _ := stk.Index(i)
+		if def, ok := slice.(Definition); ok && !def.IsZero() {
+			list = append(list, def)
+		}
+	}
+
+	return
+}
+
+/*
+diffIgnoredKeys contains DefinitionMap keys which are either identifying
+in nature, or which are derived from other clauses and would otherwise
+produce redundant ClauseDiff instances.
+*/
+var diffIgnoredKeys map[string]bool = map[string]bool{
+	`NUMERICOID`: true,
+	`RULEID`:     true,
+	`TYPE`:       true,
+	`RAW`:        true,
+	`HR`:         true,
+	`NOC`:        true,
+}
+
+/*
+diffClauses compares two DefinitionMap instances on a per-clause basis.
+Clauses are returned in alphabetical order.
+*/
+func diffClauses(older, newer DefinitionMap) (clauses []ClauseDiff) {
+	keys := make(map[string]bool, 0)
+	for k := range older {
+		keys[k] = true
+	}
+	for k := range newer {
+		keys[k] = true
+	}
+
+	var sorted []string
+	for k := range keys {
+		if !diffIgnoredKeys[k] {
+			sorted = append(sorted, k)
+		}
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		o, n := older[k], newer[k]
+		added := sliceDifference(n, o)
+		removed := sliceDifference(o, n)
+		if len(added)+len(removed) > 0 {
+			clauses = append(clauses, ClauseDiff{
+				Clause:  k,
+				Old:     o,
+				New:     n,
+				Added:   added,
+				Removed: removed,
+			})
+		}
+	}
+
+	return
+}
+
+/*
+sliceDifference returns all values in a which are not present in b. Case
+is significant in the matching process.
+*/
+func sliceDifference(a, b []string) (diff []string) {
+	for _, v := range a {
+		if !strInSlice(v, b) {
+			diff = append(diff, v)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,81 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates a per-clause comparison of two incarnations of
+the same [AttributeType].
+*/
+func ExampleSchema_Diff() {
+	older := NewBasicSchema()
+	newer := NewBasicSchema()
+
+	older.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.89.1
+		NAME 'fakeAttr'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.26 )`)
+
+	newer.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.89.1
+		NAME ( 'fakeAttr' 'fakeAttribute' )
+		EQUALITY caseIgnoreMatch
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+
+	fmt.Println(older.Diff(newer))
+	// Output:
+	// ~ attributeType 1.3.6.1.4.1.56521.999.89.1 (fakeAttr)
+	//     EQUALITY: +caseIgnoreMatch
+	//     NAME: +fakeAttribute
+	//     SYNTAX: +1.3.6.1.4.1.1466.115.121.1.15 -1.3.6.1.4.1.1466.115.121.1.26
+}
+
+func TestSchema_Diff(t *testing.T) {
+	older := NewSchema()
+	newer := NewSchema()
+
+	if diff := older.Diff(newer); !diff.IsZero() {
+		t.Errorf("%s failed: expected no differences, got:\n%s", t.Name(), diff)
+		return
+	}
+
+	newer.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.89.2
+		NAME 'fakeClass'
+		SUP top AUXILIARY
+		MAY mail )`)
+	newer.Remove(newer.ObjectClasses().Get(`applicationProcess`))
+
+	older.ObjectClasses().Get(`person`).SetExtension(`X-ORIGIN`, `RFC4519`, `X.521`)
+	older.ObjectClasses().Get(`person`).SetStringer()
+
+	diff := older.Diff(newer)
+	if diff.Len() != 3 {
+		t.Errorf("%s failed: want 3 differences, got %d:\n%s", t.Name(), diff.Len(), diff)
+		return
+	}
+
+	if added := diff.Added(); added.Len() != 1 || added[0].New.Name() != `fakeClass` {
+		t.Errorf("%s failed: unexpected additions:\n%s", t.Name(), added)
+		return
+	}
+
+	if removed := diff.Removed(); removed.Len() != 1 || removed[0].Old.Name() != `applicationProcess` {
+		t.Errorf("%s failed: unexpected removals:\n%s", t.Name(), removed)
+		return
+	}
+
+	changed := diff.Changed()
+	if changed.Len() != 1 || len(changed[0].Clauses) != 1 {
+		t.Errorf("%s failed: unexpected changes:\n%s", t.Name(), changed)
+		return
+	}
+
+	if clause := changed[0].Clauses[0]; clause.Clause != `X-ORIGIN` || clause.Removed[0] != `X.521` {
+		t.Errorf("%s failed: unexpected clause change: %s", t.Name(), clause)
+		return
+	}
+
+	var zero Schema
+	_ = zero.Diff(Schema{})
+	_ = zero.Diff(newer).String()
+}
@@ -0,0 +1,853 @@
+package schemax
+
+/*
+dit.go contains an in-memory mock Directory Information Tree (DIT), in
+which entries are managed subject to the contents of a Schema.
+*/
+
+import (
+	"errors"
+	"sort"
+)
+
+/*
+LDAP result codes, per RFC 4511 Section 4.1.9, as returned through the
+Code field of [ResultError] instances.
+*/
+const (
+	SuccessResult                   uint = 0
+	ProtocolErrorResult             uint = 2
+	NoSuchAttributeResult           uint = 16
+	UndefinedAttributeTypeResult    uint = 17
+	ConstraintViolationResult       uint = 19
+	AttributeOrValueExistsResult    uint = 20
+	InvalidAttributeSyntaxResult    uint = 21
+	NoSuchObjectResult              uint = 32
+	InvalidDNSyntaxResult           uint = 34
+	UnwillingToPerformResult        uint = 53
+	NamingViolationResult           uint = 64
+	ObjectClassViolationResult      uint = 65
+	NotAllowedOnNonLeafResult       uint = 66
+	NotAllowedOnRDNResult           uint = 67
+	EntryAlreadyExistsResult        uint = 68
+	ObjectClassModsProhibitedResult uint = 69
+	OtherResult                     uint = 80
+)
+
+var resultCodeLabels map[uint]string = map[uint]string{
+	SuccessResult:                   `success`,
+	ProtocolErrorResult:             `protocolError`,
+	NoSuchAttributeResult:           `noSuchAttribute`,
+	UndefinedAttributeTypeResult:    `undefinedAttributeType`,
+	ConstraintViolationResult:       `constraintViolation`,
+	AttributeOrValueExistsResult:    `attributeOrValueExists`,
+	InvalidAttributeSyntaxResult:    `invalidAttributeSyntax`,
+	NoSuchObjectResult:              `noSuchObject`,
+	InvalidDNSyntaxResult:           `invalidDNSyntax`,
+	UnwillingToPerformResult:        `unwillingToPerform`,
+	NamingViolationResult:           `namingViolation`,
+	ObjectClassViolationResult:      `objectClassViolation`,
+	NotAllowedOnNonLeafResult:       `notAllowedOnNonLeaf`,
+	NotAllowedOnRDNResult:           `notAllowedOnRDN`,
+	EntryAlreadyExistsResult:        `entryAlreadyExists`,
+	ObjectClassModsProhibitedResult: `objectClassModsProhibited`,
+	OtherResult:                     `other`,
+}
+
+/*
+entryViolationResults maps each kind of [EntryViolation] to the LDAP
+result code returned by a DSA for such a violation.
+*/
+var entryViolationResults map[uint]uint = map[uint]uint{
+	ObjectClassViolation:         ObjectClassViolationResult,
+	StructuralClassViolation:     ObjectClassViolationResult,
+	AuxiliaryClassViolation:      ObjectClassViolationResult,
+	UnknownAttributeViolation:    UndefinedAttributeTypeResult,
+	MissingAttributeViolation:    ObjectClassViolationResult,
+	DisallowedAttributeViolation: ObjectClassViolationResult,
+	ProhibitedAttributeViolation: ObjectClassViolationResult,
+	SingleValueViolation:         ConstraintViolationResult,
+	NoUserModificationViolation:  ConstraintViolationResult,
+	ValueViolation:               InvalidAttributeSyntaxResult,
+	RDNViolation:                 NamingViolationResult,
+}
+
+/*
+ResultError implements an error bearing an LDAP result code, as returned
+by the write operations of a [DIT] instance.
+
+Code is one of the *Result constants, such as [NamingViolationResult].
+DN contains the DN of the entry in question.  Err contains the underlying
+error, such as an instance of [EntryViolations], if applicable.
+*/
+type ResultError struct {
+	Code uint
+	DN   string
+	Err  error
+}
+
+/*
+Error returns the string representation of the receiver instance, e.g.:
+
+	objectClassViolation (65): cn=Jesse,dc=example,dc=com: required attribute type missing: sn
+
+This method satisfies the error interface.
+*/
+func (r ResultError) Error() (s string) {
+	s = resultCodeLabels[r.Code] + ` (` + itoa(int(r.Code)) + `)`
+	if len(r.DN) > 0 {
+		s += `: ` + r.DN
+	}
+	if r.Err != nil {
+		s += `: ` + r.Err.Error()
+	}
+
+	return
+}
+
+/*
+Unwrap returns the underlying error instance, if any.
+*/
+func (r ResultError) Unwrap() error {
+	return r.Err
+}
+
+/*
+ResultCode returns the LDAP result code conveyed by err. A nil err yields
+[SuccessResult], while errors which are not (and do not wrap) instances of
+[ResultError] yield [OtherResult].
+*/
+func ResultCode(err error) (code uint) {
+	if err != nil {
+		code = OtherResult
+		var re ResultError
+		if errors.As(err, &re) {
+			code = re.Code
+		}
+	}
+
+	return
+}
+
+func resultErr(code uint, dn string, err error) error {
+	return ResultError{Code: code, DN: dn, Err: err}
+}
+
+/*
+Modification operation types, for use within the Op field of instances
+of [Modification], per RFC 4511 Section 4.6.
+*/
+const (
+	AddModification     uint = iota // add values to an attribute
+	DeleteModification              // delete values (or the attribute itself)
+	ReplaceModification             // replace all values of an attribute
+)
+
+/*
+Modification describes a single change to be applied to an entry by way
+of the [DIT.Modify] method.
+
+Op is one of [AddModification], [DeleteModification] or [ReplaceModification].
+Attribute is an attribute description, such as "cn" or "cn;lang-en". Values
+contains the values to be added, deleted or used in replacement.
+*/
+type Modification struct {
+	Op        uint
+	Attribute string
+	Values    []string
+}
+
+/*
+DIT implements an in-memory mock Directory Information Tree, in which
+entries are keyed by DN and managed subject to the contents of a [Schema].
+Instances of this type are created through the [Schema.NewDIT] method.
+
+All write operations are checked in the manner of a DSA:
+
+  - The DN of each entry is normalized through [Schema.NormalizeDN], thus entries are identified as they would be by a directory server
+  - Each entry must bear a superior entry, unless it is a naming context
+  - Each entry must conform to the [Schema] per [Schema.ValidateEntry], which includes the enforcement of [DITContentRule] instances
+  - The RDN of each entry must comply with the [NameForm] of its governing [DITStructureRule], and that rule must be subordinate to the rule which governs the superior entry, if any
+  - Entries bearing subordinates cannot be deleted, and the STRUCTURAL class of an entry cannot be changed
+
+Should an entry belong to a STRUCTURAL class for which no [DITStructureRule]
+is defined, the entry is ungoverned and allowed, unless its superior entry
+is governed by a [DITStructureRule].
+
+Failures are returned as instances of [ResultError], bearing the LDAP result
+code which a DSA would return under the same circumstances.
+*/
+type DIT struct {
+	*dit
+}
+
+type dit struct {
+	schema   Schema
+	suffixes []string
+	entries  map[string]*ditEntry
+}
+
+type ditEntry struct {
+	dn    DN // as supplied
+	ndn   DN // normalized
+	attrs map[string][]string
+	rule  DITStructureRule // governing rule, if any
+}
+
+/*
+NewDIT returns a new, empty instance of [DIT] bound to the receiver
+instance.
+
+Each suffix is the DN of a naming context, being an entry which may be
+added without a superior entry present, e.g.: "dc=example,dc=com".
+Should no suffixes be specified, any entry lacking a superior entry is
+treated as a naming context.  Suffixes which are not valid DNs within
+the context of the receiver are ignored.
+*/
+func (r Schema) NewDIT(suffixes ...string) DIT {
+	d := &dit{
+		schema:  r,
+		entries: make(map[string]*ditEntry),
+	}
+
+	for _, suffix := range suffixes {
+		if ndn, err := r.NormalizeDN(suffix); err == nil && !ndn.IsZero() {
+			d.suffixes = append(d.suffixes, ndn.String())
+		}
+	}
+
+	return DIT{d}
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state.
+*/
+func (r DIT) IsZero() bool {
+	return r.dit == nil
+}
+
+/*
+Schema returns the [Schema] instance to which the receiver is bound.
+*/
+func (r DIT) Schema() (sch Schema) {
+	if !r.IsZero() {
+		sch = r.dit.schema
+	}
+
+	return
+}
+
+/*
+Len returns the integer number of entries present within the receiver.
+*/
+func (r DIT) Len() (l int) {
+	if !r.IsZero() {
+		l = len(r.dit.entries)
+	}
+
+	return
+}
+
+/*
+Entry returns a copy of the attributes of the entry identified by dn,
+alongside an error.
+*/
+func (r DIT) Entry(dn string) (attrs map[string][]string, err error) {
+	var entry *ditEntry
+	if entry, _, err = r.lookup(dn); err == nil {
+		attrs = copyAttributes(entry.attrs)
+	}
+
+	return
+}
+
+/*
+Add adds a new entry identified by dn and bearing attrs to the receiver
+instance, returning an error should the entry violate the [Schema] or
+the structure of the DIT.
+
+Per RFC 4511 Section 4.7, the entry must not already exist, and its
+superior entry must exist unless the entry is a naming context.
+*/
+func (r DIT) Add(dn string, attrs map[string][]string) (err error) {
+	if r.IsZero() {
+		return ErrNilReceiver
+	}
+
+	entry := &ditEntry{attrs: copyAttributes(attrs)}
+	if entry.dn, entry.ndn, err = r.normalize(dn); err != nil {
+		return
+	} else if entry.ndn.IsZero() {
+		return resultErr(UnwillingToPerformResult, dn, mkerr("cannot add the root DSE"))
+	} else if _, found := r.dit.entries[entry.ndn.String()]; found {
+		return resultErr(EntryAlreadyExistsResult, dn, nil)
+	}
+
+	var parent *ditEntry
+	if parent, err = r.superior(entry.ndn); err != nil {
+		return
+	}
+
+	for desc, vals := range entry.attrs {
+		if err = r.checkDuplicates(dn, desc, vals); err != nil {
+			return
+		}
+	}
+
+	if entry.rule, err = r.check(entry, parent); err == nil {
+		r.dit.entries[entry.ndn.String()] = entry
+	}
+
+	return
+}
+
+/*
+Delete removes the entry identified by dn from the receiver instance. Per
+RFC 4511 Section 4.8, only leaf entries may be removed.
+*/
+func (r DIT) Delete(dn string) (err error) {
+	var entry *ditEntry
+	var key string
+	if entry, key, err = r.lookup(dn); err != nil {
+		return
+	} else if len(r.subordinates(entry.ndn)) > 0 {
+		return resultErr(NotAllowedOnNonLeafResult, dn, nil)
+	}
+
+	delete(r.dit.entries, key)
+
+	return
+}
+
+/*
+Modify applies mods to the entry identified by dn in the manner described
+by RFC 4511 Section 4.6.  The modifications are applied atomically: should
+any single modification fail, or should the resulting entry violate the
+[Schema], the entry remains unchanged.
+
+Values of the RDN of the entry cannot be removed, nor can the STRUCTURAL
+class of the entry be changed.
+*/
+func (r DIT) Modify(dn string, mods ...Modification) (err error) {
+	var entry *ditEntry
+	if entry, _, err = r.lookup(dn); err != nil {
+		return
+	}
+
+	attrs := copyAttributes(entry.attrs)
+	for _, mod := range mods {
+		if err = r.modify(dn, attrs, mod); err != nil {
+			return
+		}
+	}
+
+	for _, atv := range entry.dn.RDN() {
+		if !r.hasValue(attrs, atv.Type, atv.Value) {
+			return resultErr(NotAllowedOnRDNResult, dn,
+				mkerr("RDN value of "+atv.Type+" cannot be removed"))
+		}
+	}
+
+	modified := &ditEntry{dn: entry.dn, ndn: entry.ndn, attrs: attrs}
+	if err = r.checkStructural(entry, modified); err != nil {
+		return
+	}
+
+	parent, _ := r.superior(entry.ndn)
+	if modified.rule, err = r.check(modified, parent); err == nil {
+		entry.attrs, entry.rule = modified.attrs, modified.rule
+	}
+
+	return
+}
+
+/*
+ModifyDN renames the entry identified by dn to bear newRDN in the manner
+described by RFC 4511 Section 4.9.  Should deleteOldRDN be true, the values
+of the former RDN are removed from the entry.  Should newSuperior be non
+zero, the entry (and all of its subordinates) are moved beneath the entry
+identified by newSuperior.
+*/
+func (r DIT) ModifyDN(dn, newRDN string, deleteOldRDN bool, newSuperior string) (err error) {
+	var entry *ditEntry
+	var key string
+	if entry, key, err = r.lookup(dn); err != nil {
+		return
+	}
+
+	var rdn, nrdn DN
+	if rdn, nrdn, err = r.normalize(newRDN); err != nil {
+		return
+	} else if rdn.Len() != 1 {
+		return resultErr(InvalidDNSyntaxResult, newRDN, mkerr("new RDN must bear exactly one RDN"))
+	}
+
+	sup, nsup := entry.dn.Parent(), entry.ndn.Parent()
+	if len(newSuperior) > 0 {
+		if sup, nsup, err = r.normalize(newSuperior); err != nil {
+			return
+		} else if _, found := r.dit.entries[nsup.String()]; !found {
+			return resultErr(NoSuchObjectResult, newSuperior, mkerr("new superior entry does not exist"))
+		}
+	}
+
+	moved := &ditEntry{
+		dn:    append(DN{rdn[0]}, sup...),
+		ndn:   append(DN{nrdn[0]}, nsup...),
+		attrs: copyAttributes(entry.attrs),
+	}
+
+	if newKey := moved.ndn.String(); newKey != key {
+		if _, found := r.dit.entries[newKey]; found {
+			return resultErr(EntryAlreadyExistsResult, moved.dn.String(), nil)
+		} else if hasSfx(nsup.String(), `,`+key) || nsup.String() == key {
+			return resultErr(UnwillingToPerformResult, dn, mkerr("cannot move an entry beneath itself"))
+		}
+	}
+
+	var parent *ditEntry
+	if parent, err = r.superior(moved.ndn); err != nil {
+		return
+	}
+
+	if deleteOldRDN {
+		for _, atv := range entry.dn.RDN() {
+			r.removeValue(moved.attrs, atv.Type, atv.Value)
+		}
+	}
+
+	for _, atv := range rdn.RDN() {
+		if !r.hasValue(moved.attrs, atv.Type, atv.Value) {
+			moved.attrs[atv.Type] = append(moved.attrs[atv.Type], atv.Value)
+		}
+	}
+
+	if err = r.checkStructural(entry, moved); err != nil {
+		return
+	} else if moved.rule, err = r.check(moved, parent); err != nil {
+		return
+	}
+
+	subs := r.subordinates(entry.ndn)
+	for _, sub := range subs {
+		if sub.ndn.Len() == entry.ndn.Len()+1 {
+			if err = r.checkRuleChain(moved.rule, sub); err != nil {
+				return
+			}
+		}
+	}
+
+	// Subordinates retain the RDNs beneath the original
+	// entry, whose depth may differ from that of moved.
+	oldLen := entry.ndn.Len()
+
+	delete(r.dit.entries, key)
+	entry.dn, entry.ndn, entry.attrs, entry.rule = moved.dn, moved.ndn, moved.attrs, moved.rule
+	r.dit.entries[entry.ndn.String()] = entry
+
+	for _, sub := range subs {
+		delete(r.dit.entries, sub.ndn.String())
+	}
+
+	for _, sub := range subs {
+		depth := sub.ndn.Len() - oldLen
+		sub.dn = append(append(DN{}, sub.dn[:depth]...), moved.dn...)
+		sub.ndn = append(append(DN{}, sub.ndn[:depth]...), moved.ndn...)
+		r.dit.entries[sub.ndn.String()] = sub
+	}
+
+	return
+}
+
+/*
+normalize returns dn in parsed and normalized form, alongside an error.
+*/
+func (r DIT) normalize(dn string) (d, ndn DN, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+	} else if d, err = ParseDN(dn); err != nil {
+		err = resultErr(InvalidDNSyntaxResult, dn, err)
+	} else if ndn, err = r.dit.schema.NormalizeDN(d); err != nil {
+		err = resultErr(InvalidDNSyntaxResult, dn, err)
+	}
+
+	return
+}
+
+/*
+lookup returns the entry identified by dn and its key, alongside an error.
+*/
+func (r DIT) lookup(dn string) (entry *ditEntry, key string, err error) {
+	var ndn DN
+	if _, ndn, err = r.normalize(dn); err == nil {
+		var found bool
+		key = ndn.String()
+		if entry, found = r.dit.entries[key]; !found {
+			err = resultErr(NoSuchObjectResult, dn, nil)
+		}
+	}
+
+	return
+}
+
+/*
+superior returns the superior entry of the entry identified by ndn, which
+is nil should the entry be a naming context, alongside an error should the
+superior entry be absent.
+*/
+func (r DIT) superior(ndn DN) (parent *ditEntry, err error) {
+	key := ndn.String()
+	if strInSlice(key, r.dit.suffixes) {
+		return
+	}
+
+	var found bool
+	if parent, found = r.dit.entries[ndn.Parent().String()]; !found {
+		parent = nil
+		if len(r.dit.suffixes) > 0 {
+			err = resultErr(NoSuchObjectResult, ndn.Parent().String(),
+				mkerr("superior entry does not exist"))
+		}
+	}
+
+	return
+}
+
+/*
+subordinates returns all entries which are subordinate to the entry
+identified by ndn, at any depth.
+*/
+func (r DIT) subordinates(ndn DN) (subs []*ditEntry) {
+	sfx := `,` + ndn.String()
+	for key, entry := range r.dit.entries {
+		if hasSfx(key, sfx) {
+			subs = append(subs, entry)
+		}
+	}
+
+	return
+}
+
+/*
+check returns the [DITStructureRule] governing entry, alongside an error
+should entry violate the [Schema] or the structure of the DIT.
+*/
+func (r DIT) check(entry *ditEntry, parent *ditEntry) (rule DITStructureRule, err error) {
+	dn := entry.dn.String()
+
+	var v EntryViolations
+	if v, err = r.dit.schema.ValidateEntry(dn, entry.attrs); err != nil {
+		err = resultErr(ProtocolErrorResult, dn, err)
+		return
+	} else if !v.IsZero() {
+		err = resultErr(entryViolationResults[v[0].Kind], dn, v.Err())
+		return
+	}
+
+	var prule DITStructureRule
+	if parent != nil {
+		prule = parent.rule
+	}
+
+	if rule, err = r.governingRule(entry, prule); err != nil {
+		err = resultErr(NamingViolationResult, dn, err)
+	}
+
+	return
+}
+
+/*
+governingRule returns the [DITStructureRule] which governs entry beneath
+a superior entry governed by prule, which may be zero, alongside an error.
+*/
+func (r DIT) governingRule(entry *ditEntry, prule DITStructureRule) (rule DITStructureRule, err error) {
+	structural := r.structuralClass(entry.attrs)
+
+	var rules []DITStructureRule
+	dsrs := r.dit.schema.DITStructureRules()
+	for i := 0; i < dsrs.Len(); i++ {
+		if dsr := dsrs.Index(i); dsr.Form().OC().NumericOID() == structural.NumericOID() {
+			rules = append(rules, dsr)
+		}
+	}
+
+	if len(rules) == 0 {
+		if !prule.IsZero() {
+			err = mkerr("no structure rule governs " + structural.OID() +
+				" entries beneath entries governed by rule " + itoa(int(prule.RuleID())))
+		}
+		return
+	}
+
+	err = mkerr("no structure rule permits the entry beneath its superior")
+	for _, dsr := range rules {
+		if sups := dsr.SuperRules(); (prule.IsZero() && sups.Len() == 0) ||
+			(!prule.IsZero() && hasRule(sups, prule)) {
+			if err = checkNameForm(dsr.Form(), entry.ndn.RDN()); err == nil {
+				rule = dsr
+				break
+			}
+		}
+	}
+
+	return
+}
+
+func hasRule(rules DITStructureRules, rule DITStructureRule) bool {
+	for i := 0; i < rules.Len(); i++ {
+		if rules.Index(i).RuleID() == rule.RuleID() {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+checkRuleChain returns an error should the rule governing sub not permit
+sub beneath an entry governed by prule.
+*/
+func (r DIT) checkRuleChain(prule DITStructureRule, sub *ditEntry) (err error) {
+	if _, err = r.governingRule(sub, prule); err != nil {
+		err = resultErr(NamingViolationResult, sub.dn.String(), err)
+	}
+
+	return
+}
+
+/*
+checkNameForm returns an error should rdn fail to comply with nf.
+*/
+func checkNameForm(nf NameForm, rdn RDN) error {
+	must, may := nf.Must(), nf.May()
+
+	var types []string
+	for _, atv := range rdn {
+		if !must.Contains(atv.Type) && !may.Contains(atv.Type) {
+			return wraperr(ErrNamingViolationUnsanctioned, ": "+atv.Type)
+		}
+		types = append(types, lc(atv.Type))
+	}
+
+	for i := 0; i < must.Len(); i++ {
+		if !strInSlice(lc(must.Index(i).OID()), types) &&
+			!strInSlice(must.Index(i).NumericOID(), types) {
+			return wraperr(ErrNamingViolationMissingMust, ": "+must.Index(i).OID())
+		}
+	}
+
+	return nil
+}
+
+/*
+structuralClass returns the STRUCTURAL class of the entry bearing attrs.
+*/
+func (r DIT) structuralClass(attrs map[string][]string) (structural ObjectClass) {
+	if ocs := r.objectClasses(attrs); !ocs.IsZero() {
+		structural, _, _ = entryStructuralClass(ocs)
+	}
+
+	return
+}
+
+/*
+objectClasses returns the object classes of the entry bearing attrs,
+alongside all of their superclasses.
+*/
+func (r DIT) objectClasses(attrs map[string][]string) (ocs ObjectClasses) {
+	present, _ := r.dit.schema.entryAttributes(attrs)
+	ocs, _ = r.dit.schema.entryObjectClasses(present)
+
+	return
+}
+
+/*
+checkStructural returns an error should the STRUCTURAL classes of modified
+differ from those of entry.
+*/
+func (r DIT) checkStructural(entry, modified *ditEntry) error {
+	structurals := func(ocs ObjectClasses) (oids []string) {
+		for i := 0; i < ocs.Len(); i++ {
+			if oc := ocs.Index(i); oc.Kind() == StructuralKind && !strInSlice(oc.NumericOID(), oids) {
+				oids = append(oids, oc.NumericOID())
+			}
+		}
+		sort.Strings(oids)
+		return
+	}
+
+	before := structurals(r.objectClasses(entry.attrs))
+	after := structurals(r.objectClasses(modified.attrs))
+	if len(after) > 0 && join(before, ` `) != join(after, ` `) {
+		return resultErr(ObjectClassModsProhibitedResult, entry.dn.String(),
+			mkerr("structural object class cannot be changed"))
+	}
+
+	return nil
+}
+
+/*
+modify applies mod to attrs on behalf of the entry identified by dn.
+*/
+func (r DIT) modify(dn string, attrs map[string][]string, mod Modification) (err error) {
+	at, key := r.describe(attrs, mod.Attribute)
+	if at.IsZero() {
+		return resultErr(UndefinedAttributeTypeResult, dn, mkerr(mod.Attribute))
+	}
+
+	switch mod.Op {
+	case AddModification:
+		if len(mod.Values) == 0 {
+			return resultErr(ProtocolErrorResult, dn, mkerr("no values to add for "+mod.Attribute))
+		} else if err = r.checkDuplicates(dn, mod.Attribute, mod.Values); err != nil {
+			return
+		}
+		if len(key) == 0 {
+			key = mod.Attribute
+		}
+		for _, val := range mod.Values {
+			if valuesContain(at, attrs[key], val) {
+				return resultErr(AttributeOrValueExistsResult, dn, mkerr(mod.Attribute+" '"+val+"'"))
+			}
+		}
+		attrs[key] = append(attrs[key], mod.Values...)
+	case DeleteModification:
+		if len(key) == 0 {
+			return resultErr(NoSuchAttributeResult, dn, mkerr(mod.Attribute))
+		}
+		for _, val := range mod.Values {
+			if !valuesContain(at, attrs[key], val) {
+				return resultErr(NoSuchAttributeResult, dn, mkerr(mod.Attribute+" '"+val+"'"))
+			}
+			attrs[key] = removeValue(at, attrs[key], val)
+		}
+		if len(mod.Values) == 0 || len(attrs[key]) == 0 {
+			delete(attrs, key)
+		}
+	case ReplaceModification:
+		if err = r.checkDuplicates(dn, mod.Attribute, mod.Values); err != nil {
+			return
+		}
+		if len(key) > 0 {
+			delete(attrs, key)
+		}
+		if len(mod.Values) > 0 {
+			attrs[mod.Attribute] = append([]string{}, mod.Values...)
+		}
+	default:
+		err = resultErr(ProtocolErrorResult, dn, mkerr("unknown modification operation "+itoa(int(mod.Op))))
+	}
+
+	return
+}
+
+/*
+describe returns the [AttributeType] of the attribute description desc,
+alongside the key within attrs which bears the same attribute description,
+if present.
+*/
+func (r DIT) describe(attrs map[string][]string, desc string) (at AttributeType, key string) {
+	typ, opts := splitAttributeDescription(desc)
+	if at = r.dit.schema.AttributeTypes().get(typ); at.IsZero() {
+		return
+	}
+
+	for k := range attrs {
+		ktyp, kopts := splitAttributeDescription(k)
+		if len(opts) == len(kopts) && hasOptions(kopts, opts) &&
+			at.NumericOID() == r.dit.schema.AttributeTypes().get(ktyp).NumericOID() {
+			key = k
+			break
+		}
+	}
+
+	return
+}
+
+/*
+checkDuplicates returns an error should vals contain duplicate values per
+the equality rule of the attribute type of desc.
+*/
+func (r DIT) checkDuplicates(dn, desc string, vals []string) error {
+	typ, _ := splitAttributeDescription(desc)
+	at := r.dit.schema.AttributeTypes().get(typ)
+	for i := range vals {
+		if valuesContain(at, vals[:i], vals[i]) {
+			return resultErr(AttributeOrValueExistsResult, dn, mkerr(desc+" '"+vals[i]+"'"))
+		}
+	}
+
+	return nil
+}
+
+/*
+hasValue returns a Boolean value indicative of val being present within
+any attribute of attrs of type typ, regardless of attribute options.
+*/
+func (r DIT) hasValue(attrs map[string][]string, typ, val string) bool {
+	at := r.dit.schema.AttributeTypes().get(typ)
+	for desc, vals := range attrs {
+		dtyp, _ := splitAttributeDescription(desc)
+		if r.dit.schema.AttributeTypes().get(dtyp).NumericOID() == at.NumericOID() &&
+			valuesContain(at, vals, val) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+removeValue removes val from all attributes of attrs of type typ, removing
+any attribute left without values.
+*/
+func (r DIT) removeValue(attrs map[string][]string, typ, val string) {
+	at := r.dit.schema.AttributeTypes().get(typ)
+	for desc, vals := range attrs {
+		dtyp, _ := splitAttributeDescription(desc)
+		if r.dit.schema.AttributeTypes().get(dtyp).NumericOID() == at.NumericOID() {
+			if attrs[desc] = removeValue(at, vals, val); len(attrs[desc]) == 0 {
+				delete(attrs, desc)
+			}
+		}
+	}
+}
+
+/*
+valuesContain returns a Boolean value indicative of val being present
+within vals per the effective equality rule of at.  Values are compared
+exactly should no [AssertionMatcher] be available.
+*/
+func valuesContain(at AttributeType, vals []string, val string) bool {
+	funk := matcher(at.EffectiveEquality())
+	for _, v := range vals {
+		if v == val || (funk != nil && funk(v, val) == nil) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+removeValue returns vals less all values equal to val per the effective
+equality rule of at.
+*/
+func removeValue(at AttributeType, vals []string, val string) (out []string) {
+	for _, v := range vals {
+		if !valuesContain(at, []string{v}, val) {
+			out = append(out, v)
+		}
+	}
+
+	return
+}
+
+func copyAttributes(attrs map[string][]string) map[string][]string {
+	_attrs := make(map[string][]string, len(attrs))
+	for desc, vals := range attrs {
+		_attrs[desc] = append([]string{}, vals...)
+	}
+
+	return _attrs
+}
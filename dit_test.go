@@ -0,0 +1,340 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the use of a [DIT] as a local stand-in for a
+directory server, and the LDAP result codes returned upon failure.
+*/
+func ExampleDIT() {
+	sch := NewSchema()
+	dit := sch.NewDIT(`dc=example,dc=com`)
+
+	fmt.Println(dit.Add(`dc=example,dc=com`, map[string][]string{
+		`objectClass`: {`top`, `domain`},
+		`dc`:          {`example`},
+	}))
+
+	err := dit.Add(`cn=Jesse Coretta,dc=example,dc=com`, map[string][]string{
+		`objectClass`: {`person`},
+		`cn`:          {`Jesse Coretta`},
+	})
+	fmt.Println(ResultCode(err))
+	fmt.Println(err)
+	// Output:
+	// <nil>
+	// 65
+	// objectClassViolation (65): cn=Jesse Coretta,dc=example,dc=com: required attribute type missing: sn
+}
+
+/*
+ditTestSchema returns a [Schema] bearing structure rules which permit
+domain entries at the top of the DIT, organizational units beneath
+domains and persons beneath organizational units.
+*/
+func ditTestSchema(t *testing.T) Schema {
+	sch := NewSchema()
+	if err := sch.ParseRaw([]byte(`nameForm ( 1.3.6.1.4.1.56521.999.84.1
+	NAME 'testOUNameForm'
+	OC organizationalUnit
+	MUST ou )
+
+nameForm ( 1.3.6.1.4.1.56521.999.84.2
+	NAME 'testPersonNameForm'
+	OC inetOrgPerson
+	MUST uid
+	MAY cn )
+
+dITStructureRule ( 100
+	NAME 'testDomainStructureRule'
+	FORM domainNameForm )
+
+dITStructureRule ( 101
+	NAME 'testOUStructureRule'
+	FORM testOUNameForm
+	SUP 100 )
+
+dITStructureRule ( 102
+	NAME 'testPersonStructureRule'
+	FORM testPersonNameForm
+	SUP 101 )
+
+dITContentRule ( 2.16.840.1.113730.3.2.2
+	NAME 'testInetOrgPersonContentRule'
+	NOT mobile )`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	return sch
+}
+
+func TestDIT(t *testing.T) {
+	dit := ditTestSchema(t).NewDIT(`dc=example,dc=com`)
+
+	person := func(uid string, extra ...string) map[string][]string {
+		attrs := map[string][]string{
+			`objectClass`: {`top`, `person`, `organizationalPerson`, `inetOrgPerson`},
+			`uid`:         {uid},
+			`cn`:          {uid + ` Coretta`},
+			`sn`:          {`Coretta`},
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			attrs[extra[i]] = append(attrs[extra[i]], extra[i+1])
+		}
+		return attrs
+	}
+
+	for idx, tc := range []struct {
+		dn    string
+		attrs map[string][]string
+		code  uint
+	}{
+		{`ou=People,dc=example,dc=com`, map[string][]string{`objectClass`: {`organizationalUnit`}, `ou`: {`People`}}, NoSuchObjectResult},
+		{`dc=example,dc=com`, map[string][]string{`objectClass`: {`domain`}, `dc`: {`example`}}, SuccessResult},
+		{`DC=Example,DC=COM`, map[string][]string{`objectClass`: {`domain`}, `dc`: {`example`}}, EntryAlreadyExistsResult},
+		{`dc=other,dc=com`, map[string][]string{`objectClass`: {`domain`}, `dc`: {`other`}}, NoSuchObjectResult},
+		{`ou=People,dc=example,dc=com`, map[string][]string{`objectClass`: {`organizationalUnit`}, `ou`: {`People`}}, SuccessResult},
+		{`uid=jesse,dc=example,dc=com`, person(`jesse`), NamingViolationResult},
+		{`cn=jesse Coretta,ou=People,dc=example,dc=com`, person(`jesse`), NamingViolationResult},
+		{`uid=jesse,ou=People,dc=example,dc=com`, person(`jesse`), SuccessResult},
+		{`uid=jesse+sn=coretta,ou=People,dc=example,dc=com`, person(`jesse`), NamingViolationResult},
+		{`uid=courtney,ou=People,dc=example,dc=com`, person(`courtney`, `mobile`, `+1 555 555 5555`), ObjectClassViolationResult},
+		{`uid=courtney,ou=People,dc=example,dc=com`, person(`courtney`, `bogusType`, `x`), UndefinedAttributeTypeResult},
+		{`uid=courtney,ou=People,dc=example,dc=com`, person(`courtney`, `cn`, `COURTNEY coretta`), AttributeOrValueExistsResult},
+		{`uid=courtney,ou=People,dc=example,dc=com`, person(`courtney`, `employeeNumber`, `1`, `employeeNumber`, `2`), ConstraintViolationResult},
+		{`uid=nobody,ou=People,dc=example,dc=com`, person(`courtney`), NamingViolationResult},
+		{`ou=Groups,ou=People,dc=example,dc=com`, map[string][]string{`objectClass`: {`organizationalUnit`}, `ou`: {`Groups`}}, NamingViolationResult},
+		{`bogus=x,dc=example,dc=com`, nil, InvalidDNSyntaxResult},
+		{``, nil, UnwillingToPerformResult},
+	} {
+		if err := dit.Add(tc.dn, tc.attrs); ResultCode(err) != tc.code {
+			t.Errorf("%s[%d] failed: want code %d, got %v", t.Name(), idx, tc.code, err)
+		}
+	}
+
+	if dit.Len() != 3 {
+		t.Errorf("%s failed: want 3 entries, got %d", t.Name(), dit.Len())
+	}
+
+	dn := `UID=Jesse,OU=people,DC=example,DC=com`
+	for idx, tc := range []struct {
+		mods []Modification
+		code uint
+	}{
+		{[]Modification{{AddModification, `mail`, []string{`jc@example.com`}}}, SuccessResult},
+		{[]Modification{{AddModification, `mail`, []string{`JC@example.com`}}}, AttributeOrValueExistsResult},
+		{[]Modification{{AddModification, `mail`, nil}}, ProtocolErrorResult},
+		{[]Modification{{AddModification, `mobile`, []string{`+1 555 555 5555`}}}, ObjectClassViolationResult},
+		{[]Modification{{AddModification, `bogusType`, []string{`x`}}}, UndefinedAttributeTypeResult},
+		{[]Modification{{AddModification, `objectClass`, []string{`device`}}}, ObjectClassModsProhibitedResult},
+		{[]Modification{{AddModification, `cn;lang-en`, []string{`Jesse`}}}, SuccessResult},
+		{[]Modification{{DeleteModification, `CN;LANG-EN`, []string{`jesse`}}}, SuccessResult},
+		{[]Modification{{DeleteModification, `cn;lang-en`, nil}}, NoSuchAttributeResult},
+		{[]Modification{{DeleteModification, `mail`, []string{`other@example.com`}}}, NoSuchAttributeResult},
+		{[]Modification{{DeleteModification, `uid`, nil}}, NotAllowedOnRDNResult},
+		{[]Modification{{DeleteModification, `sn`, nil}}, ObjectClassViolationResult},
+		{[]Modification{{ReplaceModification, `sn`, []string{`Smith`, `smith`}}}, AttributeOrValueExistsResult},
+		{[]Modification{{ReplaceModification, `uid`, []string{`jesse`, `jc`}}}, SuccessResult},
+		{[]Modification{
+			{ReplaceModification, `description`, []string{`a`}},
+			{DeleteModification, `mail`, nil},
+			{ReplaceModification, `description`, nil},
+		}, SuccessResult},
+		{[]Modification{{9, `description`, []string{`a`}}}, ProtocolErrorResult},
+		{[]Modification{
+			{ReplaceModification, `description`, []string{`a`}},
+			{DeleteModification, `mail`, nil},
+		}, NoSuchAttributeResult},
+	} {
+		if err := dit.Modify(dn, tc.mods...); ResultCode(err) != tc.code {
+			t.Errorf("%s[%d] failed: want code %d, got %v", t.Name(), idx, tc.code, err)
+		}
+	}
+
+	// the failed modification above must not have been applied
+	if attrs, err := dit.Entry(dn); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+	} else if _, found := attrs[`description`]; found || len(attrs[`uid`]) != 2 {
+		t.Errorf("%s failed: unexpected entry state %v", t.Name(), attrs)
+	}
+
+	if err := dit.Modify(`uid=nobody,ou=People,dc=example,dc=com`); ResultCode(err) != NoSuchObjectResult {
+		t.Errorf("%s failed: want code %d, got %v", t.Name(), NoSuchObjectResult, err)
+	}
+
+	for idx, tc := range []struct {
+		dn   string
+		code uint
+	}{
+		{`ou=People,dc=example,dc=com`, NotAllowedOnNonLeafResult},
+		{`uid=nobody,ou=People,dc=example,dc=com`, NoSuchObjectResult},
+		{`uid=jesse,ou=People,dc=example,dc=com`, SuccessResult},
+		{`uid=jesse,ou=People,dc=example,dc=com`, NoSuchObjectResult},
+		{`ou=People,dc=example,dc=com`, SuccessResult},
+	} {
+		if err := dit.Delete(tc.dn); ResultCode(err) != tc.code {
+			t.Errorf("%s[%d] failed: want code %d, got %v", t.Name(), idx, tc.code, err)
+		}
+	}
+}
+
+func TestDIT_ModifyDN(t *testing.T) {
+	dit := ditTestSchema(t).NewDIT()
+
+	for _, entry := range []struct {
+		dn    string
+		attrs map[string][]string
+	}{
+		{`dc=example,dc=com`, map[string][]string{`objectClass`: {`domain`}, `dc`: {`example`}}},
+		{`ou=People,dc=example,dc=com`, map[string][]string{`objectClass`: {`organizationalUnit`}, `ou`: {`People`}}},
+		{`ou=Staff,dc=example,dc=com`, map[string][]string{`objectClass`: {`organizationalUnit`}, `ou`: {`Staff`}}},
+		{`uid=jesse,ou=People,dc=example,dc=com`, map[string][]string{
+			`objectClass`: {`inetOrgPerson`}, `uid`: {`jesse`}, `cn`: {`Jesse`}, `sn`: {`Coretta`}}},
+	} {
+		if err := dit.Add(entry.dn, entry.attrs); err != nil {
+			t.Fatalf("%s failed: %v", t.Name(), err)
+		}
+	}
+
+	for idx, tc := range []struct {
+		dn, newRDN   string
+		deleteOldRDN bool
+		newSuperior  string
+		code         uint
+	}{
+		{`uid=nobody,ou=People,dc=example,dc=com`, `uid=x`, true, ``, NoSuchObjectResult},
+		{`uid=jesse,ou=People,dc=example,dc=com`, `uid=x,ou=y`, true, ``, InvalidDNSyntaxResult},
+		{`uid=jesse,ou=People,dc=example,dc=com`, `uid=jesse`, true, `ou=Nowhere,dc=example,dc=com`, NoSuchObjectResult},
+		{`uid=jesse,ou=People,dc=example,dc=com`, `uid=jesse`, true, `dc=example,dc=com`, NamingViolationResult},
+		{`uid=jesse,ou=People,dc=example,dc=com`, `sn=Coretta`, true, ``, NamingViolationResult},
+		{`uid=jesse,ou=People,dc=example,dc=com`, `uid=jc`, true, ``, SuccessResult},
+		{`uid=jc,ou=People,dc=example,dc=com`, `uid=jesse`, false, ``, SuccessResult},
+		{`ou=People,dc=example,dc=com`, `ou=Staff`, true, ``, EntryAlreadyExistsResult},
+		{`ou=People,dc=example,dc=com`, `ou=People`, true, `uid=jesse,ou=People,dc=example,dc=com`, UnwillingToPerformResult},
+		{`ou=People,dc=example,dc=com`, `ou=Persons`, true, ``, SuccessResult},
+		{`dc=example,dc=com`, `dc=sample`, true, ``, SuccessResult},
+	} {
+		if err := dit.ModifyDN(tc.dn, tc.newRDN, tc.deleteOldRDN, tc.newSuperior); ResultCode(err) != tc.code {
+			t.Errorf("%s[%d] failed: want code %d, got %v", t.Name(), idx, tc.code, err)
+		}
+	}
+
+	attrs, err := dit.Entry(`uid=jesse,ou=persons,dc=sample,dc=com`)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+	} else if len(attrs[`uid`]) != 2 {
+		t.Errorf("%s failed: want both RDN values retained, got %v", t.Name(), attrs[`uid`])
+	}
+
+	if attrs, _ = dit.Entry(`ou=persons,dc=sample,dc=com`); len(attrs[`ou`]) != 1 || attrs[`ou`][0] != `Persons` {
+		t.Errorf("%s failed: want old RDN value removed, got %v", t.Name(), attrs[`ou`])
+	}
+
+	if dit.Len() != 4 {
+		t.Errorf("%s failed: want 4 entries, got %d", t.Name(), dit.Len())
+	}
+}
+
+/*
+The subordinates of an entry moved to a different depth within the DIT
+must retain their own RDNs.
+*/
+func TestDIT_ModifyDN_depth(t *testing.T) {
+	sch := ditTestSchema(t)
+	if err := sch.ParseRaw([]byte(`dITStructureRule ( 103
+	NAME 'testNestedOUStructureRule'
+	FORM testOUNameForm
+	SUP 101 )
+
+dITStructureRule ( 104
+	NAME 'testNestedOU2StructureRule'
+	FORM testOUNameForm
+	SUP 103 )
+
+dITStructureRule ( 105
+	NAME 'testNestedOU3StructureRule'
+	FORM testOUNameForm
+	SUP 104 )`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	dit := sch.NewDIT()
+	ou := func(name string) map[string][]string {
+		return map[string][]string{`objectClass`: {`organizationalUnit`}, `ou`: {name}}
+	}
+
+	for _, entry := range []struct {
+		dn    string
+		attrs map[string][]string
+	}{
+		{`dc=example,dc=com`, map[string][]string{`objectClass`: {`domain`}, `dc`: {`example`}}},
+		{`ou=a,dc=example,dc=com`, ou(`a`)},
+		{`ou=b,ou=a,dc=example,dc=com`, ou(`b`)},
+		{`ou=x,ou=a,dc=example,dc=com`, ou(`x`)},
+		{`ou=y,ou=x,ou=a,dc=example,dc=com`, ou(`y`)},
+	} {
+		if err := dit.Add(entry.dn, entry.attrs); err != nil {
+			t.Fatalf("%s failed: %v", t.Name(), err)
+		}
+	}
+
+	if err := dit.ModifyDN(`ou=x,ou=a,dc=example,dc=com`, `ou=x`, true, `ou=b,ou=a,dc=example,dc=com`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	if dit.Len() != 5 {
+		t.Errorf("%s failed: want 5 entries, got %d", t.Name(), dit.Len())
+	}
+
+	for dn, want := range map[string]string{
+		`ou=x,ou=b,ou=a,dc=example,dc=com`:      `x`,
+		`ou=y,ou=x,ou=b,ou=a,dc=example,dc=com`: `y`,
+	} {
+		if attrs, err := dit.Entry(dn); err != nil {
+			t.Errorf("%s failed: %v", t.Name(), err)
+		} else if len(attrs[`ou`]) != 1 || attrs[`ou`][0] != want {
+			t.Errorf("%s failed: %s: want ou %q, got %v", t.Name(), dn, want, attrs[`ou`])
+		}
+	}
+
+	for _, dn := range []string{
+		`ou=x,ou=a,dc=example,dc=com`,
+		`ou=y,ou=x,ou=a,dc=example,dc=com`,
+	} {
+		if _, err := dit.Entry(dn); ResultCode(err) != NoSuchObjectResult {
+			t.Errorf("%s failed: %s: want code %d, got %v", t.Name(), dn, NoSuchObjectResult, err)
+		}
+	}
+}
+
+func TestDIT_codecov(t *testing.T) {
+	var dit DIT
+	_ = dit.IsZero()
+	_ = dit.Schema()
+	_ = dit.Len()
+
+	if err := dit.Add(`dc=com`, nil); !errors.Is(err, ErrNilReceiver) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilReceiver, err)
+	}
+	if _, err := dit.Entry(`dc=com`); !errors.Is(err, ErrNilReceiver) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilReceiver, err)
+	}
+
+	if ResultCode(nil) != SuccessResult || ResultCode(ErrNilInput) != OtherResult {
+		t.Errorf("%s failed: unexpected result codes", t.Name())
+	}
+
+	err := ResultError{Code: NamingViolationResult}
+	if err.Error() != `namingViolation (64)` || err.Unwrap() != nil {
+		t.Errorf("%s failed: unexpected error %q", t.Name(), err)
+	}
+
+	dit = NewSchema().NewDIT(`bogus`, ``)
+	if e := dit.Add(`dc=com`, nil); ResultCode(e) != ProtocolErrorResult {
+		t.Errorf("%s failed: want code %d, got %v", t.Name(), ProtocolErrorResult, e)
+	}
+}
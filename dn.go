@@ -0,0 +1,428 @@
+package schemax
+
+/*
+dn.go contains facilities for the parsing, normalization and comparison
+of RFC 4514 distinguished names.
+*/
+
+import (
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+DN implements an RFC 4514 distinguished name, as returned by [ParseDN].
+
+The RDNs are ordered from the leaf (the RDN of the named entry) to the
+root, in the same manner as the string representation of a DN.  A zero
+length DN denotes the root DSE.
+*/
+type DN []RDN
+
+/*
+RDN implements an RFC 4514 relative distinguished name, composed of one
+or more (multi-valued) [AttributeTypeAndValue] instances.
+*/
+type RDN []AttributeTypeAndValue
+
+/*
+AttributeTypeAndValue implements a single attribute type and value pair
+within an [RDN].
+
+Value contains the attribute value less any RFC 4514 escapes.  Should BER
+be true, Value contains the raw octets of a BER encoded value, which is
+expressed in hexstring form within the string representation of a DN,
+e.g.: "#04024869".
+*/
+type AttributeTypeAndValue struct {
+	Type  string
+	Value string
+	BER   bool
+}
+
+/*
+ParseDN returns an instance of [DN] alongside an error following an
+attempt to parse raw as an RFC 4514 distinguished name.  Escaped values,
+whether in backslash-character or backslash-hexpair form, as well as
+multi-valued RDNs and BER encoded hexstring values are supported.
+
+Failures are returned as [ErrInvalidSyntax] errors.
+*/
+func ParseDN(raw string) (dn DN, err error) {
+	var rdns [][][2]string
+	if rdns, err = splitDN(raw); err != nil {
+		return
+	}
+
+	for _, rdn := range rdns {
+		var _rdn RDN
+		for _, ava := range rdn {
+			atv := AttributeTypeAndValue{Type: ava[0]}
+			if hasPfx(ava[1], `#`) {
+				atv.BER = true
+				for i := 1; i < len(ava[1]); i += 2 {
+					atv.Value += string(hexByte(ava[1][i])<<4 | hexByte(ava[1][i+1]))
+				}
+			} else {
+				atv.Value = unescapeRDNValue(ava[1])
+			}
+			_rdn = append(_rdn, atv)
+		}
+		dn = append(dn, _rdn)
+	}
+
+	return
+}
+
+/*
+IsZero returns a Boolean value indicative of a zero length receiver,
+which denotes the root DSE.
+*/
+func (r DN) IsZero() bool {
+	return len(r) == 0
+}
+
+/*
+Len returns the integer number of RDNs within the receiver instance.
+*/
+func (r DN) Len() int {
+	return len(r)
+}
+
+/*
+RDN returns the leftmost (leaf) [RDN] of the receiver instance.
+*/
+func (r DN) RDN() (rdn RDN) {
+	if !r.IsZero() {
+		rdn = r[0]
+	}
+
+	return
+}
+
+/*
+Parent returns the [DN] of the entry immediately superior to the entry
+named by the receiver instance.
+*/
+func (r DN) Parent() (dn DN) {
+	if !r.IsZero() {
+		dn = r[1:]
+	}
+
+	return
+}
+
+/*
+String returns the RFC 4514 string representation of the receiver.
+*/
+func (r DN) String() string {
+	var rdns []string
+	for _, rdn := range r {
+		rdns = append(rdns, rdn.String())
+	}
+
+	return join(rdns, `,`)
+}
+
+/*
+Equal returns a Boolean value indicative of the receiver being equal to
+dn.  Attribute types are compared without regard for case, while values
+are compared exactly.  The order of the [AttributeTypeAndValue] instances
+within multi-valued RDNs is not significant.
+
+Note that no matching rules are consulted; the receiver and dn should be
+normalized through [Schema.NormalizeDN] prior to comparison.
+*/
+func (r DN) Equal(dn DN) bool {
+	if len(r) != len(dn) {
+		return false
+	}
+
+	for i := range r {
+		if !r[i].Equal(dn[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+String returns the RFC 4514 string representation of the receiver.
+*/
+func (r RDN) String() string {
+	var avas []string
+	for _, atv := range r {
+		avas = append(avas, atv.String())
+	}
+
+	return join(avas, `+`)
+}
+
+/*
+Equal returns a Boolean value indicative of the receiver being equal to
+rdn.  See [DN.Equal] for details.
+*/
+func (r RDN) Equal(rdn RDN) bool {
+	if len(r) != len(rdn) {
+		return false
+	}
+
+	x, y := r.sorted(), rdn.sorted()
+	for i := range x {
+		if !eq(x[i].Type, y[i].Type) || x[i].Value != y[i].Value || x[i].BER != y[i].BER {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+sorted returns a copy of the receiver with its [AttributeTypeAndValue]
+instances sorted by type and value.
+*/
+func (r RDN) sorted() RDN {
+	rdn := append(RDN{}, r...)
+	sort.SliceStable(rdn, func(i, j int) bool {
+		if ti, tj := lc(rdn[i].Type), lc(rdn[j].Type); ti != tj {
+			return ti < tj
+		}
+		return rdn[i].String() < rdn[j].String()
+	})
+
+	return rdn
+}
+
+/*
+String returns the RFC 4514 string representation of the receiver.
+*/
+func (r AttributeTypeAndValue) String() string {
+	if r.BER {
+		b := newStringBuilder()
+		b.WriteByte('#')
+		for i := 0; i < len(r.Value); i++ {
+			b.WriteString(fmtInt(int64(r.Value[i])|0x100, 16)[1:])
+		}
+		return r.Type + `=` + b.String()
+	}
+
+	return r.Type + `=` + escapeRDNValue(r.Value)
+}
+
+/*
+escapeRDNValue returns val with all characters requiring escape per RFC
+4514 Section 2.4 escaped.  NUL characters and bytes which do not form
+valid UTF-8 are escaped in backslash-hexpair form.
+*/
+func escapeRDNValue(val string) string {
+	b := newStringBuilder()
+	for i := 0; i < len(val); {
+		c, width := utf8.DecodeRuneInString(val[i:])
+		switch {
+		case c == utf8.RuneError && width < 2, c == 0:
+			b.WriteString(`\` + fmtInt(int64(val[i])|0x100, 16)[1:])
+		case stridx(`"+,;<>\`, string(c)) != -1,
+			(c == '#' || c == ' ') && i == 0,
+			c == ' ' && i == len(val)-1:
+			b.WriteString(`\` + string(c))
+		default:
+			b.WriteString(val[i : i+width])
+		}
+		i += width
+	}
+
+	return b.String()
+}
+
+/*
+NormalizeDN returns a normalized instance of [DN] alongside an error
+following an analysis of dn, which may be a string or an instance of
+[DN].  Two DNs which are equal in the view of a directory server shall
+produce equal normalized DNs (see [DN.Equal]) and, by extension, equal
+string representations.
+
+Each attribute type is resolved through the receiver and replaced by its
+canonical form, being the first name of the [AttributeType] or, lacking
+a name, its numeric OID.  Should oid be true, the numeric OID is always
+used.  An error wrapping [ErrAttributeTypeNotFound] is returned for any
+unknown type.
+
+Each value is normalized per the [AttributeType.EffectiveEquality] rule:
+
+  - Rules bearing a [StringPreparer] (see [MatchingRule.SetStringPreparer] and [RFC4518Preparer]) prepare the value, less insignificant leading and trailing space
+  - Integer values are verified, as their syntax only permits a canonical decimal form
+  - Generalized Time values are converted to UTC
+  - Object Identifier values naming a known definition are reduced to its numeric OID
+  - Distinguished Name values are normalized recursively
+
+BER encoded values of primitive string types, e.g.: "#04024869", are
+decoded prior to normalization.  All other values remain unchanged, and
+RDNs are sorted by attribute type and value.
+*/
+func (r Schema) NormalizeDN(dn any, oid ...bool) (norm DN, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	var d DN
+	switch tv := dn.(type) {
+	case string:
+		if d, err = ParseDN(tv); err != nil {
+			return
+		}
+	case DN:
+		d = tv
+	default:
+		err = ErrInvalidType
+		return
+	}
+
+	var useOID bool
+	if len(oid) > 0 {
+		useOID = oid[0]
+	}
+
+	for _, rdn := range d {
+		var _rdn RDN
+		for _, atv := range rdn {
+			if atv, err = r.normalizeATV(atv, useOID); err != nil {
+				norm = nil
+				return
+			}
+			_rdn = append(_rdn, atv)
+		}
+		norm = append(norm, _rdn.sorted())
+	}
+
+	return
+}
+
+/*
+normalizeATV returns the normalized form of atv alongside an error.
+*/
+func (r Schema) normalizeATV(atv AttributeTypeAndValue, useOID bool) (AttributeTypeAndValue, error) {
+	at := r.AttributeTypes().get(atv.Type)
+	if at.IsZero() {
+		return atv, wraperr(ErrAttributeTypeNotFound, `: `+atv.Type)
+	}
+
+	if atv.Type = at.OID(); useOID {
+		atv.Type = at.NumericOID()
+	}
+
+	if atv.BER {
+		if val, ok := decodeBERString(atv.Value); ok {
+			atv.Value, atv.BER = val, false
+		} else {
+			return atv, nil
+		}
+	}
+
+	mr := at.EffectiveEquality()
+	if mr.IsZero() {
+		return atv, nil
+	}
+
+	prep := mr.matchingRule.prep
+	if prep == nil {
+		prep = RFC4518Preparer(mr.NumericOID())
+	}
+
+	var err error
+	if prep != nil {
+		if atv.Value, err = prep(atv.Value, ValuePrep); err == nil {
+			atv.Value = repAll(trimS(atv.Value), `  `, ` `)
+		}
+		return atv, err
+	}
+
+	switch mr.NumericOID() {
+	case `2.5.13.0`: // objectIdentifierMatch
+		atv.Value, err = r.normalizeOID(atv.Value)
+	case `2.5.13.1`: // distinguishedNameMatch
+		var dn DN
+		if dn, err = r.NormalizeDN(atv.Value, useOID); err == nil {
+			atv.Value = dn.String()
+		}
+	case `2.5.13.14`: // integerMatch
+		err = checkInteger(atv.Value)
+	case `2.5.13.27`: // generalizedTimeMatch
+		var t time.Time
+		if t, err = parseGeneralizedTime(atv.Value); err == nil {
+			atv.Value = t.UTC().Format(`20060102150405.999999999Z`)
+		}
+	}
+
+	if err != nil {
+		err = wraperr(err, ` (`+at.OID()+`)`)
+	}
+
+	return atv, err
+}
+
+/*
+normalizeOID returns the numeric OID of the definition identified by
+val, if known to the receiver, else val in lowercase.
+*/
+func (r Schema) normalizeOID(val string) (string, error) {
+	if err := checkOID(val); err != nil {
+		return ``, err
+	} else if isNumericOID(val) {
+		return val, nil
+	}
+
+	for _, def := range []Definition{
+		r.AttributeTypes().get(val),
+		r.ObjectClasses().get(val),
+		r.MatchingRules().get(val),
+		r.NameForms().get(val),
+	} {
+		if !def.IsZero() {
+			return def.NumericOID(), nil
+		}
+	}
+
+	return lc(val), nil
+}
+
+/*
+decodeBERString returns the content of ber, which must be a primitive
+encoding of an ASN.1 universal string type, alongside a Boolean value
+indicative of success.
+*/
+func decodeBERString(ber string) (val string, ok bool) {
+	if len(ber) < 2 {
+		return
+	}
+
+	switch ber[0] {
+	case 0x04, 0x0C, 0x12, 0x13, 0x14, 0x16, 0x1A:
+		// OCTET STRING, UTF8String, NumericString,
+		// PrintableString, TeletexString, IA5String
+		// and VisibleString
+	default:
+		return
+	}
+
+	length, off := int(ber[1]), 2
+	if length&0x80 != 0 {
+		n := length & 0x7F
+		if n == 0 || n > 4 || len(ber) < 2+n {
+			return
+		}
+		length = 0
+		for _, c := range []byte(ber[2 : 2+n]) {
+			length = length<<8 | int(c)
+		}
+		off += n
+	}
+
+	if ok = len(ber)-off == length; ok {
+		val = ber[off:]
+	}
+
+	return
+}
@@ -0,0 +1,202 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the comparison of two distinguished names in
+the manner of a directory server through use of [Schema.NormalizeDN].
+*/
+func ExampleSchema_NormalizeDN() {
+	sch := NewSchema()
+
+	a, _ := sch.NormalizeDN(`UID=Jesse+CN=Jesse  Coretta,OU=People,DC=Example,DC=COM`)
+	b, _ := sch.NormalizeDN(`commonName=jesse coretta+userid=jesse,ou=people,dc=example,dc=com`)
+
+	fmt.Println(a.Equal(b))
+	fmt.Println(a)
+	// Output:
+	// true
+	// cn=jesse coretta+uid=jesse,ou=people,dc=example,dc=com
+}
+
+/*
+This example demonstrates the parsing of an RFC 4514 distinguished name
+bearing escaped and BER encoded values.
+*/
+func ExampleParseDN() {
+	dn, err := ParseDN(`cn=Smith\2C John+sn=#04024869,dc=example,dc=com`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(dn.RDN()[0].Value)
+	fmt.Println(dn.Parent())
+	// Output:
+	// Smith, John
+	// dc=example,dc=com
+}
+
+func TestParseDN(t *testing.T) {
+	for idx, tc := range []struct {
+		raw  string
+		rdns int
+		want string
+	}{
+		{``, 0, ``},
+		{`dc=com`, 1, ``},
+		{`uid=jesse+gidNumber=5042,ou=People,dc=example,dc=com`, 4, ``},
+		{`cn=Smith\, John,dc=com`, 2, ``},
+		{`cn=Smith\2C John,dc=com`, 2, `cn=Smith\, John,dc=com`},
+		{`cn=\23hash\20,dc=com`, 2, `cn=\#hash\ ,dc=com`},
+		{`cn=\ lead,dc=com`, 2, ``},
+		{`cn=a\+b\;c\<d\>e\"f\\g`, 1, ``},
+		{`cn=caf\C3\A9`, 1, "cn=caf\u00e9"},
+		{`cn=\00\FF`, 1, `cn=\00\ff`},
+		{`cn=#04024869`, 1, ``},
+		{`cn=#0402486A`, 1, `cn=#0402486a`},
+		{`2.5.4.3=x`, 1, ``},
+	} {
+		dn, err := ParseDN(tc.raw)
+		if err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+			continue
+		}
+
+		want := tc.want
+		if want == `` {
+			want = tc.raw
+		}
+
+		if dn.Len() != tc.rdns {
+			t.Errorf("%s[%d] failed: want %d RDNs, got %d", t.Name(), idx, tc.rdns, dn.Len())
+		} else if got := dn.String(); got != want {
+			t.Errorf("%s[%d] failed: want %q, got %q", t.Name(), idx, want, got)
+		}
+	}
+
+	for idx, raw := range []string{
+		`cn`,
+		`=x`,
+		`c_n=x`,
+		`cn=x,`,
+		`cn=x+`,
+		`cn=x;dc=com`,
+		`cn=#`,
+		`cn=#0`,
+		`cn=#zz`,
+		`cn= lead`,
+		`cn=trail `,
+		`cn=\zz`,
+		`cn=x\`,
+	} {
+		if _, err := ParseDN(raw); !errors.Is(err, ErrInvalidSyntax) {
+			t.Errorf("%s[%d] failed: expected %v for %q, got %v",
+				t.Name(), idx, ErrInvalidSyntax, raw, err)
+		}
+	}
+}
+
+func TestSchema_NormalizeDN(t *testing.T) {
+	sch := NewSchema()
+
+	for idx, tc := range []struct {
+		a, b  string
+		equal bool
+	}{
+		{`dc=example,dc=com`, `DC=EXAMPLE,DC=COM`, true},
+		{`dc=example,dc=com`, `0.9.2342.19200300.100.1.25=example,dc=com`, true},
+		{`cn=Jesse  Coretta`, `cn=\ Jesse Coretta\20`, true},
+		{`cn=Jesse Coretta`, `cn=JesseCoretta`, false},
+		{`cn=Hi`, `cn=#04024869`, true},
+		{`cn=Hi`, `cn=#0C024869`, true},
+		{`cn=Smith\, John`, `cn=smith\2c john`, true},
+		{`cn=a+sn=b`, `sn=B+cn=A`, true},
+		{`cn=a+sn=b`, `cn=a,sn=b`, false},
+		{`uidNumber=42`, `uidNumber=42`, true},
+		{`uidNumber=42`, `uidNumber=-42`, false},
+		{`createTimestamp=20240101010000\+0100`, `createTimestamp=20240101000000Z`, true},
+		{`objectClass=person`, `objectClass=2.5.6.6`, true},
+		{`objectClass=bogus`, `objectClass=BOGUS`, true},
+		{`seeAlso=cn=Jesse\,dc=com`, `seeAlso=CN=jesse\2CDC=COM`, true},
+		{`telephoneNumber=\+1 512-315-0280`, `telephoneNumber=\+15123150280`, true},
+		{`userPassword=Secret`, `userPassword=secret`, false},
+		{`userPassword=#04024869`, `userPassword=Hi`, true},
+		{`cn=#3000`, `cn=#3000`, true},
+	} {
+		a, err := sch.NormalizeDN(tc.a)
+		if err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+			continue
+		}
+
+		b, err := sch.NormalizeDN(tc.b)
+		if err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+			continue
+		}
+
+		if a.Equal(b) != tc.equal || (a.String() == b.String()) != tc.equal {
+			t.Errorf("%s[%d] failed: want equal=%t for %q (%s) and %q (%s)",
+				t.Name(), idx, tc.equal, tc.a, a, tc.b, b)
+		}
+	}
+
+	if dn, _ := sch.NormalizeDN(`CN=x,DC=com`, true); dn.String() != `2.5.4.3=x,0.9.2342.19200300.100.1.25=com` {
+		t.Errorf("%s failed: unexpected OID form %s", t.Name(), dn)
+	}
+
+	for idx, tc := range []struct {
+		dn  any
+		err error
+	}{
+		{`bogusType=x`, ErrAttributeTypeNotFound},
+		{`cn=x;`, ErrInvalidSyntax},
+		{`uidNumber=x`, ErrInvalidSyntax},
+		{`uidNumber=042`, ErrInvalidSyntax},
+		{`createTimestamp=never`, ErrInvalidSyntax},
+		{`objectClass=-x`, ErrInvalidSyntax},
+		{`seeAlso=bogus`, ErrInvalidSyntax},
+		{1, ErrInvalidType},
+	} {
+		if _, err := sch.NormalizeDN(tc.dn); !errors.Is(err, tc.err) {
+			t.Errorf("%s[%d] failed: expected %v, got %v", t.Name(), idx, tc.err, err)
+		}
+	}
+}
+
+func TestDN_codecov(t *testing.T) {
+	var dn DN
+	_ = dn.IsZero()
+	_ = dn.RDN()
+	_ = dn.Parent()
+	_ = dn.String()
+
+	var sch Schema
+	if _, err := sch.NormalizeDN(dn); !errors.Is(err, ErrNilReceiver) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilReceiver, err)
+	}
+
+	sch = NewSchema()
+	if norm, err := sch.NormalizeDN(dn); err != nil || !norm.IsZero() {
+		t.Errorf("%s failed: unexpected result %s, %v", t.Name(), norm, err)
+	}
+
+	x, _ := ParseDN(`cn=a,dc=com`)
+	y, _ := ParseDN(`cn=a`)
+	z, _ := ParseDN(`cn=a+sn=b`)
+	if x.Equal(y) || y.Equal(z) || !x.Equal(x) {
+		t.Errorf("%s failed: unexpected equality results", t.Name())
+	}
+
+	for _, ber := range []string{``, "\x04", "\x04\x03ab", "\x04\x81\x02ab", "\x04\x80", "\x30\x00"} {
+		_, _ = decodeBERString(ber)
+	}
+	if val, ok := decodeBERString("\x04\x81\x02ab"); !ok || val != `ab` {
+		t.Errorf("%s failed: unexpected long form result %q", t.Name(), val)
+	}
+}
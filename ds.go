@@ -53,6 +53,9 @@ E returns the underlying error instance.
 */
 func (r DITStructureRule) E() (err error) {
 	if !r.IsZero() {
+		runlock := r.dITStructureRule.schema.rlock()
+		defer runlock()
+
 		err = r.dITStructureRule.err
 	} else {
 		err = ErrNilReceiver
@@ -96,8 +99,18 @@ func (r *dITStructureRule) replace(x DITStructureRule) {
 		return
 	}
 
+	sch := r.schema
+	unlock, ok := sch.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.ID = x.dITStructureRule.ID
 	r.Name = x.dITStructureRule.Name
+	r.Name.setDefinition(DITStructureRule{r})
 	r.Desc = x.dITStructureRule.Desc
 	r.Form = x.dITStructureRule.Form
 	r.Obsolete = x.dITStructureRule.Obsolete
@@ -106,6 +119,40 @@ func (r *dITStructureRule) replace(x DITStructureRule) {
 	r.stringer = x.dITStructureRule.stringer
 	r.schema = x.dITStructureRule.schema
 	r.data = x.dITStructureRule.data
+
+	sch.reindex(DITStructureRule{r})
+}
+
+/*
+Clone returns a deep copy of the receiver instance.  Modification of
+the return instance -- such as by way of the [DITStructureRule.SetName]
+method -- shall not influence the receiver instance.
+
+Referenced definitions, such as the FORM and SUP values, are NOT cloned;
+use [Schema.Clone] to clone an entire [Schema] along with all internal
+references.
+
+Note that use of the [DITStructureRule.SetStringer] method may be needed
+following any changes to the return instance.
+*/
+func (r DITStructureRule) Clone() (c DITStructureRule) {
+	if r.IsZero() {
+		return
+	}
+
+	_c := *r.dITStructureRule
+	c = DITStructureRule{&_c}
+	_c.Name = cloneName(r.dITStructureRule.Name, c)
+	_c.SuperRules = r.dITStructureRule.SuperRules.cloneIDList()
+	_c.Extensions = cloneExtensions(r.dITStructureRule.Extensions, c)
+
+	return
+}
+
+func (r *dITStructureRule) rewire(sch Schema) {
+	r.schema = sch
+	r.Form = r.Form.counterpart(sch)
+	r.SuperRules = r.SuperRules.counterparts(sch)
 }
 
 /*
@@ -138,6 +185,14 @@ func (r DITStructureRule) SetData(x any) DITStructureRule {
 }
 
 func (r *dITStructureRule) setData(x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.data = x
 }
 
@@ -173,6 +228,14 @@ func (r DITStructureRule) SetSchema(schema Schema) DITStructureRule {
 }
 
 func (r *dITStructureRule) setSchema(schema Schema) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -239,7 +302,22 @@ compliant per the required clauses of [§ 4.1.7.1 of RFC 4512]:
 
 [§ 4.1.7.1 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.7.1
 */
-func (r DITStructureRule) Compliant() bool {
+func (r DITStructureRule) Compliant() (ok bool) {
+	if ok = r.compliant(); ok {
+		unlock, _ := r.dITStructureRule.schema.lock()
+		defer unlock()
+
+		r.dITStructureRule.err = nil
+	}
+
+	return
+}
+
+/*
+compliant is the side-effect free counterpart of Compliant, for use
+upon paths which merely read the receiver.
+*/
+func (r DITStructureRule) compliant() bool {
 	// presence of ruleid is guaranteed via
 	// uint default, no need to check.
 
@@ -249,7 +327,7 @@ func (r DITStructureRule) Compliant() bool {
 
 	// obtain nameForm and verify as compliant.
 	form := r.Form()
-	if !form.Compliant() {
+	if !form.compliant() {
 		return false
 	}
 
@@ -260,7 +338,6 @@ func (r DITStructureRule) Compliant() bool {
 	// not apply.
 	dc := r.schema().DITContentRules().Get(form.OC().OID())
 	if dc.IsZero() {
-		r.dITStructureRule.err = nil
 		return true
 	}
 
@@ -275,8 +352,6 @@ func (r DITStructureRule) Compliant() bool {
 		}
 	}
 
-	r.dITStructureRule.err = nil
-
 	return true
 }
 
@@ -492,7 +567,7 @@ stringer.
 This is a fluent method and may be used multiple times.
 */
 func (r DITStructureRule) SetStringer(function ...Stringer) DITStructureRule {
-	if r.Compliant() {
+	if r.compliant() {
 		r.dITStructureRule.setStringer(function...)
 	}
 
@@ -505,17 +580,26 @@ func (r *dITStructureRule) setStringer(function ...Stringer) {
 		stringer = function[0]
 	}
 
+	var err error
 	if stringer == nil {
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		} else {
-			r.err = err
+		// no user provided closure means we
+		// defer to a general use stringer.
+		var str string
+		str, err = r.prepareString() // perform one-time text/template op
+		stringer = func() string {
+			// Return a preserved value.
+			return str
 		}
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	} else if err != nil {
+		r.err = err
 		return
 	}
 
@@ -533,7 +617,7 @@ func (r DITStructureRules) XOrigin(x string) (defs DITStructureRules) {
 		def := r.Index(i)
 		if xo, found := def.Extensions().Get(`X-ORIGIN`); found {
 			if xo.Contains(x) {
-				defs.push(def)
+				defs.cast().Push(def)
 			}
 		}
 	}
@@ -558,14 +642,24 @@ func (r DITStructureRule) SetName(x ...string) DITStructureRule {
 }
 
 func (r *dITStructureRule) setName(x ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	b4 := r.Name.Len()
 	for i := 0; i < len(x); i++ {
-		r.Name.Push(x[i])
+		r.Name.push(x[i])
 	}
 
 	if r.Name.Len()-len(x) != b4 {
 		r.err = ErrInvalidNames
 	}
+
+	r.schema.reindex(DITStructureRule{r})
 }
 
 /*
@@ -582,6 +676,14 @@ func (r DITStructureRule) SetObsolete() DITStructureRule {
 }
 
 func (r *dITStructureRule) setObsolete() {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -603,7 +705,15 @@ func (r DITStructureRule) SetDescription(desc string) DITStructureRule {
 }
 
 func (r *dITStructureRule) setDescription(desc string) {
-	if len(desc) < 3 {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if len(desc) == 0 {
 		return
 	}
 
@@ -611,7 +721,7 @@ func (r *dITStructureRule) setDescription(desc string) {
 		desc = desc[1:]
 	}
 
-	if rune(desc[len(desc)-1]) == rune(39) {
+	if len(desc) > 0 && rune(desc[len(desc)-1]) == rune(39) {
 		desc = desc[:len(desc)-1]
 	}
 
@@ -635,6 +745,14 @@ func (r DITStructureRule) SetExtension(x string, xstrs ...string) DITStructureRu
 }
 
 func (r *dITStructureRule) setExtension(x string, xstrs ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -656,6 +774,14 @@ func (r DITStructureRule) SetRuleID(id any) DITStructureRule {
 }
 
 func (r *dITStructureRule) setRuleID(x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	var err error
 	switch tv := x.(type) {
 	case uint64:
@@ -682,7 +808,7 @@ func (r *dITStructureRule) setRuleID(x any) {
 		r.err = err
 	}
 
-	return
+	r.schema.reindex(DITStructureRule{r})
 }
 
 /*
@@ -704,6 +830,11 @@ func (r DITStructureRule) SetSuperRule(m ...any) DITStructureRule {
 }
 
 func (r *dITStructureRule) setSuperRule(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var def DITStructureRule
@@ -728,7 +859,7 @@ func (r *dITStructureRule) setSuperRule(m ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -774,7 +905,7 @@ func (r DITStructureRule) Marshal(def any) error {
 		}
 	}
 
-	if !r.Compliant() {
+	if !r.compliant() {
 		return ErrDefNonCompliant
 	}
 	r.SetStringer()
@@ -917,6 +1048,14 @@ func (r *dITStructureRule) setForm(x any) {
 		def = tv
 	}
 
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if !def.IsZero() {
 		r.Form = def
 	} else {
@@ -968,6 +1107,7 @@ a [DITStructureRule] instance.
 func NewDITStructureRule() DITStructureRule {
 	ds := DITStructureRule{newDITStructureRule()}
 	ds.dITStructureRule.Extensions.setDefinition(ds)
+	ds.dITStructureRule.Name.setDefinition(ds)
 	return ds
 
 }
@@ -1173,7 +1313,7 @@ func (r DITStructureRules) canPush(x ...any) (err error) {
 
 		// Check whether a dSR exists bearing the same
 		// ruleid as the newly pushed candidate.
-		if tst := r.get(ds.RuleID()); !tst.IsZero() {
+		if tst := r.lookup(ds.RuleID()); !tst.IsZero() {
 			// If explicitly permitted by the schema config,
 			// we'll re-index any dSRs bearing a numerical
 			// ID that conflicts with a preexisting rule
@@ -1181,8 +1321,8 @@ func (r DITStructureRules) canPush(x ...any) (err error) {
 			opts := ds.Schema().Options()
 			if !opts.Positive(AllowReindexedStructureRules) {
 				// Not allowed!
-				err = mkerr(ErrNotUnique.Error() + ": " + ds.Type() +
-					`, ` + uitoa(ds.RuleID()))
+				err = wraperr(ErrNotUnique, ": "+ds.Type()+
+					`, `+uitoa(ds.RuleID()))
 				break
 			}
 
@@ -1200,16 +1340,14 @@ func (r DITStructureRules) canPush(x ...any) (err error) {
 				break
 			}
 
-			// Overwrite the ruleid previously in conflict
-			ds.SetRuleID(next)
+			// Overwrite the ruleid previously in conflict.
+			// The lock is already held by the pusher, and
+			// the candidate is not yet indexed.
+			ds.dITStructureRule.ID = next
 
 			// Let the administrator know that a reindex has
 			// occurred.
-			ds.SetExtension(`X-WARNING`, `REINDEXED`)
-
-			// Update stringer (will clobber any CUSTOM)
-			// stringer
-			ds.setStringer()
+			ds.dITStructureRule.Extensions.Set(`X-WARNING`, `REINDEXED`)
 		}
 	}
 
@@ -1234,7 +1372,7 @@ perceived, the new sum of the number is returned.
 */
 func (r DITStructureRules) nextIndex() (idx uint, ok bool) {
 	if !r.IsZero() {
-		L := r.Len()
+		L := r.cast().Len()
 		if L == 0 {
 			ok = true
 			return
@@ -1242,7 +1380,7 @@ func (r DITStructureRules) nextIndex() (idx uint, ok bool) {
 
 		var indices []uint
 		for i := 0; i < L; i++ {
-			indices = append(indices, r.Index(i).RuleID())
+			indices = append(indices, r.at(i).RuleID())
 		}
 
 		var slice uint
@@ -1275,6 +1413,9 @@ func (r DITStructureRules) Len() int {
 }
 
 func (r DITStructureRules) len() int {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
 	return r.cast().Len()
 }
 
@@ -1302,7 +1443,17 @@ func (r DITStructureRules) Index(idx int) DITStructureRule {
 	return r.index(idx)
 }
 
-func (r DITStructureRules) index(idx int) (ds DITStructureRule) {
+func (r DITStructureRules) index(idx int) DITStructureRule {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.at(idx)
+}
+
+/*
+at is the unlocked counterpart of index.
+*/
+func (r DITStructureRules) at(idx int) (ds DITStructureRule) {
 	if slice, found := r.cast().Index(idx); found {
 		if _ds, ok := slice.(DITStructureRule); ok {
 			ds = _ds
@@ -1316,19 +1467,31 @@ func (r DITStructureRules) index(idx int) (ds DITStructureRule) {
 Push returns an error following an attempt to push a [DITStructureRule]
 into the receiver stack instance.
 */
-func (r DITStructureRules) Push(ds any) error {
-	return r.push(ds)
+func (r DITStructureRules) Push(ds any) (err error) {
+	if err = r.push(ds); err == nil {
+		pushed(r, ds)
+	}
+
+	return
 }
 
 func (r DITStructureRules) push(x any) (err error) {
 	switch tv := x.(type) {
 	case DITStructureRule:
-		if !tv.Compliant() {
+		if !tv.compliant() {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
-		err = r.cast().Err()
+		id := tv.RuleID()
+		if err = lockedPush(r.cast(), tv); err == nil {
+			err = r.cast().Err()
+		}
+
+		if err == nil && tv.RuleID() != id {
+			// Update stringer following a reindex (will
+			// clobber any CUSTOM stringer)
+			tv.dITStructureRule.setStringer()
+		}
 	default:
 		err = ErrInvalidType
 	}
@@ -1364,8 +1527,19 @@ func (r DITStructureRules) Get(id any) DITStructureRule {
 	return r.get(id)
 }
 
-func (r DITStructureRules) get(id any) (ds DITStructureRule) {
-	L := r.len()
+func (r DITStructureRules) get(id any) DITStructureRule {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.lookup(id)
+}
+
+/*
+lookup is the unlocked counterpart of get, for use where the lock is
+already held, such as during a push.
+*/
+func (r DITStructureRules) lookup(id any) (ds DITStructureRule) {
+	L := r.cast().Len()
 	if L == 0 {
 		return
 	}
@@ -1389,12 +1563,28 @@ func (r DITStructureRules) get(id any) (ds DITStructureRule) {
 			named = true
 			name = tv
 		} else {
-			return r.get(_n)
+			return r.lookup(_n)
+		}
+	}
+
+	key := name
+	if !named {
+		key = uitoa(n)
+	}
+
+	if slice, indexed := indexLookup(r.cast(), key, func(x any) bool {
+		_ds, _ := x.(DITStructureRule)
+		if named {
+			return _ds.Names().Contains(name) && len(name) > 0
 		}
+		return !_ds.IsZero() && _ds.RuleID() == n
+	}); indexed {
+		ds, _ = slice.(DITStructureRule)
+		return
 	}
 
 	for i := 0; i < L && ds.IsZero(); i++ {
-		_ds := r.index(i)
+		_ds := r.at(i)
 		if named {
 			if _ds.Names().Contains(name) && len(name) > 0 {
 				ds = _ds
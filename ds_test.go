@@ -254,9 +254,9 @@ Note: this example assumes a legitimate schema variable is defined
 in place of the fictional "mySchema" var shown here for simplicity.
 */
 func ExampleDITStructureRule_RuleID() {
-	def := mySchema.DITStructureRules().Get(`arcStructure`) // or 11, or "11"
+	def := mySchema.DITStructureRules().Get(`arcStructure`) // or 21, or "21"
 	fmt.Println(def.RuleID())
-	// Output: 0
+	// Output: 21
 }
 
 /*
@@ -0,0 +1,602 @@
+package schemax
+
+/*
+entry.go contains facilities for the validation of directory entries
+against the contents of a Schema.
+*/
+
+import (
+	"errors"
+	"sort"
+)
+
+const (
+	ObjectClassViolation         uint = iota // object classes are absent, unknown or obsolete
+	StructuralClassViolation                 // zero (0) or multiple STRUCTURAL class chains
+	AuxiliaryClassViolation                  // AUXILIARY class not permitted by the DITContentRule
+	UnknownAttributeViolation                // attribute type is not defined within the Schema
+	MissingAttributeViolation                // required attribute type is absent
+	DisallowedAttributeViolation             // attribute type is not permitted
+	ProhibitedAttributeViolation             // attribute type is precluded by the DITContentRule
+	SingleValueViolation                     // SINGLE-VALUE attribute type bears multiple values
+	NoUserModificationViolation              // NO-USER-MODIFICATION attribute type is present
+	ValueViolation                           // value was rejected by a qualifier
+	RDNViolation                             // RDN is malformed or its values are absent
+)
+
+var entryViolationLabels map[uint]string = map[uint]string{
+	ObjectClassViolation:         `object class violation`,
+	StructuralClassViolation:     `structural class violation`,
+	AuxiliaryClassViolation:      `auxiliary class not permitted`,
+	UnknownAttributeViolation:    `undefined attribute type`,
+	MissingAttributeViolation:    `required attribute type missing`,
+	DisallowedAttributeViolation: `attribute type not allowed`,
+	ProhibitedAttributeViolation: `attribute type prohibited`,
+	SingleValueViolation:         `single-value attribute type has multiple values`,
+	NoUserModificationViolation:  `attribute type not user-modifiable`,
+	ValueViolation:               `invalid attribute value`,
+	RDNViolation:                 `RDN violation`,
+}
+
+/*
+EntryViolations implements slices of [EntryViolation], collectively
+describing the manners in which an entry fails to conform to a [Schema].
+Instances of this type are produced by the [Schema.ValidateEntry] method.
+*/
+type EntryViolations []EntryViolation
+
+/*
+EntryViolation describes a single manner in which an entry fails to
+conform to a [Schema].
+
+Kind is one of the *Violation constants, such as [MissingAttributeViolation].
+Attribute contains the attribute type -- or, in the case of object class
+violations, the object class -- in question, if applicable.  Value contains
+the offending value, if applicable.  Err contains the underlying error, such
+as one returned by a [ValueQualifier], if applicable.
+*/
+type EntryViolation struct {
+	Kind      uint
+	Attribute string
+	Value     string
+	Err       error
+}
+
+/*
+Error returns the string representation of the receiver instance, e.g.:
+
+	required attribute type missing: sn
+	invalid attribute value: uidNumber 'abc': value is not an integer
+
+This method satisfies the error interface.
+*/
+func (r EntryViolation) Error() (s string) {
+	s = entryViolationLabels[r.Kind]
+	if len(r.Attribute) > 0 {
+		s += `: ` + r.Attribute
+	}
+	if len(r.Value) > 0 {
+		s += ` '` + r.Value + `'`
+	}
+	if r.Err != nil {
+		s += `: ` + r.Err.Error()
+	}
+
+	return
+}
+
+/*
+Unwrap returns the underlying error instance, if any.
+*/
+func (r EntryViolation) Unwrap() error {
+	return r.Err
+}
+
+/*
+Len returns the integer length of the receiver instance.
+*/
+func (r EntryViolations) Len() int {
+	return len(r)
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state, which
+indicates a conformant entry.
+*/
+func (r EntryViolations) IsZero() bool {
+	return r.Len() == 0
+}
+
+/*
+Kind returns all [EntryViolation] slices bearing the specified kind.
+*/
+func (r EntryViolations) Kind(kind uint) (v EntryViolations) {
+	for i := 0; i < r.Len(); i++ {
+		if r[i].Kind == kind {
+			v = append(v, r[i])
+		}
+	}
+
+	return
+}
+
+/*
+Err returns all slices within the receiver instance in the form of a
+single error instance, or nil if the receiver is zero.
+*/
+func (r EntryViolations) Err() error {
+	var errs []error
+	for i := 0; i < r.Len(); i++ {
+		errs = append(errs, r[i])
+	}
+
+	return joinErr(errs...)
+}
+
+/*
+String returns the string representation of the receiver instance, with
+each slice appearing on its own line.
+*/
+func (r EntryViolations) String() string {
+	var lines []string
+	for i := 0; i < r.Len(); i++ {
+		lines = append(lines, r[i].Error())
+	}
+
+	return join(lines, string(rune(10)))
+}
+
+/*
+entryAttribute contains all values of a single attribute type found within
+an entry, regardless of the attribute description(s) used.
+*/
+type entryAttribute struct {
+	at   AttributeType
+	desc string
+	vals []string
+}
+
+/*
+ValidateEntry returns an instance of [EntryViolations] describing the
+manners in which the entry described by dn and attrs fails to conform to
+the receiver instance, alongside an error.  A zero [EntryViolations]
+instance indicates a conformant entry.  The error is reserved for cases
+in which validation cannot take place at all.
+
+The keys of attrs are attribute descriptions, such as "cn" or "cn;lang-en",
+and are matched against the receiver instance without regard for case.
+Attribute options are ignored for the purpose of schema checking.
+
+The entry is checked in the manner of a DSA, per RFC 4512 Section 2.4 and
+Section 4.1:
+
+  - The "objectClass" attribute type must be present, and all of its values must be known
+  - All STRUCTURAL classes -- including those implied by superclass chains -- must form exactly one (1) chain
+  - All attribute types required by [ObjectClass.AllMust] must be present, as must those required by the [DITContentRule] in force (if any)
+  - All attribute types present must be allowed by [ObjectClass.AllMust] and [ObjectClass.AllMay], or by the MUST and MAY clauses of the [DITContentRule] in force
+  - No attribute type named within the NOT clause of the [DITContentRule] in force may be present
+  - Should a [DITContentRule] be in force, all AUXILIARY classes must be named within its AUX clause, or be superclasses of those named
+  - SINGLE-VALUE attribute types must bear no more than one (1) value
+  - NO-USER-MODIFICATION attribute types are flagged, as they cannot be supplied by the user
+  - Each value must be accepted by [AttributeType.QualifyValue] and [AttributeType.QualifySyntax], where qualifiers are assigned
+  - All attribute values within the RDN of dn must be present within attrs
+
+Operational attribute types (those which do not bear the userApplications
+USAGE) are exempt from the allowed attribute type check.
+
+Note that, unlike the strict reading of RFC 4512, AUXILIARY classes are
+permitted without restriction when no [DITContentRule] is in force for
+the STRUCTURAL class of the entry, as is the behavior of most DSAs.
+
+Should dn be zero length, the RDN check is skipped.
+*/
+func (r Schema) ValidateEntry(dn string, attrs map[string][]string) (v EntryViolations, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	} else if len(attrs) == 0 {
+		err = wraperr(ErrNilInput, ": no attributes")
+		return
+	}
+
+	present, v := r.entryAttributes(attrs)
+
+	ocs, ocv := r.entryObjectClasses(present)
+	if v = append(v, ocv...); ocs.IsZero() {
+		return
+	}
+
+	_, dcr, scv := entryStructuralClass(ocs)
+	if v = append(v, scv...); !dcr.IsZero() {
+		auxs := permittedAuxiliaryClasses(dcr)
+		for i := 0; i < ocs.Len(); i++ {
+			if oc := ocs.Index(i); oc.Kind() == AuxiliaryKind && !auxs.Contains(oc.NumericOID()) {
+				v = append(v, EntryViolation{
+					Kind:      AuxiliaryClassViolation,
+					Attribute: oc.OID(),
+				})
+			}
+		}
+	}
+
+	must, may, not := entryAllowances(ocs, dcr)
+
+	for _, oid := range must.keys() {
+		if _, found := present[oid]; !found {
+			v = append(v, EntryViolation{
+				Kind:      MissingAttributeViolation,
+				Attribute: must[oid].OID(),
+			})
+		}
+	}
+
+	for _, oid := range sortedAttributeKeys(present) {
+		v = append(v, checkEntryAttribute(present[oid], must, may, not)...)
+	}
+
+	v = append(v, checkEntryRDN(dn, present)...)
+
+	return
+}
+
+/*
+permittedAuxiliaryClasses returns the AUXILIARY classes permitted by dcr,
+namely those named within its AUX clause alongside their superclasses, as
+the latter are implied by the former.
+*/
+func permittedAuxiliaryClasses(dcr DITContentRule) (auxs ObjectClasses) {
+	auxs = NewObjectClasses()
+
+	aux := dcr.Aux()
+	for i := 0; i < aux.Len(); i++ {
+		oc := aux.Index(i)
+		auxs.Push(oc)
+
+		sups := oc.SuperChain()
+		for j := 0; j < sups.Len(); j++ {
+			auxs.Push(sups.Index(j))
+		}
+	}
+
+	return
+}
+
+/*
+entryAttributes returns the attribute types present within attrs, keyed
+by numeric OID, alongside violations for any unknown attribute types.
+*/
+func (r Schema) entryAttributes(attrs map[string][]string) (present map[string]*entryAttribute, v EntryViolations) {
+	present = make(map[string]*entryAttribute)
+
+	descs := make([]string, 0, len(attrs))
+	for desc := range attrs {
+		descs = append(descs, desc)
+	}
+	sort.Strings(descs)
+
+	for _, desc := range descs {
+		vals := attrs[desc]
+		if len(vals) == 0 {
+			continue
+		}
+
+		typ := desc
+		if idx := stridx(typ, `;`); idx != -1 {
+			typ = typ[:idx]
+		}
+
+		at := r.AttributeTypes().get(typ)
+		if at.IsZero() {
+			v = append(v, EntryViolation{
+				Kind:      UnknownAttributeViolation,
+				Attribute: desc,
+			})
+			continue
+		}
+
+		if ea, found := present[at.NumericOID()]; found {
+			ea.vals = append(ea.vals, vals...)
+		} else {
+			present[at.NumericOID()] = &entryAttribute{at: at, desc: typ, vals: vals}
+		}
+	}
+
+	return
+}
+
+/*
+entryObjectClasses returns the object classes named by the objectClass
+attribute type within present, alongside all of their superclasses.
+*/
+func (r Schema) entryObjectClasses(present map[string]*entryAttribute) (ocs ObjectClasses, v EntryViolations) {
+	ea, found := present[`2.5.4.0`]
+	if !found {
+		v = append(v, EntryViolation{
+			Kind:      ObjectClassViolation,
+			Attribute: `objectClass`,
+			Err:       mkerr("no object classes present"),
+		})
+		return
+	}
+
+	ocs = NewObjectClassOIDList()
+	for _, val := range ea.vals {
+		oc := r.ObjectClasses().get(val)
+		if oc.IsZero() {
+			v = append(v, EntryViolation{
+				Kind:      ObjectClassViolation,
+				Attribute: `objectClass`,
+				Value:     val,
+				Err:       ErrObjectClassNotFound,
+			})
+			continue
+		} else if oc.Obsolete() {
+			v = append(v, EntryViolation{
+				Kind:      ObjectClassViolation,
+				Attribute: `objectClass`,
+				Value:     val,
+				Err:       mkerr("object class is OBSOLETE"),
+			})
+		}
+
+		ocs.push(oc)
+		sups := oc.SuperChain()
+		for i := 0; i < sups.Len(); i++ {
+			ocs.push(sups.Index(i))
+		}
+	}
+
+	return
+}
+
+/*
+entryStructuralClass returns the most subordinate class of the sole
+STRUCTURAL class chain within ocs and the [DITContentRule] in force for
+it, if any, alongside a violation should zero (0) or multiple such chains
+be present.
+*/
+func entryStructuralClass(ocs ObjectClasses) (structural ObjectClass, dcr DITContentRule, v EntryViolations) {
+	var chain []ObjectClass
+	for i := 0; i < ocs.Len(); i++ {
+		if oc := ocs.Index(i); oc.Kind() == StructuralKind {
+			chain = append(chain, oc)
+		}
+	}
+
+	// the most subordinate classes are those which
+	// are not superior to any other structural class
+	var leaves []string
+	var leaf ObjectClass
+	for _, oc := range chain {
+		var superior bool
+		for _, other := range chain {
+			if superior = oc.SuperClassOf(other); superior {
+				break
+			}
+		}
+		if !superior {
+			leaf = oc
+			leaves = append(leaves, oc.OID())
+		}
+	}
+
+	switch len(leaves) {
+	case 0:
+		v = append(v, EntryViolation{
+			Kind:      StructuralClassViolation,
+			Attribute: `objectClass`,
+			Err:       mkerr("no structural object class present"),
+		})
+	case 1:
+		structural, dcr = leaf, leaf.EnforcedBy()
+	default:
+		v = append(v, EntryViolation{
+			Kind:      StructuralClassViolation,
+			Attribute: `objectClass`,
+			Err:       mkerr("multiple structural object class chains present: " + join(leaves, `, `)),
+		})
+	}
+
+	return
+}
+
+/*
+attributeSet contains [AttributeType] instances keyed by numeric OID.
+*/
+type attributeSet map[string]AttributeType
+
+func (r attributeSet) add(ats AttributeTypes) {
+	for i := 0; i < ats.Len(); i++ {
+		if at := ats.Index(i); !at.IsZero() {
+			r[at.NumericOID()] = at
+		}
+	}
+}
+
+func (r attributeSet) keys() (keys []string) {
+	for k := range r {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return
+}
+
+/*
+entryAllowances returns the required, allowed and prohibited attribute
+types for an entry belonging to ocs and governed by dcr, which may be zero.
+*/
+func entryAllowances(ocs ObjectClasses, dcr DITContentRule) (must, may, not attributeSet) {
+	must, may, not = make(attributeSet), make(attributeSet), make(attributeSet)
+	for i := 0; i < ocs.Len(); i++ {
+		oc := ocs.Index(i)
+		must.add(oc.AllMust())
+		may.add(oc.AllMay())
+	}
+
+	if !dcr.IsZero() {
+		must.add(dcr.Must())
+		may.add(dcr.May())
+		not.add(dcr.Not())
+	}
+
+	return
+}
+
+/*
+checkEntryAttribute returns violations concerning the values of ea and
+its presence within an entry governed by the must, may and not sets.
+*/
+func checkEntryAttribute(ea *entryAttribute, must, may, not attributeSet) (v EntryViolations) {
+	oid := ea.at.NumericOID()
+
+	if _, prohibited := not[oid]; prohibited {
+		v = append(v, EntryViolation{
+			Kind:      ProhibitedAttributeViolation,
+			Attribute: ea.desc,
+		})
+	} else if _, required := must[oid]; !required {
+		if _, allowed := may[oid]; !allowed && len(ea.at.Usage()) == 0 {
+			v = append(v, EntryViolation{
+				Kind:      DisallowedAttributeViolation,
+				Attribute: ea.desc,
+			})
+		}
+	}
+
+	if ea.at.SingleValue() && len(ea.vals) > 1 {
+		v = append(v, EntryViolation{
+			Kind:      SingleValueViolation,
+			Attribute: ea.desc,
+		})
+	}
+
+	if ea.at.NoUserModification() {
+		v = append(v, EntryViolation{
+			Kind:      NoUserModificationViolation,
+			Attribute: ea.desc,
+		})
+	}
+
+	for _, val := range ea.vals {
+		if err := ea.at.QualifyValue(val); err != nil && !errors.Is(err, ErrNilValueQualifier) {
+			v = append(v, EntryViolation{
+				Kind:      ValueViolation,
+				Attribute: ea.desc,
+				Value:     val,
+				Err:       err,
+			})
+		} else if err = ea.at.QualifySyntax(val); err != nil &&
+			!errors.Is(err, ErrNilSyntaxQualifier) && !errors.Is(err, ErrNilDef) {
+			v = append(v, EntryViolation{
+				Kind:      ValueViolation,
+				Attribute: ea.desc,
+				Value:     val,
+				Err:       err,
+			})
+		}
+	}
+
+	return
+}
+
+/*
+checkEntryRDN returns violations concerning the RDN of dn, all values of
+which must be present within the entry.
+*/
+func checkEntryRDN(dn string, present map[string]*entryAttribute) (v EntryViolations) {
+	if len(dn) == 0 {
+		return
+	}
+
+	gdn := tokenizeDN(dn)
+	if gdn.isZero() {
+		v = append(v, EntryViolation{Kind: RDNViolation, Value: dn,
+			Err: mkerr("malformed DN")})
+		return
+	}
+
+	for _, atv := range gdn.components[0] {
+		if len(atv) != 2 {
+			v = append(v, EntryViolation{Kind: RDNViolation, Value: dn,
+				Err: mkerr("malformed RDN")})
+			continue
+		}
+
+		typ, val := trimS(atv[0]), unescapeRDNValue(trimS(atv[1]))
+
+		var ea *entryAttribute
+		for _, _ea := range present {
+			if _ea.at.IsIdentifiedAs(typ) {
+				ea = _ea
+				break
+			}
+		}
+
+		var found bool
+		for i := 0; ea != nil && i < len(ea.vals) && !found; i++ {
+			found = eq(ea.vals[i], val) || ea.at.EqualityAssertion(ea.vals[i], val) == nil
+		}
+
+		if !found {
+			v = append(v, EntryViolation{
+				Kind:      RDNViolation,
+				Attribute: typ,
+				Value:     val,
+				Err:       mkerr("RDN value not present within entry"),
+			})
+		}
+	}
+
+	return
+}
+
+/*
+unescapeRDNValue returns val less RFC 4514 escapes, whether of the
+backslash-character or backslash-hexpair form.
+*/
+func unescapeRDNValue(val string) string {
+	var b []byte
+	for i := 0; i < len(val); i++ {
+		if val[i] == '\\' && i+1 < len(val) {
+			if i+2 < len(val) && isHexByte(val[i+1]) && isHexByte(val[i+2]) {
+				b = append(b, hexByte(val[i+1])<<4|hexByte(val[i+2]))
+				i += 2
+			} else {
+				b = append(b, val[i+1])
+				i++
+			}
+			continue
+		}
+		b = append(b, val[i])
+	}
+
+	return string(b)
+}
+
+func isHexByte(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func hexByte(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+
+	return c - 'A' + 10
+}
+
+/*
+sortedAttributeKeys returns the keys of present in lexical order.
+*/
+func sortedAttributeKeys(present map[string]*entryAttribute) (keys []string) {
+	for k := range present {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return
+}
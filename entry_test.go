@@ -0,0 +1,242 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the validation of an entry which bears several
+schema violations.
+*/
+func ExampleSchema_ValidateEntry() {
+	sch := NewSchema()
+
+	v, err := sch.ValidateEntry(`cn=Jesse Coretta,ou=People,dc=example,dc=com`,
+		map[string][]string{
+			`objectClass`: {`person`, `organizationalUnit`},
+			`cn`:          {`Jesse Coretta`},
+			`mail`:        {`jc@example.com`},
+		})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(v)
+	// Output:
+	// structural class violation: objectClass: multiple structural object class chains present: person, organizationalUnit
+	// required attribute type missing: ou
+	// required attribute type missing: sn
+	// attribute type not allowed: mail
+}
+
+func TestSchema_ValidateEntry(t *testing.T) {
+	sch := NewSchema()
+	if err := sch.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.83.1
+	NAME 'fakeSingleAttr'
+	EQUALITY caseIgnoreMatch
+	SYNTAX 1.3.6.1.4.1.1466.115.121.1.15
+	SINGLE-VALUE )
+
+attributeType ( 1.3.6.1.4.1.56521.999.83.2
+	NAME 'fakeQualifiedAttr'
+	SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 )
+
+objectClass ( 1.3.6.1.4.1.56521.999.83.3
+	NAME 'fakeAuxClass'
+	SUP top AUXILIARY
+	MAY ( fakeSingleAttr $ fakeQualifiedAttr ) )
+
+objectClass ( 1.3.6.1.4.1.56521.999.83.4
+	NAME 'fakeOtherAuxClass'
+	SUP top AUXILIARY )
+
+objectClass ( 1.3.6.1.4.1.56521.999.83.5
+	NAME 'fakePerson'
+	SUP person STRUCTURAL
+	MAY ( description $ mail $ telephoneNumber ) )
+
+dITContentRule ( 1.3.6.1.4.1.56521.999.83.5
+	NAME 'fakePersonContentRule'
+	AUX fakeAuxClass
+	MUST description
+	MAY mail
+	NOT telephoneNumber )`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	sch.AttributeTypes().Get(`fakeQualifiedAttr`).SetValueQualifier(func(x any) error {
+		if _, ok := atoui(x.(string)); !ok {
+			return errors.New("value is not an integer")
+		}
+		return nil
+	})
+
+	valid := map[string][]string{
+		`objectClass`:       {`fakePerson`, `fakeAuxClass`},
+		`cn`:                {`Jesse Coretta`},
+		`sn;lang-en`:        {`Coretta`},
+		`description`:       {`Fake person`},
+		`mail`:              {`jc@example.com`},
+		`fakeSingleAttr`:    {`value`},
+		`fakeQualifiedAttr`: {`42`},
+	}
+
+	v, err := sch.ValidateEntry(`cn=Jesse\20Coretta,ou=People,dc=example,dc=com`, valid)
+	if err != nil || !v.IsZero() {
+		t.Errorf("%s failed: unexpected violations (%v):\n%s", t.Name(), err, v)
+		return
+	}
+
+	// superclasses of listed classes are implied, thus
+	// person and top need not be listed explicitly.
+	for _, oc := range []string{`person`, `top`} {
+		if v, _ = sch.ValidateEntry(``, map[string][]string{
+			`objectClass`: {`fakePerson`, `fakeAuxClass`, oc},
+			`cn`:          {`Jesse Coretta`},
+			`sn`:          {`Coretta`},
+			`description`: {`Fake person`},
+		}); !v.IsZero() {
+			t.Errorf("%s failed: unexpected violations:\n%s", t.Name(), v)
+			return
+		}
+	}
+
+	invalid := map[string][]string{
+		`objectClass`:       {`fakePerson`, `fakeAuxClass`, `fakeOtherAuxClass`, `bogusClass`},
+		`cn`:                {`Someone Else`},
+		`telephoneNumber`:   {`+1 555 555 5555`},
+		`uid`:               {`jcoretta`},
+		`fakeSingleAttr`:    {`value1`, `value2`},
+		`fakeQualifiedAttr`: {`abc`},
+		`subschemaSubentry`: {`cn=schema`},
+		`bogusAttr`:         {`value`},
+	}
+
+	if v, err = sch.ValidateEntry(`cn=Jesse Coretta,ou=People,dc=example,dc=com`, invalid); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	for kind, want := range map[uint]int{
+		ObjectClassViolation:         1,
+		StructuralClassViolation:     0,
+		AuxiliaryClassViolation:      1,
+		UnknownAttributeViolation:    1,
+		MissingAttributeViolation:    2, // sn, description
+		DisallowedAttributeViolation: 1, // uid
+		ProhibitedAttributeViolation: 1, // telephoneNumber
+		SingleValueViolation:         1,
+		NoUserModificationViolation:  1, // subschemaSubentry
+		ValueViolation:               1,
+		RDNViolation:                 1,
+	} {
+		if got := v.Kind(kind).Len(); got != want {
+			t.Errorf("%s failed: want %d violations of kind %d, got %d:\n%s",
+				t.Name(), want, kind, got, v)
+			return
+		}
+	}
+
+	if err = v.Err(); err == nil || !errors.Is(err, ErrObjectClassNotFound) {
+		t.Errorf("%s failed: expected %v within %v", t.Name(), ErrObjectClassNotFound, err)
+		return
+	}
+}
+
+/*
+Superclasses of an AUXILIARY class permitted by a DITContentRule are
+implied, and are therefore permitted as well.
+*/
+func TestSchema_ValidateEntry_auxiliarySuperClass(t *testing.T) {
+	sch := NewSchema()
+	if err := sch.ParseRaw([]byte(`objectClass ( 1.3.6.1.4.1.56521.999.83.6
+	NAME 'fakeAuxSuperClass'
+	SUP top AUXILIARY )
+
+objectClass ( 1.3.6.1.4.1.56521.999.83.7
+	NAME 'fakeAuxSubClass'
+	SUP fakeAuxSuperClass AUXILIARY )
+
+objectClass ( 1.3.6.1.4.1.56521.999.83.8
+	NAME 'fakeAuxPerson'
+	SUP person STRUCTURAL )
+
+objectClass ( 1.3.6.1.4.1.56521.999.83.9
+	NAME 'fakeUnlistedAuxClass'
+	SUP fakeAuxSuperClass AUXILIARY )
+
+dITContentRule ( 1.3.6.1.4.1.56521.999.83.8
+	NAME 'fakeAuxPersonContentRule'
+	AUX fakeAuxSubClass )`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	for idx, tc := range []struct {
+		ocs  []string
+		want int
+	}{
+		{[]string{`fakeAuxPerson`, `fakeAuxSubClass`}, 0},
+		{[]string{`fakeAuxPerson`, `fakeAuxSubClass`, `fakeAuxSuperClass`}, 0},
+		{[]string{`fakeAuxPerson`, `fakeUnlistedAuxClass`}, 1},
+	} {
+		v, err := sch.ValidateEntry(``, map[string][]string{
+			`objectClass`: tc.ocs,
+			`cn`:          {`Jesse Coretta`},
+			`sn`:          {`Coretta`},
+		})
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		} else if got := v.Kind(AuxiliaryClassViolation).Len(); got != tc.want {
+			t.Errorf("%s[%d] failed: want %d AUX violations, got %d:\n%s", t.Name(), idx, tc.want, got, v)
+		}
+	}
+}
+
+func TestSchema_ValidateEntry_codecov(t *testing.T) {
+	var sch Schema
+	if _, err := sch.ValidateEntry(``, nil); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	sch = NewSchema()
+	if _, err := sch.ValidateEntry(``, nil); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	for idx, pair := range []struct {
+		dn    string
+		attrs map[string][]string
+		kind  uint
+	}{
+		{``, map[string][]string{`cn`: {`x`}, `sn`: {}}, ObjectClassViolation},
+		{``, map[string][]string{`objectClass`: {`top`}}, StructuralClassViolation},
+		{``, map[string][]string{`objectClass`: {`account`, `device`, `top`}, `uid`: {`x`}, `cn`: {`x`}}, StructuralClassViolation},
+		{`bogus`, map[string][]string{`objectClass`: {`device`}, `cn`: {`x`}}, RDNViolation},
+		{`cn=x=y`, map[string][]string{`objectClass`: {`device`}, `cn`: {`x`}}, RDNViolation},
+	} {
+		v, err := sch.ValidateEntry(pair.dn, pair.attrs)
+		if err != nil || v.Kind(pair.kind).IsZero() {
+			t.Errorf("%s[%d] failed: expected violation of kind %d, got %v (%v)",
+				t.Name(), idx, pair.kind, v, err)
+		}
+	}
+
+	for in, want := range map[string]string{
+		`Jesse\2C Coretta`: `Jesse, Coretta`,
+		`a\,b\2cc\2Fd`:     `a,b,c/d`,
+		`trailing\`:        `trailing\`,
+	} {
+		if got := unescapeRDNValue(in); got != want {
+			t.Errorf("%s failed: want %q, got %q", t.Name(), want, got)
+		}
+	}
+
+	var v EntryViolation
+	if v.Unwrap() != nil || v.Error() != `object class violation` {
+		t.Errorf("%s failed: unexpected zero violation state", t.Name())
+	}
+}
@@ -5,7 +5,10 @@ err.go contains a quick error wrapper and some predefined errors meant
 for use within this package as well as by end-users writing closures.
 */
 
-import "errors"
+import (
+	"errors"
+	"sort"
+)
 
 var (
 	ErrNamingViolationMissingMust  error = errors.New("Naming violation; required attribute type not used")
@@ -16,6 +19,7 @@ var (
 	ErrNilSyntaxQualifier          error = errors.New("No SyntaxQualifier instance assigned to LDAPSyntax")
 	ErrNilValueQualifier           error = errors.New("No ValueQualifier instance assigned to AttributeType")
 	ErrNilAssertionMatcher         error = errors.New("No AssertionMatcher instance assigned to MatchingRule")
+	ErrNilStringPreparer           error = errors.New("No StringPreparer instance assigned to MatchingRule")
 	ErrNilReceiver                 error = errors.New("Receiver instance is nil")
 	ErrNilInput                    error = errors.New("Input instance is nil")
 	ErrNilDef                      error = errors.New("Referenced definition is nil or not specified")
@@ -35,6 +39,14 @@ var (
 	ErrMissingNumericOID           error = errors.New("Missing or invalid numeric OID for definition")
 	ErrInvalidDNOrFlatInt          error = errors.New("Invalid DN or flattened integer")
 	ErrIncompatStructuralClass     error = errors.New("Incompatible structural class for target")
+	ErrDefinitionInUse             error = errors.New("Definition is referenced by one or more dependent definitions")
+	ErrUnrecognizedContent         error = errors.New("Content is not a recognized definition or directive")
+	ErrIncompleteDefinition        error = errors.New("Definition is incomplete or bears unbalanced parentheses")
+	ErrCircularDependency          error = errors.New("Circular dependency between definitions")
+	ErrInvalidFilter               error = errors.New("Search filter is malformed")
+	ErrReadOnlySchema              error = errors.New("Schema is read-only")
+	ErrTransactionDone             error = errors.New("Transaction has already been committed or rolled back")
+	ErrOverrideNotAllowed          error = errors.New("Replacement not permitted without AllowOverride")
 
 	ErrSuperTypeNotFound     error = errors.New("SUP AttributeType not found")
 	ErrOrderingRuleNotFound  error = errors.New("ORDERING MatchingRule not found")
@@ -51,4 +63,111 @@ var (
 	ErrDITStructureRuleNotFound error = errors.New("DITStructureRule not found")
 )
 
-var mkerr func(string) error = errors.New
+var (
+	mkerr   func(string) error   = errors.New
+	joinErr func(...error) error = errors.Join
+)
+
+/*
+wrappedError implements an error which extends the message of a predefined
+error while remaining compatible with [errors.Is].
+*/
+type wrappedError struct {
+	base error
+	msg  string
+}
+
+/*
+wraperr returns an error bearing the message of base suffixed with msg,
+which unwraps to base.
+*/
+func wraperr(base error, msg string) error {
+	return wrappedError{base: base, msg: msg}
+}
+
+func (r wrappedError) Error() string {
+	return r.base.Error() + r.msg
+}
+
+func (r wrappedError) Unwrap() error {
+	return r.base
+}
+
+/*
+ParseError describes a single failure encountered while parsing a schema
+file, directory or raw byte sequence in the presence of the [ContinueOnError]
+option.
+
+Instances of this type are returned in aggregated form as a single error,
+which is compatible with [errors.Is] and [errors.As].  Each instance wraps
+the underlying error, thus:
+
+	var perr ParseError
+	if errors.As(err, &perr) {
+		fmt.Println(perr.File, perr.Line)
+	}
+
+	if errors.Is(err, ErrDuplicateDef) {
+		// handle duplicates
+	}
+*/
+type ParseError struct {
+	File string // source file, if known
+	Line int    // line on which the definition begins
+	Type string // definition type, e.g.: "attributeType"
+	ID   string // numeric OID, macro or rule ID, if known
+	Name string // principal NAME, if known
+	Err  error  // the underlying error
+}
+
+/*
+Error returns the string representation of the receiver instance, e.g.:
+
+	vendor.schema:42: attributeType 1.3.6.1.4.1.56521.999.1 (fakeAttr): SUP AttributeType not found
+*/
+func (r ParseError) Error() (s string) {
+	if len(r.File) > 0 {
+		s = r.File + `:`
+	}
+	s += itoa(r.Line) + `: `
+
+	if len(r.Type) > 0 {
+		s += r.Type + ` `
+	}
+
+	if len(r.ID) > 0 {
+		s += r.ID + ` `
+	}
+
+	if len(r.Name) > 0 {
+		s += `(` + r.Name + `) `
+	}
+
+	s = trimR(s, ` `) + `: `
+	if r.Err != nil {
+		s += r.Err.Error()
+	}
+
+	return
+}
+
+/*
+Unwrap returns the underlying error instance.
+*/
+func (r ParseError) Unwrap() error {
+	return r.Err
+}
+
+/*
+sortParseErrors sorts errs, all of which must be [ParseError] instances,
+by file and line.
+*/
+func sortParseErrors(errs []error) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		ei, ej := errs[i].(ParseError), errs[j].(ParseError)
+		if ei.File != ej.File {
+			return ei.File < ej.File
+		}
+		return ei.Line < ej.Line
+	})
+}
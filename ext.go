@@ -17,6 +17,9 @@ func (r Extensions) push(extn any) (err error) {
 	if extn == nil {
 		err = ErrNilInput
 		return
+	} else if r.cast().IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
 	}
 
 	r.cast().Push(extn)
@@ -89,8 +92,15 @@ func (r Extensions) IsZero() bool {
 /*
 Set assigns the provided key and values instances to the receiver instance,
 thereby specifying a new extension value as described in RFC 4512.
+
+This method has no effect upon the extensions of a definition residing
+within a read-only [Schema].
 */
 func (r Extensions) Set(key string, values ...string) {
+	if r.cast().IsReadOnly() {
+		return
+	}
+
 	_key := uc(key)
 	var hi, se bool
 	if def := r.Definition(); def != nil {
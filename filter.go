@@ -0,0 +1,859 @@
+package schemax
+
+/*
+filter.go contains facilities for the parsing of RFC 4515 search filters
+and their evaluation against entries in the context of a Schema.
+*/
+
+import "sort"
+
+/*
+Filter kinds, as returned by [Filter.Kind].
+*/
+const (
+	AndFilter            uint = iota // (&(...)(...))
+	OrFilter                         // (|(...)(...))
+	NotFilter                        // (!(...))
+	EqualityFilter                   // (attr=value)
+	SubstringsFilter                 // (attr=in*any*fin)
+	GreaterOrEqualFilter             // (attr>=value)
+	LessOrEqualFilter                // (attr<=value)
+	PresentFilter                    // (attr=*)
+	ApproxFilter                     // (attr~=value)
+	ExtensibleFilter                 // (attr:dn:rule:=value)
+)
+
+/*
+Filter implements an RFC 4515 search filter, as returned by [ParseFilter].
+
+Instances of this type may be evaluated against an entry through the
+[Schema.MatchFilter] method.
+*/
+type Filter struct {
+	*filter
+}
+
+type filter struct {
+	kind    uint
+	subs    []Filter
+	attr    string // attribute description; may be zero for extensible
+	rule    string // extensible matchingRule
+	dnAttrs bool   // extensible dnAttributes
+	value   string // unescaped assertion value
+	initial string // substrings
+	any     []string
+	final   string
+}
+
+/*
+ParseFilter returns an instance of [Filter] alongside an error following
+an attempt to parse raw as an RFC 4515 search filter, e.g.:
+
+	(&(objectClass=person)(|(cn=jo*)(sn:caseExactMatch:=Smith)))
+
+The absolute true "(&)" and absolute false "(|)" filters of RFC 4526 are
+also supported.  Failures are returned as [ErrInvalidFilter] errors.
+*/
+func ParseFilter(raw string) (f Filter, err error) {
+	p := &filterParser{s: raw}
+	if f, err = p.filter(); err == nil && p.i != len(p.s) {
+		f = Filter{}
+		err = filterErr(p.i, `unexpected trailing content`)
+	}
+
+	return
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state.
+*/
+func (r Filter) IsZero() bool {
+	return r.filter == nil
+}
+
+/*
+Kind returns the kind of the receiver instance, such as [AndFilter] or
+[EqualityFilter].
+*/
+func (r Filter) Kind() (kind uint) {
+	if !r.IsZero() {
+		kind = r.filter.kind
+	}
+
+	return
+}
+
+/*
+Filters returns the subordinate [Filter] instances of the receiver, which
+must be an [AndFilter], [OrFilter] or [NotFilter].
+*/
+func (r Filter) Filters() (subs []Filter) {
+	if !r.IsZero() {
+		subs = r.filter.subs
+	}
+
+	return
+}
+
+/*
+Attribute returns the attribute description of the receiver instance, if
+applicable.
+*/
+func (r Filter) Attribute() (attr string) {
+	if !r.IsZero() {
+		attr = r.filter.attr
+	}
+
+	return
+}
+
+/*
+Value returns the unescaped assertion value of the receiver instance, if
+applicable.  Substrings filters return their assertion value in the form
+of the Substring Assertion syntax (RFC 4517 Section 3.3.30), e.g.:
+"jess*cor*".
+*/
+func (r Filter) Value() (value string) {
+	if !r.IsZero() {
+		if r.filter.kind == SubstringsFilter {
+			value = r.filter.substringAssertion()
+		} else {
+			value = r.filter.value
+		}
+	}
+
+	return
+}
+
+/*
+String returns the string representation of the receiver instance, with
+assertion values escaped per RFC 4515.
+*/
+func (r Filter) String() (s string) {
+	if r.IsZero() {
+		return
+	}
+
+	f := r.filter
+	switch f.kind {
+	case AndFilter, OrFilter, NotFilter:
+		s = string(`&|!`[f.kind])
+		for _, sub := range f.subs {
+			s += sub.String()
+		}
+	case EqualityFilter:
+		s = f.attr + `=` + escapeFilterValue(f.value)
+	case SubstringsFilter:
+		s = f.attr + `=` + escapeFilterValue(f.initial) + `*`
+		for _, a := range f.any {
+			s += escapeFilterValue(a) + `*`
+		}
+		s += escapeFilterValue(f.final)
+	case GreaterOrEqualFilter:
+		s = f.attr + `>=` + escapeFilterValue(f.value)
+	case LessOrEqualFilter:
+		s = f.attr + `<=` + escapeFilterValue(f.value)
+	case PresentFilter:
+		s = f.attr + `=*`
+	case ApproxFilter:
+		s = f.attr + `~=` + escapeFilterValue(f.value)
+	case ExtensibleFilter:
+		s = f.attr
+		if f.dnAttrs {
+			s += `:dn`
+		}
+		if len(f.rule) > 0 {
+			s += `:` + f.rule
+		}
+		s += `:=` + escapeFilterValue(f.value)
+	}
+
+	return `(` + s + `)`
+}
+
+/*
+substringAssertion returns the components of a substrings filter in the
+form of the Substring Assertion syntax.
+*/
+func (r *filter) substringAssertion() string {
+	esc := func(s string) string {
+		return repAll(repAll(s, `\`, `\5C`), `*`, `\2A`)
+	}
+
+	s := esc(r.initial) + `*`
+	for _, a := range r.any {
+		s += esc(a) + `*`
+	}
+
+	return s + esc(r.final)
+}
+
+/*
+escapeFilterValue returns value with all characters requiring escape per
+RFC 4515 Section 3 replaced by their escaped forms.
+*/
+func escapeFilterValue(value string) string {
+	b := newStringBuilder()
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '*', '(', ')', '\\', 0:
+			b.WriteString(`\` + lc(fmtInt(int64(c)|0x100, 16)[1:]))
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+func filterErr(pos int, reason string) error {
+	return wraperr(ErrInvalidFilter, `: `+reason+` at position `+itoa(pos))
+}
+
+/*
+filterParser implements recursive descent parsing of RFC 4515 filters.
+*/
+type filterParser struct {
+	s string
+	i int
+}
+
+// filter = LPAREN filtercomp RPAREN
+func (r *filterParser) filter() (f Filter, err error) {
+	if r.i >= len(r.s) || r.s[r.i] != '(' {
+		err = filterErr(r.i, `expected '('`)
+		return
+	}
+	r.i++
+
+	if r.i >= len(r.s) {
+		err = filterErr(r.i, `unexpected end of filter`)
+		return
+	}
+
+	switch r.s[r.i] {
+	case '&', '|':
+		f = Filter{&filter{kind: AndFilter}}
+		if r.s[r.i] == '|' {
+			f.filter.kind = OrFilter
+		}
+		r.i++
+		for err == nil && r.i < len(r.s) && r.s[r.i] == '(' {
+			var sub Filter
+			if sub, err = r.filter(); err == nil {
+				f.filter.subs = append(f.filter.subs, sub)
+			}
+		}
+	case '!':
+		r.i++
+		var sub Filter
+		if sub, err = r.filter(); err == nil {
+			f = Filter{&filter{kind: NotFilter, subs: []Filter{sub}}}
+		}
+	default:
+		f, err = r.item()
+	}
+
+	if err == nil {
+		if r.i >= len(r.s) || r.s[r.i] != ')' {
+			err = filterErr(r.i, `expected ')'`)
+		} else {
+			r.i++
+		}
+	}
+
+	if err != nil {
+		f = Filter{}
+	}
+
+	return
+}
+
+// item = simple / present / substring / extensible
+func (r *filterParser) item() (f Filter, err error) {
+	start := r.i
+	for r.i < len(r.s) && r.s[r.i] != ')' && r.s[r.i] != '(' {
+		r.i++
+	}
+	item := r.s[start:r.i]
+
+	idx := stridx(item, `=`)
+	if idx < 1 {
+		err = filterErr(start, `missing attribute description or '='`)
+		return
+	}
+
+	f = Filter{&filter{kind: EqualityFilter}}
+	attr, value := item[:idx], item[idx+1:]
+	switch attr[len(attr)-1] {
+	case '~':
+		f.filter.kind, attr = ApproxFilter, attr[:len(attr)-1]
+	case '>':
+		f.filter.kind, attr = GreaterOrEqualFilter, attr[:len(attr)-1]
+	case '<':
+		f.filter.kind, attr = LessOrEqualFilter, attr[:len(attr)-1]
+	case ':':
+		f.filter.kind = ExtensibleFilter
+		err = f.filter.setExtensible(attr[:len(attr)-1])
+	}
+
+	if err == nil && f.filter.kind != ExtensibleFilter {
+		if !isAttributeDescription(attr) {
+			err = mkerr(`invalid attribute description '` + attr + `'`)
+		}
+		f.filter.attr = attr
+	}
+
+	if err == nil {
+		if f.filter.kind == EqualityFilter && value == `*` {
+			f.filter.kind = PresentFilter
+		} else if f.filter.kind == EqualityFilter && stridx(value, `*`) != -1 {
+			f.filter.kind = SubstringsFilter
+			err = f.filter.setSubstrings(value)
+		} else {
+			f.filter.value, err = unescapeFilterValue(value)
+		}
+	}
+
+	if err != nil {
+		f = Filter{}
+		err = filterErr(start, err.Error())
+	}
+
+	return
+}
+
+/*
+setExtensible sets the attribute description, dnAttributes flag and
+matching rule of the receiver per the left-hand side of an extensible
+filter item, less its trailing colon.
+
+	extensible = ( attr [dnattrs] [matchingrule] COLON EQUALS assertionvalue )
+	             / ( [dnattrs] matchingrule COLON EQUALS assertionvalue )
+*/
+func (r *filter) setExtensible(lhs string) (err error) {
+	parts := split(lhs, `:`)
+	if r.attr = parts[0]; len(r.attr) > 0 && !isAttributeDescription(r.attr) {
+		return mkerr(`invalid attribute description '` + r.attr + `'`)
+	}
+
+	parts = parts[1:]
+	if len(parts) > 0 && eq(parts[0], `dn`) {
+		r.dnAttrs = true
+		parts = parts[1:]
+	}
+
+	switch len(parts) {
+	case 0:
+	case 1:
+		if r.rule = parts[0]; !isNumericOID(r.rule) && !isDescriptor(r.rule) {
+			err = mkerr(`invalid matching rule '` + r.rule + `'`)
+		}
+	default:
+		err = mkerr(`malformed extensible match`)
+	}
+
+	if err == nil && len(r.attr) == 0 && len(r.rule) == 0 {
+		err = mkerr(`extensible match requires an attribute description or matching rule`)
+	}
+
+	return
+}
+
+/*
+setSubstrings sets the initial, any and final components of the receiver
+per value, which must contain at least one unescaped asterisk.
+*/
+func (r *filter) setSubstrings(value string) (err error) {
+	parts := split(value, `*`)
+	if r.initial, err = unescapeFilterValue(parts[0]); err != nil {
+		return
+	} else if r.final, err = unescapeFilterValue(parts[len(parts)-1]); err != nil {
+		return
+	}
+
+	for _, part := range parts[1 : len(parts)-1] {
+		if len(part) == 0 {
+			return mkerr(`empty substring`)
+		}
+
+		var a string
+		if a, err = unescapeFilterValue(part); err != nil {
+			return
+		}
+		r.any = append(r.any, a)
+	}
+
+	return
+}
+
+/*
+unescapeFilterValue returns value less its RFC 4515 escapes, alongside an
+error should value contain a malformed escape or an unescaped character
+which requires escaping.
+*/
+func unescapeFilterValue(value string) (string, error) {
+	var b []byte
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '\\':
+			if i+2 >= len(value) {
+				return ``, mkerr(`incomplete escape sequence`)
+			} else if !isHexByte(value[i+1]) || !isHexByte(value[i+2]) {
+				return ``, mkerr(`invalid escape sequence`)
+			}
+			b = append(b, hexByte(value[i+1])<<4|hexByte(value[i+2]))
+			i += 2
+		case '*', '(', ')', 0:
+			return ``, mkerr(`unescaped '` + string(c) + `'`)
+		default:
+			b = append(b, c)
+		}
+	}
+
+	return string(b), nil
+}
+
+/*
+isAttributeDescription returns a Boolean value indicative of s being an
+RFC 4512 attribute description, in that it consists of a descriptor or
+numeric OID, optionally followed by semicolon-delimited options.
+*/
+func isAttributeDescription(s string) bool {
+	parts := split(s, `;`)
+	if !isNumericOID(parts[0]) && !isDescriptor(parts[0]) {
+		return false
+	}
+
+	for _, opt := range parts[1:] {
+		if !isKeystring(opt) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Filter evaluation results, per RFC 4511 Section 4.5.1.7.
+*/
+const (
+	filterFalse uint8 = iota
+	filterTrue
+	filterUndefined
+)
+
+/*
+filterAttribute contains the values of a single attribute description
+present within an entry under evaluation.
+*/
+type filterAttribute struct {
+	at   AttributeType
+	opts []string
+	vals []string
+}
+
+/*
+filterEvaluation contains the state of a single invocation of the
+[Schema.MatchFilter] method.
+*/
+type filterEvaluation struct {
+	schema Schema
+	dn     string
+	attrs  []filterAttribute
+	types  map[string]map[string]bool
+}
+
+/*
+MatchFilter returns a Boolean value indicative of the entry described by
+dn and attrs matching filter, alongside an error.  The filter may be a
+string, which is parsed through [ParseFilter], or a [Filter] instance.
+
+The keys of attrs are attribute descriptions, such as "cn" or "cn;lang-en",
+and are resolved against the receiver instance without regard for case.
+Unknown attribute types are ignored.
+
+Evaluation is conducted in the manner of a DSA, per RFC 4511 Section 4.5.1.7:
+
+  - Attribute descriptions within filter items are resolved to [AttributeType] instances by name or numeric OID, and match the values of the type in question as well as all of its subtypes (see [AttributeType.SubTypes])
+  - Attribute options within filter items must be present within the attribute descriptions of matched values
+  - Equality and approximate items use the [AttributeType.EffectiveEquality] rule
+  - Substrings items use the [AttributeType.EffectiveSubstring] rule
+  - Greater-or-equal items use the [AttributeType.EffectiveOrdering] rule, while less-or-equal items also use the [AttributeType.EffectiveEquality] rule; absent an ordering rule, both evaluate to Undefined
+  - Values and assertions of the objectIdentifierMatch rule (and assertions of the objectIdentifierFirstComponentMatch rule) are resolved to numeric OIDs by way of the receiver instance, such that "2.5.6.6" matches "person"
+  - Extensible items use the named [MatchingRule] or, if none, the effective equality rule of the named attribute type; the ":dn" flag extends evaluation to the attribute values of dn
+  - Extensible items which name no attribute type are applied to all attribute types for which the rule is an effective rule, or whose effective syntax is that of the rule
+  - Items evaluate to Undefined when their attribute type or matching rule is unknown, when no suitable matching rule exists, or when an assertion cannot be made, and Undefined is handled through the three-valued logic of RFC 4511
+
+Each [MatchingRule] is consulted through its assigned [AssertionMatcher]. Should
+no matcher be assigned, the built-in matcher returned by [RFC4517AssertionMatcher]
+is used, where available.
+
+A true return value indicates that the filter evaluated to TRUE, in which case a
+DSA would return the entry in a search response.
+*/
+func (r Schema) MatchFilter(filter any, dn string, attrs map[string][]string) (match bool, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	var f Filter
+	switch tv := filter.(type) {
+	case string:
+		if f, err = ParseFilter(tv); err != nil {
+			return
+		}
+	case Filter:
+		if f = tv; f.IsZero() {
+			err = ErrNilInput
+			return
+		}
+	default:
+		err = ErrInvalidType
+		return
+	}
+
+	ev := &filterEvaluation{
+		schema: r,
+		dn:     dn,
+		types:  make(map[string]map[string]bool),
+	}
+
+	for _, desc := range sortedKeys(attrs) {
+		typ, opts := splitAttributeDescription(desc)
+		if at := r.AttributeTypes().get(typ); !at.IsZero() && len(attrs[desc]) > 0 {
+			ev.attrs = append(ev.attrs, filterAttribute{at: at, opts: opts, vals: attrs[desc]})
+		}
+	}
+
+	match = ev.evaluate(f) == filterTrue
+
+	return
+}
+
+func sortedKeys(m map[string][]string) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return
+}
+
+/*
+splitAttributeDescription returns the attribute type and lowercase
+options of the attribute description desc.
+*/
+func splitAttributeDescription(desc string) (typ string, opts []string) {
+	parts := split(desc, `;`)
+	typ = parts[0]
+	for _, opt := range parts[1:] {
+		opts = append(opts, lc(opt))
+	}
+
+	return
+}
+
+func (r *filterEvaluation) evaluate(f Filter) (res uint8) {
+	switch f.filter.kind {
+	case AndFilter:
+		res = filterTrue
+		for _, sub := range f.filter.subs {
+			if subres := r.evaluate(sub); subres == filterFalse {
+				return filterFalse
+			} else if subres == filterUndefined {
+				res = filterUndefined
+			}
+		}
+	case OrFilter:
+		res = filterFalse
+		for _, sub := range f.filter.subs {
+			if subres := r.evaluate(sub); subres == filterTrue {
+				return filterTrue
+			} else if subres == filterUndefined {
+				res = filterUndefined
+			}
+		}
+	case NotFilter:
+		switch res = r.evaluate(f.filter.subs[0]); res {
+		case filterTrue:
+			res = filterFalse
+		case filterFalse:
+			res = filterTrue
+		}
+	case PresentFilter:
+		if at, opts := r.resolve(f.filter.attr); !at.IsZero() && len(r.values(at, opts)) > 0 {
+			res = filterTrue
+		}
+	case ExtensibleFilter:
+		res = r.evaluateExtensible(f.filter)
+	default:
+		res = r.evaluateItem(f.filter)
+	}
+
+	return
+}
+
+/*
+resolve returns the [AttributeType] and options of the attribute
+description desc.
+*/
+func (r *filterEvaluation) resolve(desc string) (at AttributeType, opts []string) {
+	typ, opts := splitAttributeDescription(desc)
+	at = r.schema.AttributeTypes().get(typ)
+
+	return
+}
+
+/*
+typeSet returns the numeric OIDs of at and all of its subtypes.
+*/
+func (r *filterEvaluation) typeSet(at AttributeType) map[string]bool {
+	oid := at.NumericOID()
+	if set, found := r.types[oid]; found {
+		return set
+	}
+
+	set := map[string]bool{oid: true}
+	r.types[oid] = set
+
+	subs := at.SubTypes()
+	for i := 0; i < subs.Len(); i++ {
+		for k := range r.typeSet(subs.Index(i)) {
+			set[k] = true
+		}
+	}
+
+	return set
+}
+
+/*
+values returns all values of at, and of its subtypes, whose attribute
+descriptions bear all of the options within opts.
+*/
+func (r *filterEvaluation) values(at AttributeType, opts []string) (vals []string) {
+	set := r.typeSet(at)
+	for _, attr := range r.attrs {
+		if set[attr.at.NumericOID()] && hasOptions(attr.opts, opts) {
+			vals = append(vals, attr.vals...)
+		}
+	}
+
+	return
+}
+
+func hasOptions(have, want []string) bool {
+	for _, opt := range want {
+		if !strInSlice(opt, have) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+matcher returns the [AssertionMatcher] to be used for mr, or nil if none
+is available.
+*/
+func matcher(mr MatchingRule) (funk AssertionMatcher) {
+	if mr.IsZero() {
+		return
+	} else if mr.matchingRule.assMatch != nil {
+		funk = mr.Assertion
+	} else {
+		funk = RFC4517AssertionMatcher(mr.NumericOID())
+	}
+
+	return
+}
+
+/*
+assertAny returns the result of the application of funk to each value
+within vals alongside assertion, per RFC 4511 Section 4.5.1.7.
+*/
+func assertAny(funk AssertionMatcher, vals []string, assertion string, want bool) (res uint8) {
+	for _, val := range vals {
+		switch err := funk(val, assertion); {
+		case (err == nil) == want && (err == nil || err == ErrNoMatch):
+			return filterTrue
+		case err != nil && err != ErrNoMatch:
+			res = filterUndefined
+		}
+	}
+
+	return
+}
+
+/*
+evaluateItem returns the result of the equality, substrings, ordering or
+approximate filter item f.
+*/
+func (r *filterEvaluation) evaluateItem(f *filter) (res uint8) {
+	at, opts := r.resolve(f.attr)
+	if at.IsZero() {
+		return filterUndefined
+	}
+
+	vals := r.values(at, opts)
+
+	switch f.kind {
+	case EqualityFilter, ApproxFilter:
+		res = r.assert(at.EffectiveEquality(), vals, f.value, true)
+	case SubstringsFilter:
+		res = r.assert(at.EffectiveSubstring(), vals, f.substringAssertion(), true)
+	case GreaterOrEqualFilter:
+		// TRUE if any value is NOT less than the assertion value
+		res = r.assert(at.EffectiveOrdering(), vals, f.value, false)
+	case LessOrEqualFilter:
+		ord := at.EffectiveOrdering()
+		if ord.IsZero() {
+			// equality alone cannot satisfy an ordering
+			return filterUndefined
+		}
+		if res = r.assert(ord, vals, f.value, true); res != filterTrue {
+			if eqres := r.assert(at.EffectiveEquality(), vals, f.value, true); eqres != filterFalse {
+				res = eqres
+			}
+		}
+	}
+
+	return
+}
+
+/*
+resolveOIDs returns vals and assertion with each descriptor resolved to the
+numeric OID of the definition it identifies within the schema, should mr
+be the objectIdentifierMatch rule.  Only the assertion is resolved in the
+case of the objectIdentifierFirstComponentMatch rule.  This is necessary as
+an [AssertionMatcher] is, by nature, unaware of the schema.  Values which
+do not resolve are returned as-is.
+*/
+func (r *filterEvaluation) resolveOIDs(mr MatchingRule, vals []string, assertion string) ([]string, string) {
+	resolve := func(val string) string {
+		if oid, err := r.schema.normalizeOID(val); err == nil {
+			return oid
+		}
+		return val
+	}
+
+	switch mr.NumericOID() {
+	case `2.5.13.0`: // objectIdentifierMatch
+		resolved := make([]string, len(vals))
+		for i, val := range vals {
+			resolved[i] = resolve(val)
+		}
+		vals = resolved
+		fallthrough
+	case `2.5.13.30`: // objectIdentifierFirstComponentMatch
+		assertion = resolve(assertion)
+	}
+
+	return vals, assertion
+}
+
+func (r *filterEvaluation) assert(mr MatchingRule, vals []string, assertion string, want bool) uint8 {
+	funk := matcher(mr)
+	if funk == nil {
+		return filterUndefined
+	}
+
+	vals, assertion = r.resolveOIDs(mr, vals, assertion)
+
+	return assertAny(funk, vals, assertion, want)
+}
+
+/*
+evaluateExtensible returns the result of the extensible filter item f.
+*/
+func (r *filterEvaluation) evaluateExtensible(f *filter) (res uint8) {
+	var at AttributeType
+	var opts []string
+	var mr MatchingRule
+
+	if len(f.attr) > 0 {
+		if at, opts = r.resolve(f.attr); at.IsZero() {
+			return filterUndefined
+		}
+	}
+
+	if len(f.rule) > 0 {
+		if mr = r.schema.MatchingRules().get(f.rule); mr.IsZero() {
+			return filterUndefined
+		}
+	} else {
+		mr = at.EffectiveEquality()
+	}
+
+	funk := matcher(mr)
+	if funk == nil {
+		return filterUndefined
+	}
+
+	var vals []string
+	if !at.IsZero() {
+		vals = r.values(at, opts)
+	} else {
+		for _, attr := range r.attrs {
+			if ruleApplies(mr, attr.at) {
+				vals = append(vals, attr.vals...)
+			}
+		}
+	}
+
+	if f.dnAttrs {
+		vals = append(vals, r.dnValues(at, mr)...)
+	}
+
+	vals, assertion := r.resolveOIDs(mr, vals, f.value)
+
+	return assertAny(funk, vals, assertion, true)
+}
+
+/*
+ruleApplies returns a Boolean value indicative of mr being an effective
+rule of at, or of mr bearing the effective syntax of at.
+*/
+func ruleApplies(mr MatchingRule, at AttributeType) bool {
+	oid := mr.NumericOID()
+	for _, rule := range []MatchingRule{
+		at.EffectiveEquality(),
+		at.EffectiveSubstring(),
+		at.EffectiveOrdering(),
+	} {
+		if rule.NumericOID() == oid {
+			return true
+		}
+	}
+
+	syn := at.EffectiveSyntax()
+	return !syn.IsZero() && syn.NumericOID() == mr.Syntax().NumericOID()
+}
+
+/*
+dnValues returns the string attribute values within the RDNs of the
+entry DN which are of type at (or its subtypes) or, should at be zero,
+to which mr applies.
+*/
+func (r *filterEvaluation) dnValues(at AttributeType, mr MatchingRule) (vals []string) {
+	rdns, _ := splitDN(r.dn)
+
+	var set map[string]bool
+	if !at.IsZero() {
+		set = r.typeSet(at)
+	}
+
+	for _, rdn := range rdns {
+		for _, ava := range rdn {
+			dat := r.schema.AttributeTypes().get(ava[0])
+			if dat.IsZero() || hasPfx(ava[1], `#`) {
+				continue
+			} else if (set != nil && set[dat.NumericOID()]) || (set == nil && ruleApplies(mr, dat)) {
+				vals = append(vals, unescapeRDNValue(ava[1]))
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,269 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the evaluation of an RFC 4515 search filter
+against an entry through a [Schema] instance.
+*/
+func ExampleSchema_MatchFilter() {
+	sch := NewSchema(AssertionMatchers)
+
+	entry := map[string][]string{
+		`objectClass`: {`top`, `person`},
+		`cn`:          {`Jesse Coretta`},
+		`sn`:          {`Coretta`},
+	}
+
+	match, err := sch.MatchFilter(`(&(objectClass=person)(cn=jess*))`,
+		`cn=Jesse Coretta,dc=example,dc=com`, entry)
+	fmt.Println(match, err)
+	// Output: true <nil>
+}
+
+/*
+This example demonstrates the parsing of an RFC 4515 search filter.
+*/
+func ExampleParseFilter() {
+	f, err := ParseFilter(`(&(objectClass=person)(!(cn:caseExactMatch:=Jesse\2a)))`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(f.Filters()[1].Filters()[0].Value())
+	fmt.Println(f)
+	// Output:
+	// Jesse*
+	// (&(objectClass=person)(!(cn:caseExactMatch:=Jesse\2a)))
+}
+
+func TestParseFilter(t *testing.T) {
+	for idx, tc := range []struct {
+		raw  string
+		kind uint
+		want string
+	}{
+		{`(cn=Jesse)`, EqualityFilter, ``},
+		{`(cn=*)`, PresentFilter, ``},
+		{`(cn=j*s*e)`, SubstringsFilter, ``},
+		{`(cn=*s*)`, SubstringsFilter, ``},
+		{`(cn=\2a*)`, SubstringsFilter, `(cn=\2a*)`},
+		{`(cn;lang-en>=a)`, GreaterOrEqualFilter, ``},
+		{`(2.5.4.3<=a)`, LessOrEqualFilter, ``},
+		{`(cn~=Jesse)`, ApproxFilter, ``},
+		{`(cn:=Jesse)`, ExtensibleFilter, ``},
+		{`(cn:dn:2.5.13.5:=Jesse)`, ExtensibleFilter, ``},
+		{`(:caseExactMatch:=Jesse)`, ExtensibleFilter, ``},
+		{`(:DN:caseExactMatch:=Jesse)`, ExtensibleFilter, `(:dn:caseExactMatch:=Jesse)`},
+		{`(&)`, AndFilter, ``},
+		{`(|)`, OrFilter, ``},
+		{`(!(cn=x))`, NotFilter, ``},
+		{`(|(cn=x)(&(sn=y)(!(o=z))))`, OrFilter, ``},
+		{`(cn=\28\29\5C\00)`, EqualityFilter, `(cn=\28\29\5c\00)`},
+	} {
+		f, err := ParseFilter(tc.raw)
+		if err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+			continue
+		}
+
+		want := tc.want
+		if want == `` {
+			want = tc.raw
+		}
+
+		if f.Kind() != tc.kind {
+			t.Errorf("%s[%d] failed: want kind %d, got %d", t.Name(), idx, tc.kind, f.Kind())
+		} else if got := f.String(); got != want {
+			t.Errorf("%s[%d] failed: want %q, got %q", t.Name(), idx, want, got)
+		}
+	}
+
+	for idx, raw := range []string{
+		``,
+		`cn=x`,
+		`(cn=x`,
+		`(cn=x))`,
+		`(=x)`,
+		`(cn)`,
+		`(c_n=x)`,
+		`(cn;=x)`,
+		`(cn=x(y)`,
+		`(cn=**)`,
+		`(cn=a**b)`,
+		`(cn=\2)`,
+		`(cn=\zz)`,
+		`(cn>=x*)`,
+		`(:=x)`,
+		`(:dn:=x)`,
+		`(cn:a:b:=x)`,
+		`(cn:1.:=x)`,
+		`(&(cn=x)y)`,
+		`(!)`,
+		`(!(cn=x)(sn=y))`,
+	} {
+		if _, err := ParseFilter(raw); !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("%s[%d] failed: expected %v for %q, got %v",
+				t.Name(), idx, ErrInvalidFilter, raw, err)
+		}
+	}
+}
+
+func TestSchema_MatchFilter(t *testing.T) {
+	sch := NewSchema(AssertionMatchers)
+
+	dn := `cn=Jesse Coretta+uid=jesse,ou=People,dc=example,dc=com`
+	entry := map[string][]string{
+		`objectClass`:         {`top`, `person`, `inetOrgPerson`},
+		`cn`:                  {`Jesse Coretta`},
+		`CN;lang-en`:          {`Jesse`},
+		`sn`:                  {`Coretta`},
+		`uid`:                 {`jesse`},
+		`telephoneNumber`:     {`+1 512-315-0280`},
+		`createTimestamp`:     {`20240101000000Z`},
+		`description`:         {},
+		`unknownAttributeX`:   {`value`},
+		`2.5.4.13;lang-fr`:    {`Bonjour`},
+		`uniqueMember`:        {`uid=jesse,dc=example,dc=com`},
+		`departmentNumber`:    {`101`},
+		`facsimileTelephoneN`: {`bogus`},
+	}
+
+	for idx, tc := range []struct {
+		filter string
+		want   bool
+	}{
+		{`(objectClass=person)`, true},
+		{`(objectClass=PERSON)`, true},
+		{`(objectClass=device)`, false},
+		{`(2.5.4.0=inetorgperson)`, true},
+		{`(objectClass=2.5.6.6)`, true},
+		{`(objectClass=2.16.840.1.113730.3.2.2)`, true},
+		{`(objectClass=2.5.6.14)`, false},
+		{`(objectClass:objectIdentifierMatch:=2.5.6.6)`, true},
+		{`(&(objectClass=person)(cn=jes*))`, true},
+		{`(&(objectClass=person)(cn=jo*))`, false},
+		{`(cn=*cor*)`, true},
+		{`(cn=jesse)`, true},
+		{`(cn;lang-en=jesse)`, true},
+		{`(cn;lang-en=jesse coretta)`, false},
+		{`(name=coretta)`, true},
+		{`(name;lang-en=*)`, true},
+		{`(name;lang-de=*)`, false},
+		{`(description=*)`, true},
+		{`(description;lang-fr=bonjour)`, true},
+		{`(sn=*)`, true},
+		{`(mail=*)`, false},
+		{`(bogusType=*)`, false},
+		{`(!(bogusType=x))`, false},
+		{`(|(bogusType=x)(sn=coretta))`, true},
+		{`(&(bogusType=x)(sn=coretta))`, false},
+		{`(!(&(bogusType=x)(sn=nobody)))`, true},
+		{`(telephoneNumber=+15123150280)`, true},
+		{`(createTimestamp>=20231231000000Z)`, true},
+		{`(createTimestamp>=20240101000000Z)`, true},
+		{`(createTimestamp>=20240102000000Z)`, false},
+		{`(createTimestamp<=20240101000000Z)`, true},
+		{`(createTimestamp<=20231231000000Z)`, false},
+		{`(createTimestamp<=20240102000000Z)`, true},
+		{`(createTimestamp<=bogus)`, false},
+		{`(sn<=coretta)`, false},    // no ORDERING: Undefined
+		{`(!(sn<=coretta))`, false}, // no ORDERING: Undefined
+		{`(sn~=CORETTA)`, true},
+		{`(sn:=CORETTA)`, true},
+		{`(sn:caseExactMatch:=Coretta)`, true},
+		{`(sn:caseExactMatch:=coretta)`, false},
+		{`(sn:bogusMatch:=coretta)`, false},
+		{`(:caseIgnoreMatch:=coretta)`, true},
+		{`(:caseExactMatch:=jesse)`, true},
+		{`(:caseExactMatch:=People)`, false},
+		{`(:dn:caseIgnoreMatch:=people)`, true},
+		{`(ou:dn:=people)`, true},
+		{`(ou=people)`, false},
+		{`(o:dn:=people)`, false},
+		{`(dc:dn:caseExactMatch:=Example)`, false},
+		{`(uniqueMember=UID=jesse,DC=example,DC=com)`, true},
+		{`(&)`, true},
+		{`(|)`, false},
+		{`(!(&))`, false},
+	} {
+		got, err := sch.MatchFilter(tc.filter, dn, entry)
+		if err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+		} else if got != tc.want {
+			t.Errorf("%s[%d] failed: want %t for %q, got %t",
+				t.Name(), idx, tc.want, tc.filter, got)
+		}
+	}
+}
+
+func TestFilter_codecov(t *testing.T) {
+	var f Filter
+	_ = f.IsZero()
+	_ = f.Kind()
+	_ = f.Filters()
+	_ = f.Attribute()
+	_ = f.Value()
+	_ = f.String()
+
+	var sch Schema
+	if _, err := sch.MatchFilter(`(cn=x)`, ``, nil); !errors.Is(err, ErrNilReceiver) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilReceiver, err)
+	}
+
+	sch = NewSchema()
+	if _, err := sch.MatchFilter(f, ``, nil); !errors.Is(err, ErrNilInput) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilInput, err)
+	}
+	if _, err := sch.MatchFilter(1, ``, nil); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrInvalidType, err)
+	}
+	if _, err := sch.MatchFilter(`(cn=x`, ``, nil); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrInvalidFilter, err)
+	}
+
+	f, _ = ParseFilter(`(cn=j*)`)
+	if f.Attribute() != `cn` || f.Value() != `j*` {
+		t.Errorf("%s failed: unexpected substrings filter %s", t.Name(), f)
+	}
+
+	// built-in matchers are used absent the AssertionMatchers option,
+	// while items lacking a suitable matching rule are Undefined
+	entry := map[string][]string{`cn`: {`x`}, `jpegPhoto`: {`x`}}
+	if match, err := sch.MatchFilter(`(cn:=X)`, ``, entry); err != nil || !match {
+		t.Errorf("%s failed: unexpected result: %t, %v", t.Name(), match, err)
+	}
+	for _, raw := range []string{`(jpegPhoto=x)`, `(jpegPhoto:=x)`, `(!(jpegPhoto=x))`} {
+		if match, err := sch.MatchFilter(raw, ``, entry); err != nil || match {
+			t.Errorf("%s failed: unexpected result for %s: %t, %v", t.Name(), raw, match, err)
+		}
+	}
+	if match, _ := sch.MatchFilter(`(cn=*)`, ``, entry); !match {
+		t.Errorf("%s failed: presence filter did not match", t.Name())
+	}
+
+	// first component assertions are resolved, as are
+	// OIDs within the values of objectIdentifierMatch
+	entry = map[string][]string{
+		`objectClasses`:         {`( 2.5.6.6 NAME 'person' )`},
+		`supportedExtension`:    {`bogus value`},
+		`supportedFeatures`:     {`1.3.6.1.4.1.4203.1.5.1`},
+		`structuralObjectClass`: {`PERSON`},
+	}
+	for _, raw := range []string{
+		`(objectClasses=person)`,
+		`(structuralObjectClass=2.5.6.6)`,
+	} {
+		if match, err := sch.MatchFilter(raw, ``, entry); err != nil || !match {
+			t.Errorf("%s failed: unexpected result for %s: %t, %v", t.Name(), raw, match, err)
+		}
+	}
+	if match, _ := sch.MatchFilter(`(supportedExtension=1.2.3)`, ``, entry); match {
+		t.Errorf("%s failed: malformed OID matched", t.Name())
+	}
+}
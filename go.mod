@@ -4,6 +4,8 @@ require (
 	github.com/JesseCoretta/go-antlr4512 v1.0.9
 	github.com/JesseCoretta/go-shifty v1.0.1
 	github.com/JesseCoretta/go-stackage v1.0.5-0.20240811060306-352afc3a15a7
+	golang.org/x/text v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -30,18 +30,22 @@ import (
 var (
 	atoi   func(string) (int, error)           = strconv.Atoi
 	itoa   func(int) string                    = strconv.Itoa
+	fmtInt func(int64, int) string             = strconv.FormatInt
 	repAll func(string, string, string) string = strings.ReplaceAll
 	eq     func(string, string) bool           = strings.EqualFold
 	split  func(string, string) []string       = strings.Split
 	join   func([]string, string) string       = strings.Join
 	hasPfx func(string, string) bool           = strings.HasPrefix
 	hasSfx func(string, string) bool           = strings.HasSuffix
+	stridx func(string, string) int            = strings.Index
 	lc     func(string) string                 = strings.ToLower
 	uc     func(string) string                 = strings.ToUpper
 	trim   func(string, string) string         = strings.Trim
 	trimL  func(string, string) string         = strings.TrimLeft
 	trimR  func(string, string) string         = strings.TrimRight
 	trimS  func(string) string                 = strings.TrimSpace
+
+	fieldsFunc func(string, func(rune) bool) []string = strings.FieldsFunc
 )
 
 var (
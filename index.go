@@ -0,0 +1,280 @@
+package schemax
+
+/*
+index.go contains the hash index used to accelerate lookups within the
+definition collections of a Schema instance.
+*/
+
+import (
+	"sync"
+
+	"github.com/JesseCoretta/go-stackage"
+)
+
+/*
+collectionIndex implements a case-insensitive hash index of all numeric
+OIDs, rule IDs, names and (in the case of [LDAPSyntax] instances) space
+-stripped descriptions held by the definitions within a collection.
+
+Definitions pushed into the collection are indexed lazily upon the next
+lookup. Removal, replacement and renaming of member definitions mark the
+index dirty, leading to a complete rebuild upon the next lookup.
+
+Only the first definition to bear a given key is indexed, which mirrors
+the first-match behavior of a linear scan.
+
+Names pushed directly into the [QuotedDescriptorList] of a member are
+announced by way of the [Definition] to which the list is assigned.
+*/
+type collectionIndex struct {
+	mutex   *sync.RWMutex
+	n       int              // number of stack slices indexed
+	dirty   bool             // rebuild needed
+	keys    map[string]any   // lowercased key -> definition
+	members map[any]struct{} // indexed definitions
+}
+
+func newCollectionIndex() *collectionIndex {
+	return &collectionIndex{
+		mutex:   &sync.RWMutex{},
+		keys:    make(map[string]any),
+		members: make(map[any]struct{}),
+	}
+}
+
+/*
+collectionIndexOf returns the *collectionIndex instance associated with
+the input stack, or nil if the stack is not indexed.  Only collections
+which reside within a [Schema] are indexed.
+*/
+func collectionIndexOf(stk stackage.Stack) (idx *collectionIndex) {
+	if aux := stk.Auxiliary(); aux != nil {
+		idx, _ = aux[`index`].(*collectionIndex)
+	}
+
+	return
+}
+
+/*
+indexLookup returns the definition indexed by id within stk, alongside a
+Boolean value indicative of whether stk is indexed at all.  When indexed
+is false, the caller is expected to fall back to a linear scan.
+
+The match closure is used to confirm the candidate definition, guarding
+against stale entries which have not been announced to the index (e.g.:
+a name manipulated directly through a [QuotedDescriptorList]).
+*/
+func indexLookup(stk stackage.Stack, id string, match func(any) bool) (slice any, indexed bool) {
+	var idx *collectionIndex
+	if idx = collectionIndexOf(stk); idx == nil {
+		return
+	}
+
+	indexed = true
+	slice = idx.get(stk, lc(id), match)
+
+	return
+}
+
+func (r *collectionIndex) get(stk stackage.Stack, key string, match func(any) bool) (slice any) {
+	var found bool
+
+	// Fast path: a current index permits any number
+	// of concurrent lookups.
+	r.mutex.RLock()
+	if !r.dirty && r.n == stk.Len() {
+		if slice, found = r.keys[key]; !found || match(slice) {
+			r.mutex.RUnlock()
+			return
+		}
+	}
+	r.mutex.RUnlock()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.sync(stk)
+
+	slice, found = r.keys[key]
+	if found && !match(slice) {
+		// stale entry: rebuild and try again.
+		r.rebuild(stk)
+		if slice, found = r.keys[key]; found && !match(slice) {
+			slice = nil
+		}
+	}
+
+	return
+}
+
+/*
+sync brings the receiver up to date with stk.  Slices pushed since the
+last lookup are indexed incrementally, while a dirty receiver -- or one
+which has observed a shrinking stack -- is rebuilt completely.
+*/
+func (r *collectionIndex) sync(stk stackage.Stack) {
+	L := stk.Len()
+	if r.dirty || L < r.n {
+		r.rebuild(stk)
+		return
+	}
+
+	for ; r.n < L; r.n++ {
+		slice, _ := stk.Index(r.n)
+		r.add(slice)
+	}
+}
+
+func (r *collectionIndex) rebuild(stk stackage.Stack) {
+	r.keys = make(map[string]any, len(r.keys))
+	r.members = make(map[any]struct{}, len(r.members))
+	r.n = 0
+	r.dirty = false
+
+	for L := stk.Len(); r.n < L; r.n++ {
+		slice, _ := stk.Index(r.n)
+		r.add(slice)
+	}
+}
+
+func (r *collectionIndex) add(slice any) {
+	keys := indexKeys(slice)
+	if len(keys) == 0 {
+		return
+	}
+
+	r.members[slice] = struct{}{}
+	for _, key := range keys {
+		if _, found := r.keys[key]; !found {
+			r.keys[key] = slice
+		}
+	}
+}
+
+/*
+invalidate marks the receiver dirty if def is one of its members.
+*/
+func (r *collectionIndex) invalidate(def any) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, found := r.members[def]; found {
+		r.dirty = true
+	}
+}
+
+/*
+reset marks the receiver dirty unconditionally, such as following the
+removal of a stack slice.
+*/
+func (r *collectionIndex) reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.dirty = true
+}
+
+/*
+indexKeys returns the lowercased keys by which slice shall be indexed.
+*/
+func indexKeys(slice any) (keys []string) {
+	var names QuotedDescriptorList
+
+	switch tv := slice.(type) {
+	case LDAPSyntax:
+		if !tv.IsZero() {
+			keys = append(keys, lc(tv.lDAPSyntax.OID),
+				lc(repAll(tv.lDAPSyntax.Desc, ` `, ``)))
+		}
+		return
+	case MatchingRule:
+		if !tv.IsZero() {
+			keys, names = append(keys, tv.matchingRule.OID), tv.matchingRule.Name
+		}
+	case AttributeType:
+		if !tv.IsZero() {
+			keys, names = append(keys, tv.attributeType.OID), tv.attributeType.Name
+		}
+	case MatchingRuleUse:
+		if !tv.IsZero() {
+			keys, names = append(keys, tv.NumericOID()), tv.matchingRuleUse.Name
+		}
+	case ObjectClass:
+		if !tv.IsZero() {
+			keys, names = append(keys, tv.objectClass.OID), tv.objectClass.Name
+		}
+	case DITContentRule:
+		if !tv.IsZero() {
+			keys, names = append(keys, tv.NumericOID()), tv.dITContentRule.Name
+		}
+	case NameForm:
+		if !tv.IsZero() {
+			keys, names = append(keys, tv.nameForm.OID), tv.nameForm.Name
+		}
+	case DITStructureRule:
+		if !tv.IsZero() {
+			keys, names = append(keys, uitoa(tv.RuleID())), tv.dITStructureRule.Name
+		}
+	}
+
+	for i := 0; i < names.len(); i++ {
+		keys = append(keys, lc(names.index(i)))
+	}
+
+	return
+}
+
+/*
+reindex informs the relevant collection index that def -- which may or
+may not be a member of the receiver -- has been modified in a manner
+which affects its keys, such as a rename.
+*/
+func (r Schema) reindex(def Definition) {
+	if r.IsZero() {
+		return
+	}
+
+	defs := r.collectionOf(def)
+	if defs == nil {
+		return
+	}
+
+	if idx := collectionIndexOf(defs.cast()); idx != nil {
+		idx.invalidate(def)
+	}
+
+	// A MatchingRuleUse shares the NAME clause of
+	// its MatchingRule.
+	if _, ok := def.(MatchingRule); ok {
+		if idx := collectionIndexOf(r.MatchingRuleUses().cast()); idx != nil {
+			idx.reset()
+		}
+	}
+}
+
+/*
+collectionOf returns the collection of the receiver in which definitions
+of the same type as def reside, or nil if def is of an unknown type.
+*/
+func (r Schema) collectionOf(def Definition) (defs Definitions) {
+	switch def.(type) {
+	case LDAPSyntax:
+		defs = r.LDAPSyntaxes()
+	case MatchingRule:
+		defs = r.MatchingRules()
+	case AttributeType:
+		defs = r.AttributeTypes()
+	case MatchingRuleUse:
+		defs = r.MatchingRuleUses()
+	case ObjectClass:
+		defs = r.ObjectClasses()
+	case DITContentRule:
+		defs = r.DITContentRules()
+	case NameForm:
+		defs = r.NameForms()
+	case DITStructureRule:
+		defs = r.DITStructureRules()
+	}
+
+	return
+}
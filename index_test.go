@@ -0,0 +1,159 @@
+package schemax
+
+import (
+	"testing"
+)
+
+func TestCollectionIndex(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+
+	for idx, tc := range []struct {
+		id   string
+		want string
+	}{
+		{`cn`, `2.5.4.3`},
+		{`CN`, `2.5.4.3`},
+		{`commonName`, `2.5.4.3`},
+		{`COMMONNAME`, `2.5.4.3`},
+		{`2.5.4.3`, `2.5.4.3`},
+		{`cn;lang-en`, `2.5.4.3`},
+		{`nonExistentType`, ``},
+	} {
+		if got := sch.AttributeTypes().Get(tc.id).NumericOID(); got != tc.want {
+			t.Errorf("%s[%d] failed: want %q, got %q", t.Name(), idx, tc.want, got)
+		}
+	}
+
+	if ls := sch.LDAPSyntaxes().Get(`directorystring`); ls.NumericOID() != `1.3.6.1.4.1.1466.115.121.1.15` {
+		t.Errorf("%s failed: description lookup returned %q", t.Name(), ls.NumericOID())
+	}
+
+	// Push: definitions added after the initial index build
+	// must be found without any explicit refresh.
+	if err := sch.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.91.1
+		NAME 'indexedAttr'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	at := sch.AttributeTypes().Get(`IndexedAttr`)
+	if at.IsZero() {
+		t.Fatalf("%s failed: pushed definition not found", t.Name())
+	}
+
+	// Rename: a new name assigned to a member definition must
+	// be reflected by subsequent lookups.
+	at.SetName(`renamedAttr`)
+	if !sch.AttributeTypes().Contains(`renamedattr`) {
+		t.Errorf("%s failed: renamed definition not found", t.Name())
+	}
+
+	// Replace: names dropped by the replacement must no longer
+	// resolve, while names introduced must resolve.
+	rep := sch.NewAttributeType().
+		SetNumericOID(`1.3.6.1.4.1.56521.999.91.1`).
+		SetName(`replacedAttr`).
+		SetSyntax(`1.3.6.1.4.1.1466.115.121.1.15`).
+		SetStringer()
+	sch.Replace(rep)
+	if sch.AttributeTypes().Contains(`indexedAttr`) {
+		t.Errorf("%s failed: replaced name still found", t.Name())
+	} else if got := sch.AttributeTypes().Get(`replacedAttr`); got.NumericOID() != at.NumericOID() {
+		t.Errorf("%s failed: replacement name not found", t.Name())
+	}
+
+	// Remove: removed definitions must no longer resolve.
+	if err := sch.Remove(at); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if sch.Exists(at) || sch.AttributeTypes().Contains(`1.3.6.1.4.1.56521.999.91.1`) {
+		t.Errorf("%s failed: removed definition still found", t.Name())
+	}
+
+	if err := sch.ParseNameForm(`( 1.3.6.1.4.1.56521.999.91.2
+		NAME 'indexedForm'
+		OC account
+		MUST uid )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if err = sch.ParseDITStructureRule(`( 91
+		NAME 'indexedRule'
+		FORM indexedForm )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	for idx, id := range []any{91, uint(91), `91`, `INDEXEDRULE`} {
+		if ds := sch.DITStructureRules().Get(id); ds.RuleID() != 91 {
+			t.Errorf("%s[%d] failed: structure rule %v not found", t.Name(), idx, id)
+		}
+	}
+}
+
+func TestCollectionIndex_directName(t *testing.T) {
+	sch := NewSchema()
+
+	// A name pushed directly into the name list of a member
+	// definition is announced to the index by way of that
+	// definition.
+	cn := sch.AttributeTypes().Get(`cn`)
+	_ = sch.AttributeTypes().Contains(`cn`) // ensure a current index
+	if err := cn.Names().Push(`cnAlias`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	if !sch.AttributeTypes().Contains(`cnAlias`) {
+		t.Errorf("%s failed: directly pushed name not found", t.Name())
+	} else if got := sch.AttributeTypes().Get(`CNALIAS`); got.NumericOID() != `2.5.4.3` {
+		t.Errorf("%s failed: want %q, got %q", t.Name(), `2.5.4.3`, got.NumericOID())
+	}
+
+	oc := sch.ObjectClasses().Get(`person`)
+	_ = oc.Names().Push(`personAlias`)
+	if !sch.ObjectClasses().Contains(`personAlias`) {
+		t.Errorf("%s failed: directly pushed name not found", t.Name())
+	}
+
+	// A MatchingRuleUse shares the name list of its MatchingRule.
+	mr := sch.MatchingRules().Get(`caseIgnoreMatch`)
+	_ = sch.MatchingRuleUses().Contains(`caseIgnoreMatch`)
+	_ = mr.Names().Push(`caseIgnoreAlias`)
+	if !sch.MatchingRuleUses().Contains(`caseIgnoreAlias`) {
+		t.Errorf("%s failed: shared name not found", t.Name())
+	}
+}
+
+func TestCollectionIndex_codecov(t *testing.T) {
+	var ats AttributeTypes
+	_ = ats.Get(`cn`)
+
+	ats = NewAttributeTypes()
+	if collectionIndexOf(ats.cast()) != nil {
+		t.Errorf("%s failed: unexpected index for detached collection", t.Name())
+	}
+
+	_ = indexKeys(nil)
+	for _, def := range []Definition{
+		LDAPSyntax{},
+		MatchingRule{},
+		AttributeType{},
+		MatchingRuleUse{},
+		ObjectClass{},
+		DITContentRule{},
+		NameForm{},
+		DITStructureRule{},
+	} {
+		if keys := indexKeys(def); len(keys) != 0 {
+			t.Errorf("%s failed: unexpected keys for zero %s: %v", t.Name(), def.Type(), keys)
+		}
+	}
+
+	var sch Schema
+	sch.reindex(AttributeType{})
+
+	sch = NewSchema()
+	sch.reindex(nil)
+
+	// A name removed without notice must not
+	// produce a stale hit.
+	oc := sch.ObjectClasses().Get(`account`)
+	oc.objectClass.Name.cast().Reset()
+	if got := sch.ObjectClasses().Get(`account`); !got.IsZero() {
+		t.Errorf("%s failed: stale index entry returned", t.Name())
+	}
+}
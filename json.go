@@ -0,0 +1,812 @@
+package schemax
+
+/*
+json.go contains JSON encoding and decoding facilities for Schema
+instances, definition collections and individual definitions.
+*/
+
+import "encoding/json"
+
+/*
+schemaJSON is the JSON object layout of a [Schema] instance. Collections
+are decoded in the order in which they appear below, regardless of their
+order within the input.
+*/
+type schemaJSON struct {
+	DN                string            `json:"dn,omitempty"`
+	Macros            map[string]string `json:"macros,omitempty"`
+	LDAPSyntaxes      json.RawMessage   `json:"ldapSyntaxes,omitempty"`
+	MatchingRules     json.RawMessage   `json:"matchingRules,omitempty"`
+	AttributeTypes    json.RawMessage   `json:"attributeTypes,omitempty"`
+	MatchingRuleUses  json.RawMessage   `json:"matchingRuleUses,omitempty"`
+	ObjectClasses     json.RawMessage   `json:"objectClasses,omitempty"`
+	DITContentRules   json.RawMessage   `json:"dITContentRules,omitempty"`
+	NameForms         json.RawMessage   `json:"nameForms,omitempty"`
+	DITStructureRules json.RawMessage   `json:"dITStructureRules,omitempty"`
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "dn": "cn=schema",
+	  "macros": { "<name>": "<numeric OID>", ... },
+	  "ldapSyntaxes": [ ... ],
+	  "matchingRules": [ ... ],
+	  "attributeTypes": [ ... ],
+	  "matchingRuleUses": [ ... ],
+	  "objectClasses": [ ... ],
+	  "dITContentRules": [ ... ],
+	  "nameForms": [ ... ],
+	  "dITStructureRules": [ ... ]
+	}
+
+Empty collections are omitted.  See the MarshalJSON method of each
+[Definition] type for details regarding the layout of its objects.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r Schema) MarshalJSON() (b []byte, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	sch := schemaJSON{DN: r.DN()}
+
+	macros := r.Macros()
+	if keys := macros.Keys(); len(keys) > 0 {
+		sch.Macros = make(map[string]string, len(keys))
+		for _, k := range keys {
+			sch.Macros[k], _ = macros.Resolve(k)
+		}
+	}
+
+	for _, pair := range []struct {
+		defs Definitions
+		dest *json.RawMessage
+	}{
+		{r.LDAPSyntaxes(), &sch.LDAPSyntaxes},
+		{r.MatchingRules(), &sch.MatchingRules},
+		{r.AttributeTypes(), &sch.AttributeTypes},
+		{r.MatchingRuleUses(), &sch.MatchingRuleUses},
+		{r.ObjectClasses(), &sch.ObjectClasses},
+		{r.DITContentRules(), &sch.DITContentRules},
+		{r.NameForms(), &sch.NameForms},
+		{r.DITStructureRules(), &sch.DITStructureRules},
+	} {
+		if pair.defs.Len() == 0 {
+			continue
+		}
+		if *pair.dest, err = pair.defs.(json.Marshaler).MarshalJSON(); err != nil {
+			return
+		}
+	}
+
+	b, err = json.Marshal(sch)
+
+	return
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be laid out in the manner described by [Schema.MarshalJSON] -- into
+the receiver instance.
+
+Definitions are decoded in order of type precedence, i.e.: syntaxes before
+matching rules, matching rules before attribute types, and so on.  Within
+each type, definitions are decoded in order of dependency, such that
+superior definitions (e.g.: a supertype) precede their subordinates, as
+with [Schema.ParseYAML].  Circular or unsatisfiable superior references
+result in an error.  Each is
+marshaled through the relevant Marshal method, meaning all references are
+resolved in the same manner as with [DefinitionMap] input.  Decoding ceases
+upon the first error encountered.
+
+The receiver instance must be initialized prior to use of this method,
+e.g.: through the [NewEmptySchema] function.  Any definitions bearing the
+same identity as a definition already present within the receiver instance
+shall result in an error.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r Schema) UnmarshalJSON(b []byte) (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	var sch schemaJSON
+	if err = json.Unmarshal(b, &sch); err != nil {
+		return
+	}
+
+	if len(sch.DN) > 0 {
+		r.SetDN(sch.DN)
+	}
+
+	for k, v := range sch.Macros {
+		r.Macros().Set(k, v)
+	}
+
+	for _, pair := range []struct {
+		defs Definitions
+		raw  json.RawMessage
+	}{
+		{r.LDAPSyntaxes(), sch.LDAPSyntaxes},
+		{r.MatchingRules(), sch.MatchingRules},
+		{r.AttributeTypes(), sch.AttributeTypes},
+		{r.MatchingRuleUses(), sch.MatchingRuleUses},
+		{r.ObjectClasses(), sch.ObjectClasses},
+		{r.DITContentRules(), sch.DITContentRules},
+		{r.NameForms(), sch.NameForms},
+		{r.DITStructureRules(), sch.DITStructureRules},
+	} {
+		if len(pair.raw) == 0 {
+			continue
+		}
+		if err = pair.defs.(json.Unmarshaler).UnmarshalJSON(pair.raw); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+/*
+collectionSchema returns the [Schema] instance with which defs is
+associated.  Only collections initialized through a [Schema] instance
+(e.g.: [Schema.AttributeTypes]) bear such an association.
+*/
+func collectionSchema(defs Definitions) (sch Schema) {
+	if !defs.IsZero() {
+		headerMutex.RLock()
+		defer headerMutex.RUnlock()
+
+		sch, _ = defs.cast().Auxiliary()[`schema`].(Schema)
+	}
+
+	return
+}
+
+/*
+marshalCollectionJSON returns the JSON encoding of defs in the form of an
+array, each member of which is encoded through its own MarshalJSON method.
+*/
+func marshalCollectionJSON(defs Definitions) (b []byte, err error) {
+	raws := make([]json.RawMessage, 0)
+	for _, def := range collectionDefinitions(defs) {
+		var raw []byte
+		if raw, err = def.(json.Marshaler).MarshalJSON(); err != nil {
+			return
+		}
+		raws = append(raws, raw)
+	}
+
+	b, err = json.Marshal(raws)
+
+	return
+}
+
+/*
+unmarshalCollectionJSON decodes b, which must be a JSON array, into
+individual definitions by way of the newDef closure, pushing each into
+defs.  Definitions are pushed in order of dependency, such that superior
+definitions (e.g.: a supertype) precede their subordinates regardless of
+their order within b.  Decoding ceases upon the first error encountered,
+including any attempt to import a duplicate definition.
+*/
+func unmarshalCollectionJSON(defs Definitions, b []byte, newDef func(Schema) Definition) (err error) {
+	sch := collectionSchema(defs)
+	if sch.IsZero() {
+		err = ErrNilSchemaRef
+		return
+	}
+
+	var raws []json.RawMessage
+	if err = json.Unmarshal(b, &raws); err != nil {
+		return
+	}
+
+	typ := newDef(sch).Type()
+	layouts := make([]definitionLayout, len(raws))
+	for i := 0; i < len(raws); i++ {
+		layouts[i] = newLayout(typ)
+		if err = json.Unmarshal(raws[i], layouts[i]); err != nil {
+			return
+		}
+	}
+
+	order, errs := sch.layoutOrder(typ, layouts)
+	if err = firstError(errs); err != nil {
+		return
+	}
+
+	for i := 0; i < len(order) && err == nil; i++ {
+		def := newDef(sch)
+		if err = marshalLayout(def, layouts[order[i]]); err == nil {
+			if sch.Exists(def) {
+				err = wraperr(ErrDuplicateDef, `: `+def.Type()+` `+defIdentity(def))
+			} else {
+				err = sch.pushDefinition(def, ParseSource)
+			}
+		}
+	}
+
+	return
+}
+
+/*
+unmarshalDefinitionJSON decodes b into the object layout l prior to its
+submission to def.  See the marshalLayout function for details.
+*/
+func unmarshalDefinitionJSON(def Definition, b []byte, l definitionLayout) (err error) {
+	if def.IsZero() {
+		err = ErrNilReceiver
+	} else if err = json.Unmarshal(b, l); err == nil {
+		err = marshalLayout(def, l)
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "type": "ldapSyntax",
+	  "oid": "<numeric OID>",
+	  "desc": "<description>",
+	  "extensions": { "X-<name>": [ "<value>", ... ], ... }
+	}
+
+Empty or false-valued optional fields are omitted.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r LDAPSyntax) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return nil, ErrNilReceiver
+	}
+
+	return json.Marshal(r.layout())
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be laid out in the manner described by the [LDAPSyntax.MarshalJSON] method
+-- into the receiver instance by way of the [LDAPSyntax.Marshal] method.
+
+The receiver instance must be initialized prior to use of this method
+using the [Schema.NewLDAPSyntax] method.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r LDAPSyntax) UnmarshalJSON(b []byte) error {
+	return unmarshalDefinitionJSON(r, b, &lDAPSyntaxLayout{})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance in the form
+of an array, alongside an error.  Each member is encoded through the
+[LDAPSyntax.MarshalJSON] method.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r LDAPSyntaxes) MarshalJSON() ([]byte, error) {
+	return marshalCollectionJSON(r)
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be an array of objects, each laid out in the manner described by the
+[LDAPSyntax.MarshalJSON] method -- into the receiver instance.
+
+The receiver instance must have been obtained through the [Schema.LDAPSyntaxes]
+method, as the associated [Schema] is used to initialize each LDAPSyntax.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r LDAPSyntaxes) UnmarshalJSON(b []byte) error {
+	return unmarshalCollectionJSON(r, b, func(sch Schema) Definition {
+		return sch.NewLDAPSyntax()
+	})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "type": "matchingRule",
+	  "oid": "<numeric OID>",
+	  "names": [ "<name>", ... ],
+	  "desc": "<description>",
+	  "obsolete": false,
+	  "syntax": "<numeric OID>",
+	  "extensions": { "X-<name>": [ "<value>", ... ], ... }
+	}
+
+Empty or false-valued optional fields are omitted.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r MatchingRule) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return nil, ErrNilReceiver
+	}
+
+	return json.Marshal(r.layout())
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be laid out in the manner described by the [MatchingRule.MarshalJSON] method
+-- into the receiver instance by way of the [MatchingRule.Marshal] method.
+
+The receiver instance must be initialized prior to use of this method
+using the [Schema.NewMatchingRule] method.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r MatchingRule) UnmarshalJSON(b []byte) error {
+	return unmarshalDefinitionJSON(r, b, &matchingRuleLayout{})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance in the form
+of an array, alongside an error.  Each member is encoded through the
+[MatchingRule.MarshalJSON] method.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r MatchingRules) MarshalJSON() ([]byte, error) {
+	return marshalCollectionJSON(r)
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be an array of objects, each laid out in the manner described by the
+[MatchingRule.MarshalJSON] method -- into the receiver instance.
+
+The receiver instance must have been obtained through the [Schema.MatchingRules]
+method, as the associated [Schema] is used to initialize each MatchingRule.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r MatchingRules) UnmarshalJSON(b []byte) error {
+	return unmarshalCollectionJSON(r, b, func(sch Schema) Definition {
+		return sch.NewMatchingRule()
+	})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "type": "attributeType",
+	  "oid": "<numeric OID>",
+	  "names": [ "<name>", ... ],
+	  "desc": "<description>",
+	  "obsolete": false,
+	  "sup": "<OID>",
+	  "equality": "<OID>",
+	  "ordering": "<OID>",
+	  "substr": "<OID>",
+	  "syntax": "<numeric OID>",
+	  "mub": 0,
+	  "singleValue": false,
+	  "collective": false,
+	  "noUserModification": false,
+	  "usage": "<usage>",
+	  "extensions": { "X-<name>": [ "<value>", ... ], ... }
+	}
+
+Empty or false-valued optional fields are omitted.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r AttributeType) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return nil, ErrNilReceiver
+	}
+
+	return json.Marshal(r.layout())
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be laid out in the manner described by the [AttributeType.MarshalJSON] method
+-- into the receiver instance by way of the [AttributeType.Marshal] method.
+
+The receiver instance must be initialized prior to use of this method
+using the [Schema.NewAttributeType] method.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r AttributeType) UnmarshalJSON(b []byte) error {
+	return unmarshalDefinitionJSON(r, b, &attributeTypeLayout{})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance in the form
+of an array, alongside an error.  Each member is encoded through the
+[AttributeType.MarshalJSON] method.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r AttributeTypes) MarshalJSON() ([]byte, error) {
+	return marshalCollectionJSON(r)
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be an array of objects, each laid out in the manner described by the
+[AttributeType.MarshalJSON] method -- into the receiver instance.
+
+The receiver instance must have been obtained through the [Schema.AttributeTypes]
+method, as the associated [Schema] is used to initialize each AttributeType.
+
+Definitions are imported in order of dependency, such that supertypes
+precede their subtypes regardless of their order within b.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r AttributeTypes) UnmarshalJSON(b []byte) error {
+	return unmarshalCollectionJSON(r, b, func(sch Schema) Definition {
+		return sch.NewAttributeType()
+	})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "type": "matchingRuleUse",
+	  "oid": "<numeric OID>",
+	  "names": [ "<name>", ... ],
+	  "desc": "<description>",
+	  "obsolete": false,
+	  "applies": [ "<OID>", ... ],
+	  "extensions": { "X-<name>": [ "<value>", ... ], ... }
+	}
+
+Empty or false-valued optional fields are omitted.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r MatchingRuleUse) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return nil, ErrNilReceiver
+	}
+
+	return json.Marshal(r.layout())
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be laid out in the manner described by the [MatchingRuleUse.MarshalJSON] method
+-- into the receiver instance by way of the [MatchingRuleUse.Marshal] method.
+
+The receiver instance must be initialized prior to use of this method
+using the [Schema.NewMatchingRuleUse] method.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r MatchingRuleUse) UnmarshalJSON(b []byte) error {
+	return unmarshalDefinitionJSON(r, b, &matchingRuleUseLayout{})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance in the form
+of an array, alongside an error.  Each member is encoded through the
+[MatchingRuleUse.MarshalJSON] method.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r MatchingRuleUses) MarshalJSON() ([]byte, error) {
+	return marshalCollectionJSON(r)
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be an array of objects, each laid out in the manner described by the
+[MatchingRuleUse.MarshalJSON] method -- into the receiver instance.
+
+The receiver instance must have been obtained through the [Schema.MatchingRuleUses]
+method, as the associated [Schema] is used to initialize each MatchingRuleUse.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r MatchingRuleUses) UnmarshalJSON(b []byte) error {
+	return unmarshalCollectionJSON(r, b, func(sch Schema) Definition {
+		return sch.NewMatchingRuleUse()
+	})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "type": "objectClass",
+	  "oid": "<numeric OID>",
+	  "names": [ "<name>", ... ],
+	  "desc": "<description>",
+	  "obsolete": false,
+	  "sup": [ "<OID>", ... ],
+	  "kind": "STRUCTURAL",
+	  "must": [ "<OID>", ... ],
+	  "may": [ "<OID>", ... ],
+	  "extensions": { "X-<name>": [ "<value>", ... ], ... }
+	}
+
+Empty or false-valued optional fields are omitted.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r ObjectClass) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return nil, ErrNilReceiver
+	}
+
+	return json.Marshal(r.layout())
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be laid out in the manner described by the [ObjectClass.MarshalJSON] method
+-- into the receiver instance by way of the [ObjectClass.Marshal] method.
+
+The receiver instance must be initialized prior to use of this method
+using the [Schema.NewObjectClass] method.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r ObjectClass) UnmarshalJSON(b []byte) error {
+	return unmarshalDefinitionJSON(r, b, &objectClassLayout{})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance in the form
+of an array, alongside an error.  Each member is encoded through the
+[ObjectClass.MarshalJSON] method.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r ObjectClasses) MarshalJSON() ([]byte, error) {
+	return marshalCollectionJSON(r)
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be an array of objects, each laid out in the manner described by the
+[ObjectClass.MarshalJSON] method -- into the receiver instance.
+
+The receiver instance must have been obtained through the [Schema.ObjectClasses]
+method, as the associated [Schema] is used to initialize each ObjectClass.
+
+Definitions are imported in order of dependency, such that superclasses
+precede their subclasses regardless of their order within b.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r ObjectClasses) UnmarshalJSON(b []byte) error {
+	return unmarshalCollectionJSON(r, b, func(sch Schema) Definition {
+		return sch.NewObjectClass()
+	})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "type": "dITContentRule",
+	  "oid": "<numeric OID>",
+	  "names": [ "<name>", ... ],
+	  "desc": "<description>",
+	  "obsolete": false,
+	  "aux": [ "<OID>", ... ],
+	  "must": [ "<OID>", ... ],
+	  "may": [ "<OID>", ... ],
+	  "not": [ "<OID>", ... ],
+	  "extensions": { "X-<name>": [ "<value>", ... ], ... }
+	}
+
+Empty or false-valued optional fields are omitted.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r DITContentRule) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return nil, ErrNilReceiver
+	}
+
+	return json.Marshal(r.layout())
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be laid out in the manner described by the [DITContentRule.MarshalJSON] method
+-- into the receiver instance by way of the [DITContentRule.Marshal] method.
+
+The receiver instance must be initialized prior to use of this method
+using the [Schema.NewDITContentRule] method.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r DITContentRule) UnmarshalJSON(b []byte) error {
+	return unmarshalDefinitionJSON(r, b, &dITContentRuleLayout{})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance in the form
+of an array, alongside an error.  Each member is encoded through the
+[DITContentRule.MarshalJSON] method.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r DITContentRules) MarshalJSON() ([]byte, error) {
+	return marshalCollectionJSON(r)
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be an array of objects, each laid out in the manner described by the
+[DITContentRule.MarshalJSON] method -- into the receiver instance.
+
+The receiver instance must have been obtained through the [Schema.DITContentRules]
+method, as the associated [Schema] is used to initialize each DITContentRule.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r DITContentRules) UnmarshalJSON(b []byte) error {
+	return unmarshalCollectionJSON(r, b, func(sch Schema) Definition {
+		return sch.NewDITContentRule()
+	})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "type": "nameForm",
+	  "oid": "<numeric OID>",
+	  "names": [ "<name>", ... ],
+	  "desc": "<description>",
+	  "obsolete": false,
+	  "oc": "<OID>",
+	  "must": [ "<OID>", ... ],
+	  "may": [ "<OID>", ... ],
+	  "extensions": { "X-<name>": [ "<value>", ... ], ... }
+	}
+
+Empty or false-valued optional fields are omitted.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r NameForm) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return nil, ErrNilReceiver
+	}
+
+	return json.Marshal(r.layout())
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be laid out in the manner described by the [NameForm.MarshalJSON] method
+-- into the receiver instance by way of the [NameForm.Marshal] method.
+
+The receiver instance must be initialized prior to use of this method
+using the [Schema.NewNameForm] method.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r NameForm) UnmarshalJSON(b []byte) error {
+	return unmarshalDefinitionJSON(r, b, &nameFormLayout{})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance in the form
+of an array, alongside an error.  Each member is encoded through the
+[NameForm.MarshalJSON] method.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r NameForms) MarshalJSON() ([]byte, error) {
+	return marshalCollectionJSON(r)
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be an array of objects, each laid out in the manner described by the
+[NameForm.MarshalJSON] method -- into the receiver instance.
+
+The receiver instance must have been obtained through the [Schema.NameForms]
+method, as the associated [Schema] is used to initialize each NameForm.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r NameForms) UnmarshalJSON(b []byte) error {
+	return unmarshalCollectionJSON(r, b, func(sch Schema) Definition {
+		return sch.NewNameForm()
+	})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "type": "dITStructureRule",
+	  "ruleid": 0,
+	  "names": [ "<name>", ... ],
+	  "desc": "<description>",
+	  "obsolete": false,
+	  "form": "<OID>",
+	  "sup": [ 0, ... ],
+	  "extensions": { "X-<name>": [ "<value>", ... ], ... }
+	}
+
+Empty or false-valued optional fields are omitted.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r DITStructureRule) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return nil, ErrNilReceiver
+	}
+
+	return json.Marshal(r.layout())
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be laid out in the manner described by the [DITStructureRule.MarshalJSON] method
+-- into the receiver instance by way of the [DITStructureRule.Marshal] method.
+
+The receiver instance must be initialized prior to use of this method
+using the [Schema.NewDITStructureRule] method.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r DITStructureRule) UnmarshalJSON(b []byte) error {
+	return unmarshalDefinitionJSON(r, b, &dITStructureRuleLayout{})
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance in the form
+of an array, alongside an error.  Each member is encoded through the
+[DITStructureRule.MarshalJSON] method.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r DITStructureRules) MarshalJSON() ([]byte, error) {
+	return marshalCollectionJSON(r)
+}
+
+/*
+UnmarshalJSON returns an error following an attempt to decode b -- which
+must be an array of objects, each laid out in the manner described by the
+[DITStructureRule.MarshalJSON] method -- into the receiver instance.
+
+The receiver instance must have been obtained through the [Schema.DITStructureRules]
+method, as the associated [Schema] is used to initialize each DITStructureRule.
+
+Definitions are imported in order of dependency, such that superior
+rules precede their subordinate rules regardless of their order within b.
+
+This method satisfies the [encoding/json.Unmarshaler] interface.
+*/
+func (r DITStructureRules) UnmarshalJSON(b []byte) error {
+	return unmarshalCollectionJSON(r, b, func(sch Schema) Definition {
+		return sch.NewDITStructureRule()
+	})
+}
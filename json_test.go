@@ -0,0 +1,206 @@
+package schemax
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the JSON encoding of an [AttributeType].
+*/
+func ExampleAttributeType_MarshalJSON() {
+	sch := NewSchema()
+
+	b, err := json.Marshal(sch.AttributeTypes().Get(`cn`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(string(b))
+	// Output: {"type":"attributeType","oid":"2.5.4.3","names":["cn","commonName"],"desc":"RFC4519: common name(s) for which the entity is known by","sup":"name","extensions":{"X-ORIGIN":["RFC4519"]}}
+}
+
+/*
+This example demonstrates the decoding of a JSON object into a new
+[ObjectClass], the references of which are resolved using the
+associated [Schema].
+*/
+func ExampleObjectClass_UnmarshalJSON() {
+	sch := NewSchema()
+
+	oc := sch.NewObjectClass()
+	err := json.Unmarshal([]byte(`{
+		"type": "objectClass",
+		"oid": "1.3.6.1.4.1.56521.999.88.1",
+		"names": ["fakeClass"],
+		"sup": ["top"],
+		"kind": "AUXILIARY",
+		"may": ["cn", "2.5.4.4"]
+	}`), &oc)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(oc)
+	// Output: ( 1.3.6.1.4.1.56521.999.88.1 NAME 'fakeClass' SUP top AUXILIARY MAY ( cn $ sn ) )
+}
+
+func TestSchema_JSON(t *testing.T) {
+	sch := NewSchema()
+	sch.Macros().Set(`fakeMacro`, `1.3.6.1.4.1.56521.999`)
+	if err := sch.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.88.2
+	NAME 'fakeMUBAttr'
+	DESC 'Fake attribute'
+	EQUALITY caseIgnoreMatch
+	SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{64}
+	SINGLE-VALUE
+	USAGE directoryOperation
+	X-ORIGIN 'Testing' )
+
+dITContentRule ( 2.5.6.6
+	NAME 'personContentRule'
+	AUX simpleSecurityObject
+	NOT telephoneNumber )`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	b, err := json.Marshal(sch)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	imported := NewEmptySchema()
+	if err = json.Unmarshal(b, &imported); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if diff := sch.Diff(imported); !diff.IsZero() {
+		t.Errorf("%s failed: unexpected differences:\n%s", t.Name(), diff)
+		return
+	}
+
+	if got, want := imported.Counters(), sch.Counters(); got != want {
+		t.Errorf("%s failed: counters mismatch; want %v, got %v", t.Name(), want, got)
+		return
+	}
+
+	if res, _ := imported.Macros().Resolve(`fakeMacro`); res != `1.3.6.1.4.1.56521.999` {
+		t.Errorf("%s failed: macros not imported", t.Name())
+		return
+	}
+
+	if mub := imported.AttributeTypes().Get(`fakeMUBAttr`).MinimumUpperBounds(); mub != 64 {
+		t.Errorf("%s failed: want MUB 64, got %d", t.Name(), mub)
+		return
+	}
+}
+
+func TestSchema_UnmarshalJSON_dependencyOrder(t *testing.T) {
+	b := []byte(`{
+	"attributeTypes": [
+		{"oid": "1.3.6.1.4.1.56521.999.87.2", "names": ["fakeSubAttr"], "sup": "fakeAttr"},
+		{"oid": "1.3.6.1.4.1.56521.999.87.1", "names": ["fakeAttr"], "sup": "name"}
+	],
+	"objectClasses": [
+		{"oid": "1.3.6.1.4.1.56521.999.87.4", "names": ["fakeSubClass"], "sup": ["fakeClass"], "kind": "AUXILIARY"},
+		{"oid": "1.3.6.1.4.1.56521.999.87.3", "names": ["fakeClass"], "sup": ["top"], "kind": "AUXILIARY", "may": ["fakeSubAttr"]}
+	]
+}`)
+
+	sch := NewSchema()
+	if err := json.Unmarshal(b, &sch); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if sup := sch.AttributeTypes().Get(`fakeSubAttr`).SuperType(); sup.OID() != `fakeAttr` {
+		t.Errorf("%s failed: unexpected supertype %v", t.Name(), sup)
+		return
+	} else if !sch.ObjectClasses().Get(`fakeSubClass`).SuperClasses().Contains(`fakeClass`) {
+		t.Errorf("%s failed: superclass not resolved", t.Name())
+		return
+	}
+
+	// circular references cannot be satisfied
+	err := NewSchema().AttributeTypes().UnmarshalJSON([]byte(`[
+		{"oid": "1.3.6.1.4.1.56521.999.87.5", "names": ["fakeAttrA"], "sup": "fakeAttrB"},
+		{"oid": "1.3.6.1.4.1.56521.999.87.6", "names": ["fakeAttrB"], "sup": "fakeAttrA"}
+	]`))
+	if !errors.Is(err, ErrCircularDependency) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrCircularDependency, err)
+		return
+	}
+}
+
+func TestJSON_codecov(t *testing.T) {
+	var sch Schema
+	if _, err := sch.MarshalJSON(); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+	if err := sch.UnmarshalJSON([]byte(`{}`)); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	sch = NewSchema()
+	if err := sch.UnmarshalJSON([]byte(`{`)); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	// duplicate definitions must not be imported
+	if err := sch.UnmarshalJSON([]byte(`{"attributeTypes":[{"oid":"2.5.4.3","names":["cn"],"sup":"name"}]}`)); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	// collections not obtained via a Schema cannot decode
+	if err := NewAttributeTypes().UnmarshalJSON([]byte(`[]`)); !errors.Is(err, ErrNilSchemaRef) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilSchemaRef, err)
+	}
+	if err := sch.AttributeTypes().UnmarshalJSON([]byte(`{`)); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	// mismatched type
+	if err := sch.NewAttributeType().UnmarshalJSON([]byte(`{"type":"objectClass"}`)); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrInvalidType, err)
+	}
+
+	for _, def := range []Definition{
+		LDAPSyntax{},
+		MatchingRule{},
+		AttributeType{},
+		MatchingRuleUse{},
+		ObjectClass{},
+		DITContentRule{},
+		NameForm{},
+		DITStructureRule{},
+	} {
+		if _, err := def.(json.Marshaler).MarshalJSON(); err == nil {
+			t.Errorf("%s failed: expected error, got nil", t.Name())
+		}
+		if err := def.(json.Unmarshaler).UnmarshalJSON([]byte(`{}`)); err == nil {
+			t.Errorf("%s failed: expected error, got nil", t.Name())
+		}
+	}
+
+	for _, defs := range []Definitions{
+		NewLDAPSyntaxes(),
+		NewMatchingRules(),
+		NewMatchingRuleUses(),
+		NewObjectClasses(),
+		NewDITContentRules(),
+		NewNameForms(),
+		NewDITStructureRules(),
+	} {
+		if b, err := defs.(json.Marshaler).MarshalJSON(); err != nil || string(b) != `[]` {
+			t.Errorf("%s failed: want [], got %s (%v)", t.Name(), b, err)
+		}
+		_ = defs.(json.Unmarshaler).UnmarshalJSON([]byte(`[]`))
+	}
+}
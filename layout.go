@@ -0,0 +1,603 @@
+package schemax
+
+/*
+layout.go contains the object layouts shared by the JSON and YAML encodings
+of definitions, as well as the means of converting between definitions and
+their layouts.
+*/
+
+/*
+definitionLayout is satisfied by the object layout types of all definition
+types.
+*/
+type definitionLayout interface {
+	// layoutType returns the value of the "type" field, if set.
+	layoutType() string
+
+	// definitionMap returns the DefinitionMap equivalent of the
+	// receiver, suitable for submission to a Marshal method.
+	definitionMap() DefinitionMap
+}
+
+/*
+lDAPSyntaxLayout is the object layout of an [LDAPSyntax].  See the
+[LDAPSyntax.MarshalJSON] method for details.
+*/
+type lDAPSyntaxLayout struct {
+	Type       string              `json:"type" yaml:"type"`
+	OID        string              `json:"oid" yaml:"oid"`
+	Desc       string              `json:"desc,omitempty" yaml:"desc,omitempty"`
+	Extensions map[string][]string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+/*
+matchingRuleLayout is the object layout of a [MatchingRule].  See the
+[MatchingRule.MarshalJSON] method for details.
+*/
+type matchingRuleLayout struct {
+	Type       string              `json:"type" yaml:"type"`
+	OID        string              `json:"oid" yaml:"oid"`
+	Names      []string            `json:"names,omitempty" yaml:"names,omitempty"`
+	Desc       string              `json:"desc,omitempty" yaml:"desc,omitempty"`
+	Obsolete   bool                `json:"obsolete,omitempty" yaml:"obsolete,omitempty"`
+	Syntax     string              `json:"syntax" yaml:"syntax"`
+	Extensions map[string][]string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+/*
+attributeTypeLayout is the object layout of an [AttributeType].  See the
+[AttributeType.MarshalJSON] method for details.
+*/
+type attributeTypeLayout struct {
+	Type        string              `json:"type" yaml:"type"`
+	OID         string              `json:"oid" yaml:"oid"`
+	Names       []string            `json:"names,omitempty" yaml:"names,omitempty"`
+	Desc        string              `json:"desc,omitempty" yaml:"desc,omitempty"`
+	Obsolete    bool                `json:"obsolete,omitempty" yaml:"obsolete,omitempty"`
+	Sup         string              `json:"sup,omitempty" yaml:"sup,omitempty"`
+	Equality    string              `json:"equality,omitempty" yaml:"equality,omitempty"`
+	Ordering    string              `json:"ordering,omitempty" yaml:"ordering,omitempty"`
+	Substr      string              `json:"substr,omitempty" yaml:"substr,omitempty"`
+	Syntax      string              `json:"syntax,omitempty" yaml:"syntax,omitempty"`
+	MUB         uint                `json:"mub,omitempty" yaml:"mub,omitempty"`
+	SingleValue bool                `json:"singleValue,omitempty" yaml:"singleValue,omitempty"`
+	Collective  bool                `json:"collective,omitempty" yaml:"collective,omitempty"`
+	NoUserMod   bool                `json:"noUserModification,omitempty" yaml:"noUserModification,omitempty"`
+	Usage       string              `json:"usage,omitempty" yaml:"usage,omitempty"`
+	Extensions  map[string][]string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+/*
+matchingRuleUseLayout is the object layout of a [MatchingRuleUse].  See the
+[MatchingRuleUse.MarshalJSON] method for details.
+*/
+type matchingRuleUseLayout struct {
+	Type       string              `json:"type" yaml:"type"`
+	OID        string              `json:"oid" yaml:"oid"`
+	Names      []string            `json:"names,omitempty" yaml:"names,omitempty"`
+	Desc       string              `json:"desc,omitempty" yaml:"desc,omitempty"`
+	Obsolete   bool                `json:"obsolete,omitempty" yaml:"obsolete,omitempty"`
+	Applies    []string            `json:"applies" yaml:"applies"`
+	Extensions map[string][]string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+/*
+objectClassLayout is the object layout of an [ObjectClass].  See the
+[ObjectClass.MarshalJSON] method for details.
+*/
+type objectClassLayout struct {
+	Type       string              `json:"type" yaml:"type"`
+	OID        string              `json:"oid" yaml:"oid"`
+	Names      []string            `json:"names,omitempty" yaml:"names,omitempty"`
+	Desc       string              `json:"desc,omitempty" yaml:"desc,omitempty"`
+	Obsolete   bool                `json:"obsolete,omitempty" yaml:"obsolete,omitempty"`
+	Sup        []string            `json:"sup,omitempty" yaml:"sup,omitempty"`
+	Kind       string              `json:"kind" yaml:"kind"`
+	Must       []string            `json:"must,omitempty" yaml:"must,omitempty"`
+	May        []string            `json:"may,omitempty" yaml:"may,omitempty"`
+	Extensions map[string][]string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+/*
+dITContentRuleLayout is the object layout of a [DITContentRule].  See the
+[DITContentRule.MarshalJSON] method for details.
+*/
+type dITContentRuleLayout struct {
+	Type       string              `json:"type" yaml:"type"`
+	OID        string              `json:"oid" yaml:"oid"`
+	Names      []string            `json:"names,omitempty" yaml:"names,omitempty"`
+	Desc       string              `json:"desc,omitempty" yaml:"desc,omitempty"`
+	Obsolete   bool                `json:"obsolete,omitempty" yaml:"obsolete,omitempty"`
+	Aux        []string            `json:"aux,omitempty" yaml:"aux,omitempty"`
+	Must       []string            `json:"must,omitempty" yaml:"must,omitempty"`
+	May        []string            `json:"may,omitempty" yaml:"may,omitempty"`
+	Not        []string            `json:"not,omitempty" yaml:"not,omitempty"`
+	Extensions map[string][]string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+/*
+nameFormLayout is the object layout of a [NameForm].  See the
+[NameForm.MarshalJSON] method for details.
+*/
+type nameFormLayout struct {
+	Type       string              `json:"type" yaml:"type"`
+	OID        string              `json:"oid" yaml:"oid"`
+	Names      []string            `json:"names,omitempty" yaml:"names,omitempty"`
+	Desc       string              `json:"desc,omitempty" yaml:"desc,omitempty"`
+	Obsolete   bool                `json:"obsolete,omitempty" yaml:"obsolete,omitempty"`
+	OC         string              `json:"oc" yaml:"oc"`
+	Must       []string            `json:"must" yaml:"must"`
+	May        []string            `json:"may,omitempty" yaml:"may,omitempty"`
+	Extensions map[string][]string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+/*
+dITStructureRuleLayout is the object layout of a [DITStructureRule].  See the
+[DITStructureRule.MarshalJSON] method for details.
+*/
+type dITStructureRuleLayout struct {
+	Type       string              `json:"type" yaml:"type"`
+	RuleID     uint                `json:"ruleid" yaml:"ruleid"`
+	Names      []string            `json:"names,omitempty" yaml:"names,omitempty"`
+	Desc       string              `json:"desc,omitempty" yaml:"desc,omitempty"`
+	Obsolete   bool                `json:"obsolete,omitempty" yaml:"obsolete,omitempty"`
+	Form       string              `json:"form" yaml:"form"`
+	Sup        []uint              `json:"sup,omitempty" yaml:"sup,omitempty"`
+	Extensions map[string][]string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+/*
+marshalLayout returns an error following an attempt to marshal the
+contents of l into def by way of the relevant Marshal method, thereby
+resolving all references using the [Schema] associated with def.
+
+Should the "type" value of l be set, it must match the type of def.
+Should the numeric OID value of l be a macro (e.g.: "myOrg:1.1"), it
+is resolved using the [Macros] of the associated [Schema].
+*/
+func marshalLayout(def Definition, l definitionLayout) (err error) {
+	if def.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	if typ := l.layoutType(); len(typ) > 0 && typ != def.Type() {
+		err = wraperr(ErrInvalidType, `: `+typ)
+		return
+	}
+
+	dm := l.definitionMap()
+	if oid := dm[`NUMERICOID`]; len(oid) > 0 {
+		dm[`NUMERICOID`] = []string{def.Schema().layoutOID(oid[0])}
+	}
+
+	err = def.Marshal(dm)
+
+	return
+}
+
+/*
+layoutOID returns oid, resolved using the [Macros] of the receiver instance
+should it be macro-based (e.g.: "myOrg:1.1").  Unresolvable values are
+returned as-is.
+*/
+func (r Schema) layoutOID(oid string) string {
+	if !isNumericOID(oid) && !r.IsZero() {
+		if res := handleMacro(r, split(oid, `:`), ``); len(res) > 0 {
+			oid = res
+		}
+	}
+
+	return oid
+}
+
+/*
+defLayout returns the object layout equivalent of def, or nil if def is
+not a recognized definition type.
+*/
+func defLayout(def Definition) (l definitionLayout) {
+	switch tv := def.(type) {
+	case LDAPSyntax:
+		l = tv.layout()
+	case MatchingRule:
+		l = tv.layout()
+	case AttributeType:
+		l = tv.layout()
+	case MatchingRuleUse:
+		l = tv.layout()
+	case ObjectClass:
+		l = tv.layout()
+	case DITContentRule:
+		l = tv.layout()
+	case NameForm:
+		l = tv.layout()
+	case DITStructureRule:
+		l = tv.layout()
+	}
+
+	return
+}
+
+/*
+newLayout returns a new zero instance of the object layout associated with
+the specified definition type, or nil if unrecognized.
+*/
+func newLayout(typ string) (l definitionLayout) {
+	switch typ {
+	case `ldapSyntax`:
+		l = &lDAPSyntaxLayout{}
+	case `matchingRule`:
+		l = &matchingRuleLayout{}
+	case `attributeType`:
+		l = &attributeTypeLayout{}
+	case `matchingRuleUse`:
+		l = &matchingRuleUseLayout{}
+	case `objectClass`:
+		l = &objectClassLayout{}
+	case `dITContentRule`:
+		l = &dITContentRuleLayout{}
+	case `nameForm`:
+		l = &nameFormLayout{}
+	case `dITStructureRule`:
+		l = &dITStructureRuleLayout{}
+	}
+
+	return
+}
+
+/*
+newDefinition returns a new instance of the specified definition type,
+associated with sch, or nil if unrecognized.
+*/
+func (r Schema) newDefinition(typ string) (def Definition) {
+	switch typ {
+	case `ldapSyntax`:
+		def = r.NewLDAPSyntax()
+	case `matchingRule`:
+		def = r.NewMatchingRule()
+	case `attributeType`:
+		def = r.NewAttributeType()
+	case `matchingRuleUse`:
+		def = r.NewMatchingRuleUse()
+	case `objectClass`:
+		def = r.NewObjectClass()
+	case `dITContentRule`:
+		def = r.NewDITContentRule()
+	case `nameForm`:
+		def = r.NewNameForm()
+	case `dITStructureRule`:
+		def = r.NewDITStructureRule()
+	}
+
+	return
+}
+
+/*
+layoutExtensions returns the extensions of def in map form, or nil if no
+extensions are present.
+*/
+func layoutExtensions(def Definition) (exts map[string][]string) {
+	x := def.Extensions()
+	for _, k := range x.Keys() {
+		if ext, found := x.get(k); found {
+			if exts == nil {
+				exts = make(map[string][]string)
+			}
+			exts[k] = ext.List()
+		}
+	}
+
+	return
+}
+
+/*
+layoutDefinitionMap returns a new [DefinitionMap] instance populated with
+the common clauses and the extensions provided.
+*/
+func layoutDefinitionMap(names []string, desc string, obsolete bool, exts map[string][]string) (dm DefinitionMap) {
+	dm = make(DefinitionMap, 0)
+	if len(names) > 0 {
+		dm[`NAME`] = names
+	}
+	if len(desc) > 0 {
+		dm[`DESC`] = []string{desc}
+	}
+	if obsolete {
+		dm[`OBSOLETE`] = []string{`TRUE`}
+	}
+	for k, v := range exts {
+		dm[uc(k)] = v
+	}
+
+	return
+}
+
+/*
+oidStrings returns the OID (or descriptor) of each member of defs.
+*/
+func oidStrings(defs Definitions) (oids []string) {
+	for _, def := range collectionDefinitions(defs) {
+		oids = append(oids, def.(interface{ OID() string }).OID())
+	}
+
+	return
+}
+
+/*
+layout returns the object layout equivalent of the receiver instance.
+*/
+func (r LDAPSyntax) layout() lDAPSyntaxLayout {
+	return lDAPSyntaxLayout{
+		Type:       r.Type(),
+		OID:        r.NumericOID(),
+		Desc:       r.Description(),
+		Extensions: layoutExtensions(r),
+	}
+}
+
+func (r lDAPSyntaxLayout) layoutType() string {
+	return r.Type
+}
+
+func (r lDAPSyntaxLayout) definitionMap() (dm DefinitionMap) {
+	dm = layoutDefinitionMap(nil, r.Desc, false, r.Extensions)
+	dm[`NUMERICOID`] = []string{r.OID}
+
+	return
+}
+
+/*
+layout returns the object layout equivalent of the receiver instance.
+*/
+func (r MatchingRule) layout() matchingRuleLayout {
+	return matchingRuleLayout{
+		Type:       r.Type(),
+		OID:        r.NumericOID(),
+		Names:      r.Names().List(),
+		Desc:       r.Description(),
+		Obsolete:   r.Obsolete(),
+		Syntax:     r.Syntax().NumericOID(),
+		Extensions: layoutExtensions(r),
+	}
+}
+
+func (r matchingRuleLayout) layoutType() string {
+	return r.Type
+}
+
+func (r matchingRuleLayout) definitionMap() (dm DefinitionMap) {
+	dm = layoutDefinitionMap(r.Names, r.Desc, r.Obsolete, r.Extensions)
+	dm[`NUMERICOID`] = []string{r.OID}
+	dm[`SYNTAX`] = []string{r.Syntax}
+
+	return
+}
+
+/*
+layout returns the object layout equivalent of the receiver instance.
+*/
+func (r AttributeType) layout() attributeTypeLayout {
+	return attributeTypeLayout{
+		Type:        r.Type(),
+		OID:         r.NumericOID(),
+		Names:       r.Names().List(),
+		Desc:        r.Description(),
+		Obsolete:    r.Obsolete(),
+		Sup:         r.SuperType().OID(),
+		Equality:    r.Equality().OID(),
+		Ordering:    r.Ordering().OID(),
+		Substr:      r.Substring().OID(),
+		Syntax:      r.Syntax().NumericOID(),
+		MUB:         r.MinimumUpperBounds(),
+		SingleValue: r.SingleValue(),
+		Collective:  r.Collective(),
+		NoUserMod:   r.NoUserModification(),
+		Usage:       r.Usage(),
+		Extensions:  layoutExtensions(r),
+	}
+}
+
+func (r attributeTypeLayout) layoutType() string {
+	return r.Type
+}
+
+func (r attributeTypeLayout) definitionMap() (dm DefinitionMap) {
+	dm = layoutDefinitionMap(r.Names, r.Desc, r.Obsolete, r.Extensions)
+	dm[`NUMERICOID`] = []string{r.OID}
+	for k, v := range map[string]string{
+		`SUP`:      r.Sup,
+		`EQUALITY`: r.Equality,
+		`ORDERING`: r.Ordering,
+		`SUBSTR`:   r.Substr,
+		`SYNTAX`:   r.Syntax,
+		`USAGE`:    r.Usage,
+	} {
+		if len(v) > 0 {
+			dm[k] = []string{v}
+		}
+	}
+	for k, v := range map[string]bool{
+		`SINGLE-VALUE`:         r.SingleValue,
+		`COLLECTIVE`:           r.Collective,
+		`NO-USER-MODIFICATION`: r.NoUserMod,
+	} {
+		if v {
+			dm[k] = []string{`TRUE`}
+		}
+	}
+	if r.MUB > 0 {
+		dm[`MUB`] = []string{uitoa(r.MUB)}
+	}
+
+	return
+}
+
+/*
+layout returns the object layout equivalent of the receiver instance.
+*/
+func (r MatchingRuleUse) layout() matchingRuleUseLayout {
+	return matchingRuleUseLayout{
+		Type:       r.Type(),
+		OID:        r.NumericOID(),
+		Names:      r.Names().List(),
+		Desc:       r.Description(),
+		Obsolete:   r.Obsolete(),
+		Applies:    oidStrings(r.Applies()),
+		Extensions: layoutExtensions(r),
+	}
+}
+
+func (r matchingRuleUseLayout) layoutType() string {
+	return r.Type
+}
+
+func (r matchingRuleUseLayout) definitionMap() (dm DefinitionMap) {
+	dm = layoutDefinitionMap(r.Names, r.Desc, r.Obsolete, r.Extensions)
+	dm[`NUMERICOID`] = []string{r.OID}
+	dm[`APPLIES`] = r.Applies
+
+	return
+}
+
+/*
+layout returns the object layout equivalent of the receiver instance.
+*/
+func (r ObjectClass) layout() objectClassLayout {
+	kind := `STRUCTURAL`
+	switch r.Kind() {
+	case AbstractKind:
+		kind = `ABSTRACT`
+	case AuxiliaryKind:
+		kind = `AUXILIARY`
+	}
+
+	return objectClassLayout{
+		Type:       r.Type(),
+		OID:        r.NumericOID(),
+		Names:      r.Names().List(),
+		Desc:       r.Description(),
+		Obsolete:   r.Obsolete(),
+		Sup:        oidStrings(r.SuperClasses()),
+		Kind:       kind,
+		Must:       oidStrings(r.Must()),
+		May:        oidStrings(r.May()),
+		Extensions: layoutExtensions(r),
+	}
+}
+
+func (r objectClassLayout) layoutType() string {
+	return r.Type
+}
+
+func (r objectClassLayout) definitionMap() (dm DefinitionMap) {
+	dm = layoutDefinitionMap(r.Names, r.Desc, r.Obsolete, r.Extensions)
+	dm[`NUMERICOID`] = []string{r.OID}
+	dm[`SUP`] = r.Sup
+	dm[`MUST`] = r.Must
+	dm[`MAY`] = r.May
+	if len(r.Kind) > 0 {
+		dm[`KIND`] = []string{r.Kind}
+	}
+
+	return
+}
+
+/*
+layout returns the object layout equivalent of the receiver instance.
+*/
+func (r DITContentRule) layout() dITContentRuleLayout {
+	return dITContentRuleLayout{
+		Type:       r.Type(),
+		OID:        r.NumericOID(),
+		Names:      r.Names().List(),
+		Desc:       r.Description(),
+		Obsolete:   r.Obsolete(),
+		Aux:        oidStrings(r.Aux()),
+		Must:       oidStrings(r.Must()),
+		May:        oidStrings(r.May()),
+		Not:        oidStrings(r.Not()),
+		Extensions: layoutExtensions(r),
+	}
+}
+
+func (r dITContentRuleLayout) layoutType() string {
+	return r.Type
+}
+
+func (r dITContentRuleLayout) definitionMap() (dm DefinitionMap) {
+	dm = layoutDefinitionMap(r.Names, r.Desc, r.Obsolete, r.Extensions)
+	dm[`NUMERICOID`] = []string{r.OID}
+	dm[`AUX`] = r.Aux
+	dm[`MUST`] = r.Must
+	dm[`MAY`] = r.May
+	dm[`NOT`] = r.Not
+
+	return
+}
+
+/*
+layout returns the object layout equivalent of the receiver instance.
+*/
+func (r NameForm) layout() nameFormLayout {
+	return nameFormLayout{
+		Type:       r.Type(),
+		OID:        r.NumericOID(),
+		Names:      r.Names().List(),
+		Desc:       r.Description(),
+		Obsolete:   r.Obsolete(),
+		OC:         r.OC().OID(),
+		Must:       oidStrings(r.Must()),
+		May:        oidStrings(r.May()),
+		Extensions: layoutExtensions(r),
+	}
+}
+
+func (r nameFormLayout) layoutType() string {
+	return r.Type
+}
+
+func (r nameFormLayout) definitionMap() (dm DefinitionMap) {
+	dm = layoutDefinitionMap(r.Names, r.Desc, r.Obsolete, r.Extensions)
+	dm[`NUMERICOID`] = []string{r.OID}
+	dm[`OC`] = []string{r.OC}
+	dm[`MUST`] = r.Must
+	dm[`MAY`] = r.May
+
+	return
+}
+
+/*
+layout returns the object layout equivalent of the receiver instance.
+*/
+func (r DITStructureRule) layout() dITStructureRuleLayout {
+	var sups []uint
+	for i := 0; i < r.SuperRules().Len(); i++ {
+		sups = append(sups, r.SuperRules().Index(i).RuleID())
+	}
+
+	return dITStructureRuleLayout{
+		Type:       r.Type(),
+		RuleID:     r.RuleID(),
+		Names:      r.Names().List(),
+		Desc:       r.Description(),
+		Obsolete:   r.Obsolete(),
+		Form:       r.Form().OID(),
+		Sup:        sups,
+		Extensions: layoutExtensions(r),
+	}
+}
+
+func (r dITStructureRuleLayout) layoutType() string {
+	return r.Type
+}
+
+func (r dITStructureRuleLayout) definitionMap() (dm DefinitionMap) {
+	dm = layoutDefinitionMap(r.Names, r.Desc, r.Obsolete, r.Extensions)
+	dm[`RULEID`] = []string{uitoa(r.RuleID)}
+	dm[`FORM`] = []string{r.Form}
+	for _, sup := range r.Sup {
+		dm[`SUP`] = append(dm[`SUP`], uitoa(sup))
+	}
+
+	return
+}
@@ -0,0 +1,530 @@
+package schemax
+
+/*
+ldif.go contains RFC 2849 LDIF facilities, including the reading and
+writing of subschema subentries and the generation of change records
+meant to migrate a live subschema subentry.
+*/
+
+import (
+	"encoding/base64"
+	"io"
+)
+
+/*
+subschemaAttributes maps each definition type to the subschema subentry
+attribute type (per RFC 4512 Section 4.2) in which it is published.
+*/
+var subschemaAttributes map[string]string = map[string]string{
+	`ldapSyntax`:       `ldapSyntaxes`,
+	`matchingRule`:     `matchingRules`,
+	`attributeType`:    `attributeTypes`,
+	`matchingRuleUse`:  `matchingRuleUse`,
+	`objectClass`:      `objectClasses`,
+	`dITContentRule`:   `dITContentRules`,
+	`nameForm`:         `nameForms`,
+	`dITStructureRule`: `dITStructureRules`,
+}
+
+/*
+subschemaType returns the definition type associated with the subschema
+subentry attribute type attr, or a zero string if attr is not one of the
+attribute types described by RFC 4512 Section 4.2.  Case is not significant,
+and attribute options (e.g.: ";binary") are ignored.
+*/
+func subschemaType(attr string) (typ string) {
+	if idx := stridx(attr, `;`); idx != -1 {
+		attr = attr[:idx]
+	}
+
+	for t, a := range subschemaAttributes {
+		if eq(attr, a) {
+			typ = t
+			break
+		}
+	}
+
+	return
+}
+
+/*
+migrationOrder contains the definition types, in order of precedence, to be
+added to a live subschema subentry.  Deletions are conducted in the reverse
+order.
+
+Note that [MatchingRuleUse] definitions are absent, as these are maintained
+by the DSA itself per RFC 4512 Section 4.1.4.
+*/
+var migrationOrder []string = []string{
+	`ldapSyntax`,
+	`matchingRule`,
+	`attributeType`,
+	`objectClass`,
+	`dITContentRule`,
+	`nameForm`,
+	`dITStructureRule`,
+}
+
+/*
+ParseLDIF returns an error following an attempt to read and parse an RFC
+2849 LDIF representation of a subschema subentry from rdr, such as that
+returned by an LDAP search of the subschema subentry of a live directory
+server, e.g.:
+
+	$ ldapsearch -LLL -b cn=schema -s base '(objectClass=*)' '+' '*'
+
+Values of the ldapSyntaxes, matchingRules, matchingRuleUse, attributeTypes,
+objectClasses, dITContentRules, nameForms and dITStructureRules attribute
+types are processed.  All other content is ignored.  Folded lines are
+unfolded and base64 values are decoded.  Should a DN be present, it is
+assigned to the receiver instance via the [Schema.SetDN] method.
+
+Definitions are written to the receiver instance in the manner described
+by the [Schema.ParseRaw] method, thus the order of values is not significant.
+Note that definitions already present within the receiver instance (e.g.:
+those loaded by [NewSchema]) shall result in duplicate definition errors;
+consider use of [NewEmptySchema] in such cases.
+
+Failures are returned as [ParseError] instances bearing the line number of
+the value in question.  Parsing ceases upon the first error unless the
+[ContinueOnError] option is in effect, in which case all failures are
+returned.
+*/
+func (r Schema) ParseLDIF(rdr io.Reader) (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	} else if rdr == nil {
+		err = ErrNilInput
+		return
+	}
+
+	var raw []byte
+	if raw, err = io.ReadAll(rdr); err != nil {
+		return
+	}
+
+	defs, dn, errs := scanLDIF(string(raw), ``, subschemaType)
+	if len(dn) > 0 {
+		r.SetDN(dn)
+	}
+
+	err = joinErr(r.incorporateRaw(defs, errs,
+		r.Options().Positive(ContinueOnError))...)
+
+	return
+}
+
+/*
+LDIF returns an RFC 2849 LDIF representation of the receiver instance in
+the form of a subschema subentry, alongside an error, e.g.:
+
+	dn: cn=schema
+	objectClass: top
+	objectClass: subschema
+	ldapSyntaxes: ( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )
+	...
+	dITStructureRules: ( 1 NAME 'domainStructureRule' FORM domainNameForm )
+
+The DN of the receiver instance is used.  See [Schema.DN] and [Schema.SetDN]
+for details.  Values are written in order of type precedence and, within
+each type, in order of appearance.  The output is suitable for use with the
+[Schema.ParseLDIF] method.
+
+Lines longer than seventy-six (76) characters are folded, and values which
+do not qualify as SAFE-STRING are base64 encoded, per RFC 2849.
+*/
+func (r Schema) LDIF() (ldif string, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	} else if len(r.DN()) == 0 {
+		err = wraperr(ErrNilInput, ": missing subschema subentry DN")
+		return
+	}
+
+	lines := []string{
+		ldifLine(`dn`, r.DN()),
+		ldifLine(`objectClass`, `top`),
+		ldifLine(`objectClass`, `subschema`),
+	}
+
+	for i, defs := range []Definitions{
+		r.LDAPSyntaxes(),
+		r.MatchingRules(),
+		r.AttributeTypes(),
+		r.MatchingRuleUses(),
+		r.ObjectClasses(),
+		r.DITContentRules(),
+		r.NameForms(),
+		r.DITStructureRules(),
+	} {
+		typ := definitionTypes[i]
+		for _, def := range collectionDefinitions(defs) {
+			val := condenseWHSP(def.String())
+			if len(val) == 0 {
+				err = wraperr(ErrDefNonCompliant, ": no string value for "+
+					typ+` `+defIdentity(def))
+				return
+			}
+			lines = append(lines, ldifLine(subschemaAttributes[typ], val))
+		}
+	}
+
+	ldif = join(lines, string(rune(10))) + string(rune(10))
+
+	return
+}
+
+/*
+scanLDIF returns the values found within the LDIF content held by raw in
+the form of definitions and directives, alongside the DN of the first
+record (if any) and any failures encountered.  The attrType closure maps
+an attribute type to a definition type (or directive); values of those
+attribute types for which a zero string is returned are ignored.
+
+Modify records are tolerated, in that the values of their add and replace
+operations are processed like any other value.  Values listed beneath a
+delete operation are ignored.  URL values ("attr:<") are not supported.
+*/
+func scanLDIF(raw, file string, attrType func(string) string) (defs []rawDefinition, dn string, errs []error) {
+	var seenDN, deleting bool
+	for _, ll := range unfoldLDIF(raw) {
+		idx := stridx(ll.text, `:`)
+		if ll.text == `-` {
+			// modify record separator
+			deleting = false
+			continue
+		} else if idx == -1 {
+			errs = append(errs, ParseError{File: file, Line: ll.line,
+				Err: ErrUnrecognizedContent})
+			continue
+		}
+
+		attr, val := ll.text[:idx], ll.text[idx+1:]
+		switch {
+		case eq(attr, `delete`):
+			deleting = true
+			continue
+		case eq(attr, `dn`):
+			// a new record begins
+			deleting = false
+		case deleting:
+			continue
+		}
+
+		typ := attrType(attr)
+		isDN := eq(attr, `dn`) && !seenDN
+		if typ == `` && !isDN {
+			continue
+		}
+
+		switch {
+		case hasPfx(val, `:`):
+			b, err := base64.StdEncoding.DecodeString(trimS(val[1:]))
+			if err != nil {
+				errs = append(errs, ParseError{File: file, Line: ll.line,
+					Type: typ, Err: err})
+				continue
+			}
+			val = string(b)
+		case hasPfx(val, `<`):
+			errs = append(errs, ParseError{File: file, Line: ll.line, Type: typ,
+				Err: wraperr(ErrUnrecognizedContent, `: URL values are not supported`)})
+			continue
+		}
+
+		if isDN {
+			dn, seenDN = trimS(val), true
+			continue
+		}
+
+		defs = append(defs, rawDefinition{
+			Type: typ,
+			Text: trimS(val),
+			File: file,
+			Line: ll.line,
+		})
+	}
+
+	return
+}
+
+/*
+ldifLogicalLine contains an unfolded LDIF line, alongside the number of
+the physical line on which it begins.
+*/
+type ldifLogicalLine struct {
+	text string
+	line int
+}
+
+/*
+unfoldLDIF returns the logical lines found within raw, per RFC 2849.  Any
+physical line which begins with a single space is appended to the line
+which precedes it, less the space.  Comments (and their continuations),
+as well as empty lines, are discarded.
+*/
+func unfoldLDIF(raw string) (lines []ldifLogicalLine) {
+	var comment bool
+	for i, line := range split(raw, string(rune(10))) {
+		line = trimR(line, "\r")
+		switch {
+		case hasPfx(line, ` `):
+			if !comment && len(lines) > 0 {
+				lines[len(lines)-1].text += line[1:]
+			}
+		case hasPfx(line, `#`):
+			comment = true
+		case len(line) == 0:
+			comment = false
+		default:
+			comment = false
+			lines = append(lines, ldifLogicalLine{text: line, line: i + 1})
+		}
+	}
+
+	return
+}
+
+/*
+MigrationLDIF returns an RFC 2849 LDIF modify change record, alongside an
+error, which -- when submitted to the directory server in question -- would
+transform the live subschema subentry described by the receiver instance
+into that which is described by target.
+
+The change record is directed at the DN of the receiver instance, which is
+"cn=schema" by default.  See [Schema.DN] and [Schema.SetDN] for details.
+
+This is a convenience method which is equivalent to:
+
+	r.Diff(target).LDIF(r.DN())
+
+See [SchemaDiff.LDIF] for details regarding the ordering of modifications.
+*/
+func (r Schema) MigrationLDIF(target Schema) (ldif string, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	ldif, err = r.Diff(target).LDIF(r.DN())
+	return
+}
+
+/*
+LDIF returns an RFC 2849 LDIF modify change record, alongside an error,
+which effects the differences described by the receiver instance upon the
+subschema subentry identified by dn, e.g.:
+
+	dn: cn=schema
+	changetype: modify
+	delete: objectClasses
+	objectClasses: ( 1.3.6.1.4.1.56521.999.2 NAME 'oldClass' ... )
+	-
+	add: attributeTypes
+	attributeTypes: ( 1.3.6.1.4.1.56521.999.1 NAME 'newAttr' ... )
+	-
+
+Modifications are written in dependency-safe order.  First, all removed
+and changed definitions are deleted in reverse dependency order, namely
+[DITStructureRule], [NameForm], [DITContentRule], [ObjectClass],
+[AttributeType], [MatchingRule] and [LDAPSyntax].  Within a given type,
+definitions are deleted in reverse dependency order, such that any
+subordinate (e.g.: a subtype) is deleted before its superior.
+
+Next, all added and changed definitions are added in the opposite order,
+beginning with [LDAPSyntax] and ending with [DITStructureRule].  Within a
+given type, definitions are added in dependency order, such that any
+superior is added before its subordinates.  Independent definitions are
+otherwise written in order of appearance.
+
+A changed definition is thus expressed as the deletion of its old value
+and the addition of its new value.  Deleted values are matched by the
+server through objectIdentifierFirstComponentMatch (or its integer-based
+counterpart for [DITStructureRule] instances), meaning the deleted value
+need only bear the correct numeric identifier.
+
+[MatchingRuleUse] differences are ignored, as these definitions are
+maintained by the DSA and cannot be modified by the user.
+
+Lines longer than seventy-six (76) characters are folded, and values which
+do not qualify as SAFE-STRING are base64 encoded, per RFC 2849.
+
+A zero length string and nil error are returned if the receiver instance
+describes no actionable differences.
+*/
+func (r SchemaDiff) LDIF(dn string) (ldif string, err error) {
+	if len(dn) == 0 {
+		err = wraperr(ErrNilInput, ": missing subschema subentry DN")
+		return
+	}
+
+	var mods []string
+	for i := len(migrationOrder) - 1; i >= 0; i-- {
+		var vals []string
+		if vals, err = r.migrationValues(migrationOrder[i], false); err != nil {
+			return
+		}
+		mods = append(mods, ldifModification(`delete`,
+			subschemaAttributes[migrationOrder[i]], vals)...)
+	}
+
+	for i := 0; i < len(migrationOrder); i++ {
+		var vals []string
+		if vals, err = r.migrationValues(migrationOrder[i], true); err != nil {
+			return
+		}
+		mods = append(mods, ldifModification(`add`,
+			subschemaAttributes[migrationOrder[i]], vals)...)
+	}
+
+	if len(mods) > 0 {
+		lines := []string{
+			ldifLine(`dn`, dn),
+			ldifLine(`changetype`, `modify`),
+		}
+		ldif = join(append(lines, mods...), string(rune(10))) + string(rune(10))
+	}
+
+	return
+}
+
+/*
+migrationValues returns the condensed string values of all definitions of
+the specified type which must be added (add is true) or deleted (add is
+false) in order to effect the changes described by the receiver instance.
+
+Values to be added are returned in dependency order, such that a superior
+definition (e.g.: a supertype) precedes its subordinates.  Values to be
+deleted are returned in the reverse of that order.
+*/
+func (r SchemaDiff) migrationValues(typ string, add bool) (vals []string, err error) {
+	var defs []Definition
+	for _, dd := range r {
+		if dd.Type != typ {
+			continue
+		}
+
+		def := dd.Old
+		if add {
+			if dd.Action == RemovedDiff {
+				continue
+			}
+			def = dd.New
+		} else if dd.Action == AddedDiff {
+			continue
+		}
+
+		if len(condenseWHSP(def.String())) == 0 {
+			err = wraperr(ErrDefNonCompliant, ": no string value for "+
+				dd.Type+` `+dd.ID)
+			return
+		}
+		defs = append(defs, def)
+	}
+
+	order := definitionOrder(defs)
+	for i := range order {
+		idx := order[i]
+		if !add {
+			idx = order[len(order)-1-i]
+		}
+		vals = append(vals, condenseWHSP(defs[idx].String()))
+	}
+
+	return
+}
+
+/*
+ldifModification returns the LDIF lines which describe a single modify
+operation of the given kind ("add", "delete" or "replace") upon attr. A
+nil return value is produced if vals is empty.
+*/
+func ldifModification(kind, attr string, vals []string) (lines []string) {
+	if len(vals) == 0 {
+		return
+	}
+
+	lines = append(lines, kind+`: `+attr)
+	for _, val := range vals {
+		lines = append(lines, ldifLine(attr, val))
+	}
+	lines = append(lines, `-`)
+
+	return
+}
+
+/*
+ldifLine returns a single (possibly folded) LDIF attrval-spec line. Should
+val not qualify as an RFC 2849 SAFE-STRING, it is base64 encoded.
+*/
+func ldifLine(attr, val string) string {
+	line := attr + `: ` + val
+	if !isSafeString(val) {
+		line = attr + `:: ` + base64.StdEncoding.EncodeToString([]byte(val))
+	}
+
+	return foldLDIFLine(line)
+}
+
+/*
+foldLDIFLine returns line folded such that no physical line exceeds
+seventy-six (76) bytes.  Each continuation line begins with a single
+space character, per RFC 2849.  Folds never occur in the middle of a
+multi-byte UTF-8 sequence.
+*/
+func foldLDIFLine(line string) string {
+	const width int = 76
+	if len(line) <= width {
+		return line
+	}
+
+	var folded []string
+	max := width
+	for len(line) > max {
+		cut := max
+		for cut > 1 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		folded = append(folded, line[:cut])
+		line = line[cut:]
+		max = width - 1 // account for leading space
+	}
+	folded = append(folded, line)
+
+	return join(folded, string(rune(10))+` `)
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+/*
+isSafeString returns a Boolean value indicative of whether val qualifies
+as an RFC 2849 SAFE-STRING.  Values bearing a trailing space are not
+considered safe, per the recommendation of RFC 2849.
+*/
+func isSafeString(val string) bool {
+	if len(val) == 0 {
+		return true
+	}
+
+	switch val[0] {
+	case ' ', ':', '<':
+		return false
+	}
+
+	if val[len(val)-1] == ' ' {
+		return false
+	}
+
+	for i := 0; i < len(val); i++ {
+		switch c := val[i]; {
+		case c == 0, c == '\n', c == '\r', c > 127:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,379 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+/*
+This example demonstrates the generation of an LDIF change record which
+would migrate a live subschema subentry to a target [Schema].
+*/
+func ExampleSchema_MigrationLDIF() {
+	live := NewSchema()
+	target := NewSchema()
+
+	live.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.90.1
+		NAME 'fakeAttr'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.26 )`)
+
+	target.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.90.1
+		NAME 'fakeAttr'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	target.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.90.2
+		NAME 'fakeClass'
+		SUP top AUXILIARY
+		MAY fakeAttr )`)
+
+	ldif, err := live.MigrationLDIF(target)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Print(ldif)
+	// Output:
+	// dn: cn=schema
+	// changetype: modify
+	// delete: attributeTypes
+	// attributeTypes: ( 1.3.6.1.4.1.56521.999.90.1 NAME 'fakeAttr' SYNTAX 1.3.6.1.
+	//  4.1.1466.115.121.1.26 )
+	// -
+	// add: attributeTypes
+	// attributeTypes: ( 1.3.6.1.4.1.56521.999.90.1 NAME 'fakeAttr' SYNTAX 1.3.6.1.
+	//  4.1.1466.115.121.1.15 )
+	// -
+	// add: objectClasses
+	// objectClasses: ( 1.3.6.1.4.1.56521.999.90.2 NAME 'fakeClass' SUP top AUXILIA
+	//  RY MAY fakeAttr )
+	// -
+}
+
+func TestSchemaDiff_LDIF(t *testing.T) {
+	live := NewSchema()
+	target := NewSchema()
+
+	raw := `attributeType ( 1.3.6.1.4.1.56521.999.90.1 NAME 'fakeAttr' SUP name )
+attributeType ( 1.3.6.1.4.1.56521.999.90.2 NAME 'fakeSubAttr' SUP fakeAttr )
+objectClass ( 1.3.6.1.4.1.56521.999.90.3 NAME 'fakeClass' SUP top AUXILIARY MAY fakeSubAttr )`
+
+	if err := live.ParseRaw([]byte(raw)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if err := target.ParseLDAPSyntax(`( 1.3.6.1.4.1.56521.999.90.4 DESC 'Fake syntax' )`); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+	target.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.90.5 NAME 'fakeNewAttr' SYNTAX 1.3.6.1.4.1.56521.999.90.4 DESC 'Ünïcödé' )`)
+
+	ldif, err := live.MigrationLDIF(target)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	// Deletions must be ordered such that dependents go first;
+	// additions must be ordered such that dependencies go first.
+	for _, pair := range [][2]string{
+		{`NAME 'fakeClass'`, `NAME 'fakeSubAttr'`},
+		{`NAME 'fakeSubAttr'`, `NAME 'fakeAttr'`},
+		{`NAME 'fakeAttr'`, `add: ldapSyntaxes`},
+		{`add: ldapSyntaxes`, `add: attributeTypes`},
+	} {
+		if a, b := strings.Index(ldif, pair[0]), strings.Index(ldif, pair[1]); a < 0 || b < 0 || a > b {
+			t.Errorf("%s failed: %q not found before %q:\n%s", t.Name(), pair[0], pair[1], ldif)
+			return
+		}
+	}
+
+	if !strings.Contains(ldif, `attributeTypes:: `) {
+		t.Errorf("%s failed: expected base64 value for non-ASCII definition:\n%s", t.Name(), ldif)
+		return
+	}
+
+	for _, line := range strings.Split(ldif, "\n") {
+		if len(line) > 76 {
+			t.Errorf("%s failed: unfolded line: %s", t.Name(), line)
+			return
+		}
+	}
+
+	if strings.Contains(ldif, `matchingRuleUse`) {
+		t.Errorf("%s failed: unexpected matchingRuleUse modification", t.Name())
+		return
+	}
+}
+
+func TestSchemaDiff_LDIF_dependencyOrder(t *testing.T) {
+	live := NewSchema()
+	if err := live.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.100.1 NAME 'xAttr' SUP name )
+objectClass ( 1.3.6.1.4.1.56521.999.100.2 NAME 'xClass' SUP top AUXILIARY MAY xAttr )`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	// The changed xAttr and xClass precede the newly added yAttr
+	// and yClass within the diff, yet depend upon them.
+	target := NewSchema()
+	if err := target.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.100.3 NAME 'yAttr' SUP name )
+attributeType ( 1.3.6.1.4.1.56521.999.100.1 NAME 'xAttr' SUP yAttr )
+objectClass ( 1.3.6.1.4.1.56521.999.100.4 NAME 'yClass' SUP top AUXILIARY )
+objectClass ( 1.3.6.1.4.1.56521.999.100.2 NAME 'xClass' SUP yClass AUXILIARY MAY xAttr )`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	ldif, err := live.MigrationLDIF(target)
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	for _, pair := range [][2]string{
+		{`NAME 'yAttr'`, `NAME 'xAttr' SUP yAttr`},
+		{`NAME 'yClass'`, `NAME 'xClass' SUP yClass`},
+	} {
+		if a, b := strings.Index(ldif, pair[0]), strings.Index(ldif, pair[1]); a < 0 || b < 0 || a > b {
+			t.Errorf("%s failed: %q not found before %q:\n%s", t.Name(), pair[0], pair[1], ldif)
+		}
+	}
+
+	// Deletions are written in reverse dependency order.
+	if ldif, err = target.MigrationLDIF(NewSchema()); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	for _, pair := range [][2]string{
+		{`NAME 'xAttr'`, `NAME 'yAttr'`},
+		{`NAME 'xClass'`, `NAME 'yClass'`},
+	} {
+		if a, b := strings.Index(ldif, pair[0]), strings.Index(ldif, pair[1]); a < 0 || b < 0 || a > b {
+			t.Errorf("%s failed: %q not found before %q:\n%s", t.Name(), pair[0], pair[1], ldif)
+		}
+	}
+}
+
+func TestSchemaDiff_LDIF_codecov(t *testing.T) {
+	var sch Schema
+	if _, err := sch.MigrationLDIF(NewSchema()); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	if _, err := (SchemaDiff{}).LDIF(``); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	if ldif, err := NewSchema().MigrationLDIF(NewSchema()); err != nil || len(ldif) != 0 {
+		t.Errorf("%s failed: expected empty LDIF, got %q (%v)", t.Name(), ldif, err)
+	}
+
+	for _, val := range []string{``, ` x`, `:x`, `<x`, `x `, "x\ny", `ü`} {
+		_ = isSafeString(val)
+	}
+
+	_ = foldLDIFLine(strings.Repeat(`ü`, 100))
+}
+
+/*
+This example demonstrates the parsing of a subschema subentry, such as
+one obtained through an LDAP search of a live directory server.
+*/
+func ExampleSchema_ParseLDIF() {
+	sch := NewEmptySchema()
+
+	ldif := `dn: cn=Subschema
+objectClass: top
+objectClass: subentry
+objectClass: subschema
+cn: Subschema
+ldapSyntaxes: ( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )
+matchingRules: ( 2.5.13.2 NAME 'caseIgnoreMatch' SYNTAX 1.3.6.1.4.1.1466.115
+ .121.1.15 )
+attributeTypes:: KCAyLjUuNC40MSBOQU1FICduYW1lJyBFUVVBTElUWSBjYXNlSWdub3JlTWF0
+ Y2ggU1lOVEFYIDEuMy42LjEuNC4xLjE0NjYuMTE1LjEyMS4xLjE1ICk=
+`
+
+	if err := sch.ParseLDIF(strings.NewReader(ldif)); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(sch.DN())
+	fmt.Println(sch.AttributeTypes().Get(`name`))
+	// Output:
+	// cn=Subschema
+	// ( 2.5.4.41 NAME 'name' EQUALITY caseIgnoreMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )
+}
+
+func TestSchema_LDIF(t *testing.T) {
+	sch := NewSchema()
+	sch.SetDN(`cn=subSchema`)
+
+	ldif, err := sch.LDIF()
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	imported := NewEmptySchema()
+	if err = imported.ParseLDIF(strings.NewReader(ldif)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if imported.DN() != `cn=subSchema` {
+		t.Errorf("%s failed: want DN cn=subSchema, got %s", t.Name(), imported.DN())
+		return
+	}
+
+	if diff := sch.Diff(imported); !diff.IsZero() {
+		t.Errorf("%s failed: unexpected differences:\n%s", t.Name(), diff)
+		return
+	}
+
+	if got, want := imported.Counters(), sch.Counters(); got != want {
+		t.Errorf("%s failed: counters mismatch; want %v, got %v", t.Name(), want, got)
+		return
+	}
+}
+
+func TestSchema_ParseLDIF_errors(t *testing.T) {
+	ldif := `dn: cn=schema
+attributeTypes: ( 1.3.6.1.4.1.56521.999.84.1 NAME 'fakeAttr' SUP bogusAttr )
+# a folded comment
+ which continues here
+objectClasses:: !!!
+bogus line
+`
+
+	if err := NewSchema().ParseLDIF(strings.NewReader(ldif)); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+		return
+	}
+
+	err := NewSchema(ContinueOnError).ParseLDIF(strings.NewReader(ldif))
+	for _, want := range []error{
+		ErrAttributeTypeNotFound,
+		ErrUnrecognizedContent,
+	} {
+		if !errors.Is(err, want) {
+			t.Errorf("%s failed: expected %v, got %v", t.Name(), want, err)
+			return
+		}
+	}
+
+	var perr ParseError
+	if !errors.As(err, &perr) || perr.Line != 2 {
+		t.Errorf("%s failed: unexpected first error: %#v", t.Name(), perr)
+		return
+	}
+}
+
+func TestSchema_ParseLDIF_firstError(t *testing.T) {
+	ldif := `dn: cn=schema
+attributeTypes: ( 1.3.6.1.4.1.56521.999.84.1 NAME 'fakeAttr' SUP bogusAttr )
+attributeTypes: ( 1.3.6.1.4.1.56521.999.84.2 NAME 'goodAttr' EQUALITY caseExactMatch SUP name )
+`
+
+	sch := NewSchema()
+	err := sch.ParseLDIF(strings.NewReader(ldif))
+	if !errors.Is(err, ErrAttributeTypeNotFound) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrAttributeTypeNotFound, err)
+		return
+	} else if sch.AttributeTypes().Contains(`goodAttr`) {
+		t.Errorf("%s failed: goodAttr written after first failure", t.Name())
+		return
+	}
+
+	// Neither parsing path regenerates matching rule uses.
+	for _, opt := range []Option{0, ContinueOnError} {
+		sch = NewSchema(opt)
+		want := sch.MatchingRuleUses().String()
+		if err = sch.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.84.2 NAME 'goodAttr' EQUALITY caseExactMatch SUP name )`)); err != nil {
+			t.Errorf("%s failed: %v", t.Name(), err)
+			return
+		} else if got := sch.MatchingRuleUses().String(); got != want {
+			t.Errorf("%s failed [%d]: matching rule uses regenerated", t.Name(), opt)
+			return
+		}
+	}
+}
+
+func TestSchema_ParseLDIF_modifyDelete(t *testing.T) {
+	ldif := `dn: cn=schema
+changetype: modify
+delete: attributeTypes
+attributeTypes: ( 1.3.6.1.4.1.56521.999.84.10 NAME 'deletedAttr' SUP name )
+-
+add: attributeTypes
+attributeTypes: ( 1.3.6.1.4.1.56521.999.84.11 NAME 'addedAttr' SUP name )
+-
+delete: attributeTypes
+attributeTypes: ( 1.3.6.1.4.1.56521.999.84.12 NAME 'otherDeletedAttr' SUP name )
+
+dn: cn=schema
+changetype: modify
+add: attributeTypes
+attributeTypes: ( 1.3.6.1.4.1.56521.999.84.13 NAME 'nextRecordAttr' SUP name )
+`
+
+	sch := NewSchema()
+	if err := sch.ParseLDIF(strings.NewReader(ldif)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	ats := sch.AttributeTypes()
+	for _, name := range []string{`deletedAttr`, `otherDeletedAttr`} {
+		if ats.Contains(name) {
+			t.Errorf("%s failed: value of delete operation incorporated: %s", t.Name(), name)
+		}
+	}
+	for _, name := range []string{`addedAttr`, `nextRecordAttr`} {
+		if !ats.Contains(name) {
+			t.Errorf("%s failed: value of add operation not incorporated: %s", t.Name(), name)
+		}
+	}
+}
+
+func TestSchema_LDIF_codecov(t *testing.T) {
+	var sch Schema
+	if err := sch.ParseLDIF(strings.NewReader(``)); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+	if _, err := sch.LDIF(); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	sch = NewEmptySchema()
+	if err := sch.ParseLDIF(nil); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+	if err := sch.ParseLDIF(errReader{}); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	sch.SetDN(``)
+	if _, err := sch.LDIF(); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	if err := sch.ParseLDIF(strings.NewReader("dn:: !!!\nobjectClasses:< file:///tmp/x\n")); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	for attr, want := range map[string]string{
+		`attributeTypes;binary`: `attributeType`,
+		`MATCHINGRULEUSE`:       `matchingRuleUse`,
+		`cn`:                    ``,
+	} {
+		if got := subschemaType(attr); got != want {
+			t.Errorf("%s failed: want %q, got %q", t.Name(), want, got)
+		}
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failure")
+}
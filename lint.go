@@ -0,0 +1,618 @@
+package schemax
+
+/*
+lint.go contains a configurable linter meant to identify questionable,
+though not necessarily non-compliant, practices within a Schema.
+*/
+
+import "encoding/json"
+
+const (
+	DisabledSeverity uint = iota // rule is not evaluated
+	InfoSeverity                 // finding is informational
+	WarningSeverity              // finding warrants attention
+	ErrorSeverity                // finding should be corrected
+)
+
+var lintSeverityLabels map[uint]string = map[uint]string{
+	DisabledSeverity: `disabled`,
+	InfoSeverity:     `info`,
+	WarningSeverity:  `warning`,
+	ErrorSeverity:    `error`,
+}
+
+/*
+Identifiers of the built-in [LintRule] instances registered by way of the
+[Schema.NewLinter] method.
+*/
+const (
+	ComplianceLintRule          = `compliance`            // violation of RFC 4512; see [Schema.Check]
+	MissingDescLintRule         = `missing-desc`          // definition lacks a DESC clause
+	MissingXOriginLintRule      = `missing-x-origin`      // definition lacks an X-ORIGIN extension
+	NameCaseLintRule            = `name-case`             // NAME is not lowerCamelCase
+	DuplicateNameLintRule       = `duplicate-name`        // NAME is shared by another definition
+	ExampleOIDLintRule          = `example-oid`           // numeric OID resides under an example or documentation arc
+	ObsoleteReferenceLintRule   = `obsolete-reference`    // OBSOLETE definition is referenced by a current one
+	MissingEqualityLintRule     = `missing-equality`      // attribute type lacks an effective EQUALITY rule
+	EmptyMustLintRule           = `empty-must`            // object class has no MUST clause
+	UnusedAttributeTypeLintRule = `unused-attribute-type` // user attribute type is referenced by no other definition
+)
+
+/*
+exampleArcs contains the numeric OID arcs reserved for use in examples
+and documentation, namely the X.660 "example" arc and the RFC 5612 IANA
+enterprise number.
+*/
+var exampleArcs []string = []string{
+	`2.999`,
+	`1.3.6.1.4.1.32473`,
+}
+
+/*
+LintRule is an interface type qualified by any single linter rule, whether
+built-in or user-authored, and is registered by way of the [Linter.Register]
+method.  See also the [NewLintRule] function.
+*/
+type LintRule interface {
+	// ID returns the unique identifier of the rule, such
+	// as "missing-desc".
+	ID() string
+
+	// Severity returns the default severity of the rule,
+	// such as WarningSeverity.  This may be overridden by
+	// way of the Linter.SetSeverity method.
+	Severity() uint
+
+	// Lint returns a LintReport describing the findings
+	// of the rule with regards to the input Schema.  The
+	// Rule and Severity fields of each LintFinding need
+	// not be set, as these are assigned by the Linter.
+	Lint(Schema) LintReport
+}
+
+/*
+lintRule implements [LintRule] by way of a closure function.
+*/
+type lintRule struct {
+	id  string
+	sev uint
+	fn  func(Schema) LintReport
+}
+
+/*
+NewLintRule returns a [LintRule] bearing the specified identifier and
+default severity, which is implemented by the input closure function.
+*/
+func NewLintRule(id string, severity uint, fn func(Schema) LintReport) LintRule {
+	return lintRule{id: id, sev: severity, fn: fn}
+}
+
+func (r lintRule) ID() string     { return r.id }
+func (r lintRule) Severity() uint { return r.sev }
+
+func (r lintRule) Lint(schema Schema) (report LintReport) {
+	if r.fn != nil {
+		report = r.fn(schema)
+	}
+
+	return
+}
+
+/*
+LintReport implements slices of [LintFinding], and is produced by way of
+the [Linter.Lint] method.  Slices appear in order of rule registration.
+*/
+type LintReport []LintFinding
+
+/*
+LintFinding describes a single finding of a [LintRule].
+
+Rule contains the identifier of the rule, such as [NameCaseLintRule], and
+Severity contains the configured severity of said rule, such as the
+[WarningSeverity] constant.  Type and Definition contain the type and the
+principal identifier of the offending definition, while Message describes
+the finding.
+*/
+type LintFinding struct {
+	Rule       string
+	Severity   uint
+	Type       string
+	Definition string
+	Message    string
+}
+
+/*
+String returns the string representation of the receiver instance, e.g.:
+
+	[warning] name-case: attributeType 'My-Attr': NAME 'My-Attr' is not lowerCamelCase
+*/
+func (r LintFinding) String() (s string) {
+	s = `[` + lintSeverityLabels[r.Severity] + `] ` + r.Rule + `: ` + r.Type
+	if len(r.Definition) > 0 {
+		s += ` '` + r.Definition + `'`
+	}
+	s += `: ` + r.Message
+
+	return
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The resulting object is laid out as follows:
+
+	{
+	  "rule": "name-case",
+	  "severity": "warning",
+	  "type": "attributeType",
+	  "definition": "My-Attr",
+	  "message": "NAME 'My-Attr' is not lowerCamelCase"
+	}
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r LintFinding) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule       string `json:"rule"`
+		Severity   string `json:"severity"`
+		Type       string `json:"type"`
+		Definition string `json:"definition,omitempty"`
+		Message    string `json:"message"`
+	}{
+		Rule:       r.Rule,
+		Severity:   lintSeverityLabels[r.Severity],
+		Type:       r.Type,
+		Definition: r.Definition,
+		Message:    r.Message,
+	})
+}
+
+/*
+Len returns the integer length of the receiver instance.
+*/
+func (r LintReport) Len() int {
+	return len(r)
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state, which
+indicates that no findings were produced.
+*/
+func (r LintReport) IsZero() bool {
+	return r.Len() == 0
+}
+
+/*
+Rule returns all [LintFinding] slices produced by the specified rule.
+*/
+func (r LintReport) Rule(id string) (v LintReport) {
+	for i := 0; i < r.Len(); i++ {
+		if r[i].Rule == id {
+			v = append(v, r[i])
+		}
+	}
+
+	return
+}
+
+/*
+Severity returns all [LintFinding] slices bearing a severity greater than
+or equal to the specified severity.  For example, the [WarningSeverity]
+constant returns warnings and errors.
+*/
+func (r LintReport) Severity(severity uint) (v LintReport) {
+	for i := 0; i < r.Len(); i++ {
+		if r[i].Severity >= severity {
+			v = append(v, r[i])
+		}
+	}
+
+	return
+}
+
+/*
+Max returns the highest severity found within the receiver instance, or
+[DisabledSeverity] if the receiver is zero.  This is useful in determining
+an exit code.
+*/
+func (r LintReport) Max() (severity uint) {
+	for i := 0; i < r.Len(); i++ {
+		if r[i].Severity > severity {
+			severity = r[i].Severity
+		}
+	}
+
+	return
+}
+
+/*
+String returns the text representation of the receiver instance, with
+each slice appearing on its own line.
+*/
+func (r LintReport) String() string {
+	var lines []string
+	for i := 0; i < r.Len(); i++ {
+		lines = append(lines, r[i].String())
+	}
+
+	return join(lines, string(rune(10)))
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver instance, alongside
+an error.  The result is an array of the objects described within the
+[LintFinding.MarshalJSON] method.  A zero receiver produces an empty array.
+
+This method satisfies the [encoding/json.Marshaler] interface.
+*/
+func (r LintReport) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return []byte(`[]`), nil
+	}
+
+	return json.Marshal([]LintFinding(r))
+}
+
+/*
+Linter implements a configurable set of [LintRule] instances for use in
+identifying questionable practices within a [Schema].  Instances of this
+type are produced by way of the [Schema.NewLinter] method.
+*/
+type Linter struct {
+	*linter
+}
+
+type linter struct {
+	schema     Schema
+	rules      []LintRule
+	severities map[string]uint
+}
+
+/*
+NewLinter returns a new instance of [Linter] for use with the receiver
+instance.  The following built-in rules are registered, shown alongside
+their default severities:
+
+  - [ComplianceLintRule] ([ErrorSeverity])
+  - [MissingDescLintRule] ([InfoSeverity])
+  - [MissingXOriginLintRule] ([InfoSeverity])
+  - [NameCaseLintRule] ([WarningSeverity])
+  - [DuplicateNameLintRule] ([WarningSeverity])
+  - [ExampleOIDLintRule] ([WarningSeverity])
+  - [ObsoleteReferenceLintRule] ([WarningSeverity])
+  - [MissingEqualityLintRule] ([InfoSeverity])
+  - [EmptyMustLintRule] ([InfoSeverity])
+  - [UnusedAttributeTypeLintRule] ([InfoSeverity])
+
+Severities may be adjusted, or rules disabled, by way of the [Linter.SetSeverity]
+method.  Additional rules may be registered by way of [Linter.Register].
+*/
+func (r Schema) NewLinter() Linter {
+	l := Linter{&linter{
+		schema:     r,
+		severities: make(map[string]uint),
+	}}
+
+	return l.Register(
+		NewLintRule(ComplianceLintRule, ErrorSeverity, lintCompliance),
+		NewLintRule(MissingDescLintRule, InfoSeverity, lintMissingDesc),
+		NewLintRule(MissingXOriginLintRule, InfoSeverity, lintMissingXOrigin),
+		NewLintRule(NameCaseLintRule, WarningSeverity, lintNameCase),
+		NewLintRule(DuplicateNameLintRule, WarningSeverity, lintDuplicateName),
+		NewLintRule(ExampleOIDLintRule, WarningSeverity, lintExampleOID),
+		NewLintRule(ObsoleteReferenceLintRule, WarningSeverity, lintObsoleteReference),
+		NewLintRule(MissingEqualityLintRule, InfoSeverity, lintMissingEquality),
+		NewLintRule(EmptyMustLintRule, InfoSeverity, lintEmptyMust),
+		NewLintRule(UnusedAttributeTypeLintRule, InfoSeverity, lintUnusedAttributeType),
+	)
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state.
+*/
+func (r Linter) IsZero() bool {
+	return r.linter == nil
+}
+
+/*
+Register assigns one (1) or more instances of [LintRule] to the receiver
+instance.  A rule bearing the same identifier as a previously registered
+rule replaces said rule in place.  Nil rules, and those bearing a zero
+identifier, are ignored.
+
+This is a fluent method.
+*/
+func (r Linter) Register(rules ...LintRule) Linter {
+	if r.IsZero() {
+		return r
+	}
+
+	for _, rule := range rules {
+		if rule == nil || len(rule.ID()) == 0 {
+			continue
+		}
+
+		var replaced bool
+		for i, reg := range r.linter.rules {
+			if replaced = reg.ID() == rule.ID(); replaced {
+				r.linter.rules[i] = rule
+				break
+			}
+		}
+
+		if !replaced {
+			r.linter.rules = append(r.linter.rules, rule)
+		}
+	}
+
+	return r
+}
+
+/*
+Rules returns the identifiers of all [LintRule] instances registered within
+the receiver instance, in order of registration.
+*/
+func (r Linter) Rules() (ids []string) {
+	if !r.IsZero() {
+		for _, rule := range r.linter.rules {
+			ids = append(ids, rule.ID())
+		}
+	}
+
+	return
+}
+
+/*
+SetSeverity assigns severity to the rule identified by id, overriding the
+default severity of said rule.  Use of the [DisabledSeverity] constant
+disables the rule.
+
+This is a fluent method.
+*/
+func (r Linter) SetSeverity(id string, severity uint) Linter {
+	if !r.IsZero() {
+		if _, found := lintSeverityLabels[severity]; found {
+			r.linter.severities[id] = severity
+		}
+	}
+
+	return r
+}
+
+/*
+Severity returns the effective severity of the rule identified by id.  A
+value of [DisabledSeverity] is returned if the rule is unregistered.
+*/
+func (r Linter) Severity(id string) (severity uint) {
+	if r.IsZero() {
+		return
+	}
+
+	for _, rule := range r.linter.rules {
+		if rule.ID() == id {
+			severity = rule.Severity()
+			if sev, found := r.linter.severities[id]; found {
+				severity = sev
+			}
+			break
+		}
+	}
+
+	return
+}
+
+/*
+Lint returns an instance of [LintReport] following the execution of all
+enabled rules registered within the receiver instance.
+*/
+func (r Linter) Lint() (report LintReport) {
+	if r.IsZero() || r.linter.schema.IsZero() {
+		return
+	}
+
+	for _, rule := range r.linter.rules {
+		severity := r.Severity(rule.ID())
+		if severity == DisabledSeverity {
+			continue
+		}
+
+		findings := rule.Lint(r.linter.schema)
+		for i := 0; i < findings.Len(); i++ {
+			findings[i].Rule = rule.ID()
+			findings[i].Severity = severity
+			report = append(report, findings[i])
+		}
+	}
+
+	return
+}
+
+/*
+lintFinding returns a [LintFinding] concerning def bearing msg.
+*/
+func lintFinding(def Definition, msg string) LintFinding {
+	return LintFinding{
+		Type:       def.Type(),
+		Definition: def.Identifier(),
+		Message:    msg,
+	}
+}
+
+func lintCompliance(schema Schema) (report LintReport) {
+	violations := schema.Check()
+	for i := 0; i < violations.Len(); i++ {
+		v := violations[i]
+		msg := v.Message() + ` (` + v.Section() + `)`
+		if len(v.Clause) > 0 {
+			clause := v.Clause
+			if len(v.Value) > 0 {
+				clause += ` '` + v.Value + `'`
+			}
+			msg = clause + `: ` + msg
+		}
+
+		report = append(report, LintFinding{
+			Type:       v.Type,
+			Definition: v.Definition,
+			Message:    msg,
+		})
+	}
+
+	return
+}
+
+func lintMissingDesc(schema Schema) (report LintReport) {
+	for _, def := range schema.collectionMembers() {
+		if len(def.Description()) == 0 {
+			report = append(report, lintFinding(def, `DESC clause not specified`))
+		}
+	}
+
+	return
+}
+
+func lintMissingXOrigin(schema Schema) (report LintReport) {
+	for _, def := range schema.collectionMembers() {
+		if !def.Extensions().Exists(`X-ORIGIN`) {
+			report = append(report, lintFinding(def, `X-ORIGIN extension not specified`))
+		}
+	}
+
+	return
+}
+
+func lintNameCase(schema Schema) (report LintReport) {
+	for _, def := range schema.collectionMembers() {
+		names := def.Names()
+		for i := 0; i < names.Len(); i++ {
+			if name := names.Index(i); !isLowerCamelCase(name) {
+				report = append(report, lintFinding(def,
+					`NAME '`+name+`' is not lowerCamelCase`))
+			}
+		}
+	}
+
+	return
+}
+
+/*
+isLowerCamelCase returns a Boolean value indicative of name beginning with
+a lowercase letter and consisting solely of letters and digits.
+*/
+func isLowerCamelCase(name string) bool {
+	if len(name) == 0 || name[0] < 'a' || name[0] > 'z' {
+		return false
+	}
+
+	for _, c := range name {
+		if !isAlnum(c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lintDuplicateName(schema Schema) (report LintReport) {
+	seen := make(map[string]Definition)
+	for _, def := range schema.collectionMembers() {
+		if _, ok := def.(MatchingRuleUse); ok {
+			// MatchingRuleUse instances bear the
+			// names of their MatchingRule by design.
+			continue
+		}
+
+		names := def.Names()
+		for i := 0; i < names.Len(); i++ {
+			name := names.Index(i)
+			if other, found := seen[lc(name)]; found {
+				report = append(report, lintFinding(def, `NAME '`+name+
+					`' also used by `+other.Type()+` '`+other.Identifier()+`'`))
+			} else {
+				seen[lc(name)] = def
+			}
+		}
+	}
+
+	return
+}
+
+func lintExampleOID(schema Schema) (report LintReport) {
+	for _, def := range schema.collectionMembers() {
+		noid := def.NumericOID()
+		for _, arc := range exampleArcs {
+			if noid == arc || hasPfx(noid, arc+`.`) {
+				report = append(report, lintFinding(def, `numeric OID `+
+					noid+` resides under example arc `+arc))
+				break
+			}
+		}
+	}
+
+	return
+}
+
+func lintObsoleteReference(schema Schema) (report LintReport) {
+	for _, def := range schema.collectionMembers() {
+		if !def.Obsolete() {
+			continue
+		}
+
+		for _, dep := range schema.Dependents(def) {
+			if _, ok := dep.(MatchingRuleUse); ok || dep.Obsolete() {
+				continue
+			}
+			report = append(report, lintFinding(dep, `references OBSOLETE `+
+				def.Type()+` '`+def.Identifier()+`'`))
+		}
+	}
+
+	return
+}
+
+func lintMissingEquality(schema Schema) (report LintReport) {
+	ats := schema.AttributeTypes()
+	for i := 0; i < ats.Len(); i++ {
+		if at := ats.Index(i); at.EffectiveEquality().IsZero() {
+			report = append(report, lintFinding(at, `no EQUALITY matching rule in effect`))
+		}
+	}
+
+	return
+}
+
+func lintEmptyMust(schema Schema) (report LintReport) {
+	ocs := schema.ObjectClasses()
+	for i := 0; i < ocs.Len(); i++ {
+		if oc := ocs.Index(i); oc.Must().Len() == 0 {
+			report = append(report, lintFinding(oc, `MUST clause not specified`))
+		}
+	}
+
+	return
+}
+
+func lintUnusedAttributeType(schema Schema) (report LintReport) {
+	ats := schema.AttributeTypes()
+	for i := 0; i < ats.Len(); i++ {
+		at := ats.Index(i)
+		if len(at.Usage()) > 0 || at.Collective() {
+			// operational and collective types are
+			// never present within object classes.
+			continue
+		}
+
+		var used bool
+		for _, dep := range schema.Dependents(at) {
+			if _, ok := dep.(MatchingRuleUse); !ok {
+				used = true
+				break
+			}
+		}
+
+		if !used {
+			report = append(report, lintFinding(at, `not referenced by any other definition`))
+		}
+	}
+
+	return
+}
@@ -0,0 +1,183 @@
+package schemax
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the linting of a [Schema] with select rules
+disabled or adjusted.
+*/
+func ExampleLinter_Lint() {
+	sch := NewSchema()
+	if err := sch.ParseAttributeType(`( 2.999.1
+		NAME 'Example-Attr'
+		DESC 'An example attribute type'
+		EQUALITY caseIgnoreMatch
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15
+		X-ORIGIN 'Example' )`); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	report := sch.NewLinter().
+		SetSeverity(ExampleOIDLintRule, ErrorSeverity).
+		SetSeverity(NameCaseLintRule, DisabledSeverity).
+		Lint()
+
+	fmt.Println(report.Severity(WarningSeverity))
+	// Output: [error] example-oid: attributeType 'Example-Attr': numeric OID 2.999.1 resides under example arc 2.999
+}
+
+/*
+This example demonstrates the registration of a user-authored [LintRule]
+by way of the [NewLintRule] function.
+*/
+func ExampleNewLintRule() {
+	sch := NewSchema()
+	noLegacy := NewLintRule(`no-legacy`, ErrorSeverity, func(s Schema) (report LintReport) {
+		if at := s.AttributeTypes().Get(`uniqueMember`); !at.IsZero() {
+			report = append(report, LintFinding{
+				Type:       at.Type(),
+				Definition: at.Identifier(),
+				Message:    `legacy attribute type present`,
+			})
+		}
+		return
+	})
+
+	linter := sch.NewLinter().Register(noLegacy)
+	fmt.Println(linter.Lint().Rule(`no-legacy`))
+	// Output: [error] no-legacy: attributeType 'uniqueMember': legacy attribute type present
+}
+
+func TestLinter_Lint(t *testing.T) {
+	sch := NewSchema()
+	for idx, raw := range []string{
+		`( 1.3.6.1.4.1.32473.1 NAME 'oldAttr' DESC 'old' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 OBSOLETE X-ORIGIN 'test' )`,
+		`( 1.3.6.1.4.1.56521.999.89.1 NAME 'lintTest' DESC 'x' EQUALITY caseIgnoreMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 X-ORIGIN 'test' )`,
+	} {
+		if err := sch.ParseAttributeType(raw); err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		}
+	}
+
+	if err := sch.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.89.2
+		NAME 'lintTest'
+		SUP top AUXILIARY
+		MAY oldAttr )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	linter := sch.NewLinter()
+	report := linter.Lint()
+
+	for idx, tc := range []struct {
+		rule     string
+		def      string
+		severity uint
+		count    int
+	}{
+		{ComplianceLintRule, ``, ErrorSeverity, 0},
+		{DuplicateNameLintRule, `lintTest`, WarningSeverity, 1},
+		{ExampleOIDLintRule, `oldAttr`, WarningSeverity, 1},
+		{ObsoleteReferenceLintRule, `lintTest`, WarningSeverity, 1},
+		{MissingEqualityLintRule, `oldAttr`, InfoSeverity, 1},
+		{UnusedAttributeTypeLintRule, `lintTest`, InfoSeverity, 1},
+	} {
+		var found LintReport
+		findings := report.Rule(tc.rule)
+		for i := 0; i < findings.Len(); i++ {
+			if findings[i].Definition == tc.def {
+				found = append(found, findings[i])
+			}
+		}
+
+		if found.Len() != tc.count {
+			t.Errorf("%s[%d] failed: want %d %s findings for %q, got %d:\n%s",
+				t.Name(), idx, tc.count, tc.rule, tc.def, found.Len(), findings)
+		} else if tc.count > 0 && found[0].Severity != tc.severity {
+			t.Errorf("%s[%d] failed: want severity %d, got %d",
+				t.Name(), idx, tc.severity, found[0].Severity)
+		}
+	}
+
+	if report.Max() != WarningSeverity {
+		t.Errorf("%s failed: want max severity %d, got %d", t.Name(), WarningSeverity, report.Max())
+	}
+
+	for _, id := range linter.Rules() {
+		linter.SetSeverity(id, DisabledSeverity)
+	}
+	if report = linter.Lint(); !report.IsZero() {
+		t.Errorf("%s failed: unexpected findings from disabled rules:\n%s", t.Name(), report)
+	}
+}
+
+func TestLintReport_MarshalJSON(t *testing.T) {
+	var report LintReport
+	if b, err := json.Marshal(report); err != nil || string(b) != `[]` {
+		t.Errorf("%s failed: unexpected result %s, %v", t.Name(), b, err)
+	}
+
+	report = LintReport{{
+		Rule:       NameCaseLintRule,
+		Severity:   WarningSeverity,
+		Type:       `attributeType`,
+		Definition: `My-Attr`,
+		Message:    `NAME 'My-Attr' is not lowerCamelCase`,
+	}}
+
+	want := `[{"rule":"name-case","severity":"warning","type":"attributeType",` +
+		`"definition":"My-Attr","message":"NAME 'My-Attr' is not lowerCamelCase"}]`
+	if b, err := json.Marshal(report); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+	} else if string(b) != want {
+		t.Errorf("%s failed:\nwant: %s\ngot:  %s", t.Name(), want, b)
+	}
+}
+
+func TestLinter_codecov(t *testing.T) {
+	var linter Linter
+	_ = linter.Register(nil)
+	_ = linter.SetSeverity(NameCaseLintRule, ErrorSeverity)
+	_ = linter.Severity(NameCaseLintRule)
+	_ = linter.Rules()
+	_ = linter.Lint()
+
+	linter = NewSchema().NewLinter()
+	linter.Register(nil, NewLintRule(``, InfoSeverity, nil))
+	if got := len(linter.Rules()); got != 10 {
+		t.Errorf("%s failed: want 10 rules, got %d", t.Name(), got)
+	}
+
+	linter.Register(NewLintRule(NameCaseLintRule, ErrorSeverity, nil))
+	if got := len(linter.Rules()); got != 10 {
+		t.Errorf("%s failed: want 10 rules following replacement, got %d", t.Name(), got)
+	} else if sev := linter.Severity(NameCaseLintRule); sev != ErrorSeverity {
+		t.Errorf("%s failed: want replaced severity %d, got %d", t.Name(), ErrorSeverity, sev)
+	} else if !linter.Lint().Rule(NameCaseLintRule).IsZero() {
+		t.Errorf("%s failed: unexpected findings from nil closure", t.Name())
+	}
+
+	linter.SetSeverity(NameCaseLintRule, 100)
+	if sev := linter.Severity(NameCaseLintRule); sev != ErrorSeverity {
+		t.Errorf("%s failed: invalid severity was accepted", t.Name())
+	}
+	if sev := linter.Severity(`bogus`); sev != DisabledSeverity {
+		t.Errorf("%s failed: want %d for unregistered rule, got %d", t.Name(), DisabledSeverity, sev)
+	}
+
+	for _, name := range []string{``, `Cn`, `c-l`, `0cn`} {
+		if isLowerCamelCase(name) {
+			t.Errorf("%s failed: %q is not lowerCamelCase", t.Name(), name)
+		}
+	}
+
+	_ = lintCompliance(NewEmptySchema())
+	if report := lintCompliance(ditTestSchema(t)); !report.IsZero() {
+		t.Errorf("%s failed: unexpected compliance findings:\n%s", t.Name(), report)
+	}
+}
@@ -15,6 +15,9 @@ E returns the underlying error instance.
 */
 func (r LDAPSyntax) E() (err error) {
 	if !r.IsZero() {
+		runlock := r.lDAPSyntax.schema.rlock()
+		defer runlock()
+
 		err = r.lDAPSyntax.err
 	} else {
 		err = ErrNilReceiver
@@ -75,6 +78,9 @@ func (r LDAPSyntaxes) Len() int {
 }
 
 func (r LDAPSyntaxes) len() int {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
 	return r.cast().Len()
 }
 
@@ -132,7 +138,7 @@ func (r LDAPSyntax) Marshal(def any) error {
 		}
 	}
 
-	if !r.Compliant() {
+	if !r.compliant() {
 		return ErrDefNonCompliant
 	}
 	r.SetStringer()
@@ -156,6 +162,14 @@ func (r LDAPSyntax) SetData(x any) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setData(x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.data = x
 }
 
@@ -191,6 +205,14 @@ func (r LDAPSyntax) SetSchema(schema Schema) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setSchema(schema Schema) *lDAPSyntax {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return r
+	}
+
 	r.schema = schema
 	return r
 }
@@ -241,18 +263,27 @@ instance.
 
 [§ 4.1.5 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.5
 */
-func (r LDAPSyntax) Compliant() bool {
-	if r.IsZero() {
-		return false
-	}
+func (r LDAPSyntax) Compliant() (ok bool) {
+	if ok = r.compliant(); ok {
+		unlock, _ := r.lDAPSyntax.schema.lock()
+		defer unlock()
 
-	ok := isNumericOID(r.lDAPSyntax.OID)
-	if ok {
 		r.lDAPSyntax.err = nil
 	}
 
-	return ok
+	return
+}
 
+/*
+compliant is the side-effect free counterpart of Compliant, for use
+upon paths which merely read the receiver.
+*/
+func (r LDAPSyntax) compliant() bool {
+	if r.IsZero() {
+		return false
+	}
+
+	return isNumericOID(r.lDAPSyntax.OID)
 }
 
 /*
@@ -293,7 +324,7 @@ stringer.
 This is a fluent method and may be used multiple times.
 */
 func (r LDAPSyntax) SetStringer(function ...Stringer) LDAPSyntax {
-	if r.Compliant() {
+	if r.compliant() {
 		r.lDAPSyntax.setStringer(function...)
 	}
 
@@ -306,23 +337,29 @@ func (r *lDAPSyntax) setStringer(function ...Stringer) {
 		stringer = function[0]
 	}
 
+	var err error
 	if stringer == nil {
 		// no user provided closure means we
 		// defer to a general use stringer.
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		} else {
-			r.err = err
+		var str string
+		str, err = r.prepareString() // perform one-time text/template op
+		stringer = func() string {
+			// Return a preserved value.
+			return str
 		}
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	} else if err != nil {
+		r.err = err
 		return
 	}
 
-	// assign user-provided closure
 	r.stringer = stringer
 }
 
@@ -337,7 +374,7 @@ func (r LDAPSyntaxes) XOrigin(x string) (defs LDAPSyntaxes) {
 		def := r.Index(i)
 		if xo, found := def.Extensions().Get(`X-ORIGIN`); found {
 			if xo.Contains(x) {
-				defs.push(def)
+				defs.cast().Push(def)
 			}
 		}
 	}
@@ -450,8 +487,8 @@ func (r LDAPSyntaxes) canPush(x ...any) (err error) {
 		instance := x[i]
 		if ls, ok := instance.(LDAPSyntax); !ok || ls.IsZero() {
 			err = ErrTypeAssert
-		} else if tst := r.get(ls.NumericOID()); !tst.IsZero() {
-			err = mkerr(ErrNotUnique.Error() + ": " + ls.Type() + `, ` + ls.NumericOID())
+		} else if tst := r.lookup(ls.NumericOID()); !tst.IsZero() {
+			err = wraperr(ErrNotUnique, ": "+ls.Type()+`, `+ls.NumericOID())
 		}
 	}
 
@@ -476,6 +513,14 @@ func (r LDAPSyntax) SetNumericOID(id string) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setNumericOID(id string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
@@ -516,6 +561,14 @@ func (r LDAPSyntax) SetExtension(x string, xstrs ...string) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setExtension(x string, xstrs ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -599,7 +652,15 @@ func (r LDAPSyntax) SetDescription(desc string) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setDescription(desc string) {
-	if len(desc) < 3 {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if len(desc) == 0 {
 		return
 	}
 
@@ -607,13 +668,13 @@ func (r *lDAPSyntax) setDescription(desc string) {
 		desc = desc[1:]
 	}
 
-	if rune(desc[len(desc)-1]) == rune(39) {
+	if len(desc) > 0 && rune(desc[len(desc)-1]) == rune(39) {
 		desc = desc[:len(desc)-1]
 	}
 
 	r.Desc = desc
 
-	return
+	r.schema.reindex(LDAPSyntax{r})
 }
 
 /*
@@ -641,22 +702,48 @@ func (r LDAPSyntaxes) Get(id string) LDAPSyntax {
 	return r.get(id)
 }
 
-func (r LDAPSyntaxes) get(id string) (ls LDAPSyntax) {
-	for i := 0; i < r.len() && ls.IsZero(); i++ {
-		if _ls := r.index(i); !_ls.IsZero() {
-			if eq(_ls.lDAPSyntax.OID, id) {
-				ls = _ls
-			} else {
-				if desc := repAll(_ls.lDAPSyntax.Desc, ` `, ``); eq(desc, id) {
-					ls = _ls
-				}
-			}
+func (r LDAPSyntaxes) get(id string) LDAPSyntax {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.lookup(id)
+}
+
+/*
+lookup is the unlocked counterpart of get, for use where the lock is
+already held, such as during a push.
+*/
+func (r LDAPSyntaxes) lookup(id string) (ls LDAPSyntax) {
+	if slice, indexed := indexLookup(r.cast(), id, func(x any) bool {
+		_ls, _ := x.(LDAPSyntax)
+		return _ls.identifiedBy(id)
+	}); indexed {
+		ls, _ = slice.(LDAPSyntax)
+		return
+	}
+
+	for i := 0; i < r.cast().Len() && ls.IsZero(); i++ {
+		if _ls := r.at(i); _ls.identifiedBy(id) {
+			ls = _ls
 		}
 	}
 
 	return
 }
 
+/*
+identifiedBy returns a Boolean value indicative of whether id matches
+the numeric OID or the space-stripped description of the receiver.
+*/
+func (r LDAPSyntax) identifiedBy(id string) (ident bool) {
+	if !r.IsZero() {
+		ident = eq(r.lDAPSyntax.OID, id) ||
+			eq(repAll(r.lDAPSyntax.Desc, ` `, ``), id)
+	}
+
+	return
+}
+
 /*
 NumericOID returns the string representation of the numeric OID
 held by the receiver instance.
@@ -678,7 +765,17 @@ func (r LDAPSyntaxes) Index(idx int) LDAPSyntax {
 	return r.index(idx)
 }
 
-func (r LDAPSyntaxes) index(idx int) (ls LDAPSyntax) {
+func (r LDAPSyntaxes) index(idx int) LDAPSyntax {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.at(idx)
+}
+
+/*
+at is the unlocked counterpart of index.
+*/
+func (r LDAPSyntaxes) at(idx int) (ls LDAPSyntax) {
 	slice, found := r.cast().Index(idx)
 	if found {
 		if _ls, ok := slice.(LDAPSyntax); ok {
@@ -693,18 +790,22 @@ func (r LDAPSyntaxes) index(idx int) (ls LDAPSyntax) {
 Push returns an error following an attempt to push an [LDAPSyntax]
 into the receiver stack instance.
 */
-func (r LDAPSyntaxes) Push(ls any) error {
-	return r.push(ls)
+func (r LDAPSyntaxes) Push(ls any) (err error) {
+	if err = r.push(ls); err == nil {
+		pushed(r, ls)
+	}
+
+	return
 }
 
 func (r LDAPSyntaxes) push(x any) (err error) {
 	switch tv := x.(type) {
 	case LDAPSyntax:
-		if !tv.Compliant() {
+		if !tv.compliant() {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		err = lockedPush(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -831,7 +932,7 @@ func (r LDAPSyntax) Replace(x LDAPSyntax) LDAPSyntax {
 		return r
 	}
 
-	if !r.IsZero() && x.Compliant() {
+	if !r.IsZero() && x.compliant() {
 		r.lDAPSyntax.replace(x)
 	}
 
@@ -839,6 +940,15 @@ func (r LDAPSyntax) Replace(x LDAPSyntax) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) replace(x LDAPSyntax) {
+	sch := r.schema
+	unlock, ok := sch.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if r.OID == `` {
 		r.err = ErrMissingNumericOID
 		return
@@ -855,6 +965,29 @@ func (r *lDAPSyntax) replace(x LDAPSyntax) {
 	r.stringer = x.lDAPSyntax.stringer
 	r.synQual = x.lDAPSyntax.synQual
 	r.data = x.lDAPSyntax.data
+
+	sch.reindex(LDAPSyntax{r})
+}
+
+/*
+Clone returns a deep copy of the receiver instance.  Modification of
+the return instance -- such as by way of the [LDAPSyntax.SetDescription]
+method -- shall not influence the receiver instance.
+
+Note that use of the [LDAPSyntax.SetStringer] method may be needed
+following any changes to the return instance.
+*/
+func (r LDAPSyntax) Clone() (c LDAPSyntax) {
+	if r.IsZero() {
+		return
+	}
+
+	_c := *r.lDAPSyntax
+	c = LDAPSyntax{&_c}
+	_c.Macro = cloneStrings(r.lDAPSyntax.Macro)
+	_c.Extensions = cloneExtensions(r.lDAPSyntax.Extensions, c)
+
+	return
 }
 
 /*
@@ -915,6 +1048,14 @@ func (r LDAPSyntax) SetSyntaxQualifier(function SyntaxQualifier) LDAPSyntax {
 }
 
 func (r *lDAPSyntax) setSyntaxQualifier(function SyntaxQualifier) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.synQual = function
 }
 
@@ -1026,6 +1167,10 @@ func (r Schema) loadRFC4517Syntaxes() (err error) {
 		}
 	}
 
+	if err == nil && r.Options().Positive(SyntaxQualifiers) {
+		r.assignRFC4517SyntaxQualifiers()
+	}
+
 	return
 }
 
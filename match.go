@@ -0,0 +1,596 @@
+package schemax
+
+/*
+match.go contains the built-in AssertionMatcher implementations for the
+matching rules defined within RFC 4517 Section 4.2.
+*/
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"time"
+	"unicode"
+)
+
+/*
+rfc4517AssertionMatchers maps the numeric OIDs of RFC 4517 matching rules
+to their respective [AssertionMatcher] implementations.  Rules which
+operate upon prepared strings reside within rfc4517PreparedMatchers.
+*/
+var rfc4517AssertionMatchers map[string]AssertionMatcher
+
+/*
+rfc4517PreparedMatchers maps the numeric OIDs of RFC 4517 matching rules
+which operate upon prepared strings to functions which return the
+respective [AssertionMatcher] implementations, bound to the input
+[StringPreparer] instance.
+*/
+var rfc4517PreparedMatchers map[string]func(StringPreparer) AssertionMatcher
+
+func init() {
+	rfc4517AssertionMatchers = map[string]AssertionMatcher{
+		`2.5.13.0`:  newAssertionMatcher(checkOID, checkOID, matchOID),
+		`2.5.13.1`:  newAssertionMatcher(checkDN, checkDN, matchDN),
+		`2.5.13.13`: newAssertionMatcher(checkBoolean, checkBoolean, func(a, b string) bool { return a == b }),
+		`2.5.13.14`: newAssertionMatcher(checkInteger, checkInteger, func(a, b string) bool { return compareIntegers(a, b) == 0 }),
+		`2.5.13.15`: newAssertionMatcher(checkInteger, checkInteger, func(a, b string) bool { return compareIntegers(a, b) < 0 }),
+		`2.5.13.16`: newAssertionMatcher(checkBitString, checkBitString, func(a, b string) bool { return a == b }),
+		`2.5.13.17`: newAssertionMatcher(checkOctets, checkOctets, func(a, b string) bool { return a == b }),
+		`2.5.13.18`: newAssertionMatcher(checkOctets, checkOctets, func(a, b string) bool { return bytes.Compare([]byte(a), []byte(b)) < 0 }),
+		`2.5.13.23`: newAssertionMatcher(checkNameAndOptionalUID, checkNameAndOptionalUID, matchUniqueMember),
+		`2.5.13.27`: newAssertionMatcher(checkGeneralizedTime, checkGeneralizedTime, func(a, b string) bool { return compareGeneralizedTimes(a, b) == 0 }),
+		`2.5.13.28`: newAssertionMatcher(checkGeneralizedTime, checkGeneralizedTime, func(a, b string) bool { return compareGeneralizedTimes(a, b) < 0 }),
+		`2.5.13.29`: newAssertionMatcher(checkFirstComponent, checkInteger, matchIntegerFirstComponent),
+		`2.5.13.30`: newAssertionMatcher(checkFirstComponent, checkOID, matchOIDFirstComponent),
+	}
+
+	rfc4517PreparedMatchers = map[string]func(StringPreparer) AssertionMatcher{
+		`2.5.13.2`:  equalityMatcher(checkDirectoryString),
+		`2.5.13.3`:  orderingMatcher(checkDirectoryString),
+		`2.5.13.4`:  substringsMatcher(checkDirectoryString),
+		`2.5.13.5`:  equalityMatcher(checkDirectoryString),
+		`2.5.13.6`:  orderingMatcher(checkDirectoryString),
+		`2.5.13.7`:  substringsMatcher(checkDirectoryString),
+		`2.5.13.8`:  equalityMatcher(checkNumericString),
+		`2.5.13.9`:  orderingMatcher(checkNumericString),
+		`2.5.13.10`: substringsMatcher(checkNumericString),
+		`2.5.13.11`: newPreparedMatcher(checkPostalAddress, checkPostalAddress, matchCaseIgnoreList),
+		`2.5.13.12`: newPreparedMatcher(checkPostalAddress, checkSubstringAssertion, matchCaseIgnoreListSubstrings),
+		`2.5.13.20`: equalityMatcher(checkTelephoneNumber),
+		`2.5.13.21`: substringsMatcher(checkTelephoneNumber),
+		`2.5.13.31`: newPreparedMatcher(checkFirstComponent, checkDirectoryString, matchDirectoryStringFirstComponent),
+		`2.5.13.32`: newPreparedMatcher(checkDirectoryString, checkDirectoryString, matchWord(unicode.IsSpace)),
+		`2.5.13.33`: newPreparedMatcher(checkDirectoryString, checkDirectoryString, matchWord(isKeywordDelim)),
+
+		`1.3.6.1.4.1.1466.109.114.1`: equalityMatcher(checkIA5String),
+		`1.3.6.1.4.1.1466.109.114.2`: equalityMatcher(checkIA5String),
+		`1.3.6.1.4.1.1466.109.114.3`: substringsMatcher(checkIA5String),
+	}
+}
+
+/*
+RFC4517AssertionMatcher returns the built-in [AssertionMatcher] for the RFC
+4517 matching rule identified by the numeric OID oid, or nil if no such
+matcher exists.
+
+Matchers accept string and []byte values.  The first value is understood
+to be the attribute value, while the second is the assertion value.  The
+returned error is interpreted as follows:
+
+  - nil indicates the rule evaluated to TRUE
+  - [ErrNoMatch] indicates the rule evaluated to FALSE
+  - [ErrInvalidSyntax] indicates the rule evaluated to Undefined, as one or both values is malformed
+  - [ErrInvalidType] indicates one or both values is of an unsupported type
+
+Ordering rules evaluate to TRUE when the attribute value is less than the
+assertion value.  Substrings rules expect an assertion value that honors
+the Substring Assertion syntax, e.g.: "jess*cor*".
+
+String values are prepared in the manner described by RFC 4518, by way of
+the [StringPreparer] returned by [RFC4518Preparer] for the rule in question.
+
+Matchers are provided for all RFC 4517 matching rules except for the
+presentationAddressMatch and protocolInformationMatch rules, which were
+obsoleted by RFC 4517.
+
+Note that matchers are, by nature, unaware of the [Schema] in which their
+respective rules reside.  Thus, objectIdentifierMatch will only consider
+like forms (numeric OID or descriptor) to be equal -- unless invoked through
+[Schema.MatchFilter], which resolves descriptors beforehand -- and the values
+of the distinguishedNameMatch and uniqueMemberMatch rules are compared
+according to caseIgnoreMatch.
+
+See also the [AssertionMatchers] option, which assigns these matchers
+automatically.
+*/
+func RFC4517AssertionMatcher(oid string) AssertionMatcher {
+	if funk, found := rfc4517PreparedMatchers[oid]; found {
+		return funk(rfc4518Preparers[oid])
+	}
+
+	return rfc4517AssertionMatchers[oid]
+}
+
+/*
+assignRFC4517AssertionMatchers assigns the built-in RFC 4517 matchers to
+each applicable [MatchingRule] within the receiver instance which does not
+already bear a matcher.
+
+Matchers which operate upon prepared strings are bound to the [StringPreparer]
+of their respective rule -- which is assigned here if absent -- such that a
+subsequent call of [MatchingRule.SetStringPreparer] shall take effect.
+*/
+func (r Schema) assignRFC4517AssertionMatchers() {
+	mrs := r.MatchingRules()
+	for i := 0; i < mrs.Len(); i++ {
+		mr := mrs.Index(i)
+		if mr.matchingRule.assMatch != nil {
+			continue
+		}
+		oid := mr.NumericOID()
+		if funk, found := rfc4517PreparedMatchers[oid]; found {
+			if mr.matchingRule.prep == nil {
+				mr.SetStringPreparer(rfc4518Preparers[oid])
+			}
+			mr.SetAssertionMatcher(funk(mr.Prepare))
+		} else if funk, found := rfc4517AssertionMatchers[oid]; found {
+			mr.SetAssertionMatcher(funk)
+		}
+	}
+}
+
+/*
+newAssertionMatcher returns an [AssertionMatcher] which submits the string
+forms of its attribute and assertion values to checkValue and checkAssertion
+respectively, and which then returns [ErrNoMatch] should match return false.
+*/
+func newAssertionMatcher(checkValue, checkAssertion func(string) error,
+	match func(string, string) bool) AssertionMatcher {
+
+	return func(x, y any) (err error) {
+		var a, b string
+		if a, err = assertSyntaxValue(x); err != nil {
+			return
+		} else if b, err = assertSyntaxValue(y); err != nil {
+			return
+		}
+
+		if err = checkValue(a); err == nil {
+			if err = checkAssertion(b); err == nil && !match(a, b) {
+				err = ErrNoMatch
+			}
+		}
+
+		return
+	}
+}
+
+/*
+newPreparedMatcher returns a function which returns an [AssertionMatcher],
+bound to the input [StringPreparer], which submits the string forms of its
+attribute and assertion values to checkValue and checkAssertion respectively,
+and which then returns [ErrNoMatch] should match return false.
+*/
+func newPreparedMatcher(checkValue, checkAssertion func(string) error,
+	match func(string, string, StringPreparer) (bool, error)) func(StringPreparer) AssertionMatcher {
+
+	return func(prep StringPreparer) AssertionMatcher {
+		return func(x, y any) (err error) {
+			var a, b string
+			if a, err = assertSyntaxValue(x); err != nil {
+				return
+			} else if b, err = assertSyntaxValue(y); err != nil {
+				return
+			}
+
+			if err = checkValue(a); err == nil {
+				if err = checkAssertion(b); err == nil {
+					var ok bool
+					if ok, err = match(a, b, prep); err == nil && !ok {
+						err = ErrNoMatch
+					}
+				}
+			}
+
+			return
+		}
+	}
+}
+
+/*
+prepareValues returns the prepared forms of a and b, alongside an error.
+*/
+func prepareValues(a, b string, prep StringPreparer) (x, y string, err error) {
+	if prep == nil {
+		err = ErrNilStringPreparer
+	} else if x, err = prep(a, ValuePrep); err == nil {
+		y, err = prep(b, ValuePrep)
+	}
+
+	return
+}
+
+/*
+equalityMatcher returns a function which returns an [AssertionMatcher]
+that compares prepared values for equality.
+*/
+func equalityMatcher(check func(string) error) func(StringPreparer) AssertionMatcher {
+	return newPreparedMatcher(check, check, func(a, b string, prep StringPreparer) (bool, error) {
+		x, y, err := prepareValues(a, b, prep)
+		return x == y, err
+	})
+}
+
+/*
+orderingMatcher returns a function which returns an [AssertionMatcher]
+that evaluates to TRUE when the prepared attribute value collates before
+the prepared assertion value.
+*/
+func orderingMatcher(check func(string) error) func(StringPreparer) AssertionMatcher {
+	return newPreparedMatcher(check, check, func(a, b string, prep StringPreparer) (bool, error) {
+		x, y, err := prepareValues(a, b, prep)
+		return x < y, err
+	})
+}
+
+/*
+substringsMatcher returns a function which returns an [AssertionMatcher]
+that evaluates the prepared attribute value against the prepared
+components of a Substring Assertion value.
+*/
+func substringsMatcher(check func(string) error) func(StringPreparer) AssertionMatcher {
+	return newPreparedMatcher(check, checkSubstringAssertion, func(a, b string, prep StringPreparer) (bool, error) {
+		if prep == nil {
+			return false, ErrNilStringPreparer
+		}
+
+		v, err := prep(a, ValuePrep)
+		if err != nil {
+			return false, err
+		}
+
+		return matchSubstrings(v, b, prep)
+	})
+}
+
+func isKeywordDelim(c rune) bool {
+	return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+}
+
+/*
+splitSubstringAssertion returns the initial, any and final components of
+the Substring Assertion value s, less their escapes.
+*/
+func splitSubstringAssertion(s string) (initial string, any []string, final string) {
+	parts := split(s, `*`)
+	initial = unescapeRDNValue(parts[0])
+	final = unescapeRDNValue(parts[len(parts)-1])
+	for _, part := range parts[1 : len(parts)-1] {
+		any = append(any, unescapeRDNValue(part))
+	}
+
+	return
+}
+
+/*
+matchSubstrings returns a Boolean value indicative of the prepared value v
+matching the Substring Assertion value s, the components of which are
+prepared through prep, alongside an error.
+*/
+func matchSubstrings(v, s string, prep StringPreparer) (bool, error) {
+	initial, any, final := splitSubstringAssertion(s)
+
+	var err error
+	var pos int
+	if len(initial) > 0 {
+		if initial, err = prep(initial, InitialPrep); err != nil || !hasPfx(v, initial) {
+			return false, err
+		}
+		pos = len(initial)
+	}
+
+	for _, a := range any {
+		if a, err = prep(a, AnyPrep); err != nil {
+			return false, err
+		}
+		idx := stridx(v[pos:], a)
+		if idx == -1 {
+			return false, nil
+		}
+		pos += idx + len(a)
+	}
+
+	if len(final) > 0 {
+		if final, err = prep(final, FinalPrep); err != nil {
+			return false, err
+		}
+		return len(v)-len(final) >= pos && hasSfx(v, final), nil
+	}
+
+	return true, nil
+}
+
+func checkOctets(s string) error {
+	return qualifyOctets(s)
+}
+
+/*
+matchOID compares two OIDs.  Numeric OIDs are compared literally, while
+descriptors are compared without regard for case.
+*/
+func matchOID(a, b string) bool {
+	if isNumericOID(a) && isNumericOID(b) {
+		return a == b
+	}
+
+	return eq(a, b)
+}
+
+/*
+compareIntegers returns the result of a comparison of two RFC 4517
+INTEGER values: -1 if a < b, 0 if a == b and +1 if a > b.
+*/
+func compareIntegers(a, b string) int {
+	x, _ := new(big.Int).SetString(a, 10)
+	y, _ := new(big.Int).SetString(b, 10)
+
+	return x.Cmp(y)
+}
+
+/*
+compareGeneralizedTimes returns the result of a comparison of the
+instants described by two RFC 4517 Generalized Time values: -1 if a
+is earlier than b, 0 if equal and +1 if a is later than b.
+*/
+func compareGeneralizedTimes(a, b string) int {
+	x, _ := parseGeneralizedTime(a)
+	y, _ := parseGeneralizedTime(b)
+
+	return x.Compare(y)
+}
+
+/*
+parseGeneralizedTime returns an instance of [time.Time] alongside an error
+following an attempt to parse s as an RFC 4517 Generalized Time value.
+*/
+func parseGeneralizedTime(s string) (t time.Time, err error) {
+	if err = checkGeneralizedTime(s); err != nil {
+		return
+	}
+
+	num := func(s string) int { n, _ := atoi(s); return n }
+	year, month, day, hour := num(s[:4]), num(s[4:6]), num(s[6:8]), num(s[8:10])
+
+	var min, sec int
+	unit := time.Hour
+	rest := s[10:]
+	if len(rest) >= 2 && isDigits(rest[:2]) {
+		min, unit, rest = num(rest[:2]), time.Minute, rest[2:]
+		if len(rest) >= 2 && isDigits(rest[:2]) {
+			sec, unit, rest = num(rest[:2]), time.Second, rest[2:]
+		}
+	}
+
+	// the fraction, if any, applies to the smallest unit present
+	var frac time.Duration
+	if rest[0] == '.' || rest[0] == ',' {
+		var n int
+		for n = 1; '0' <= rest[n] && rest[n] <= '9'; n++ {
+		}
+		f := 0.0
+		for i := n - 1; i > 0; i-- {
+			f = (f + float64(rest[i]-'0')) / 10
+		}
+		frac = time.Duration(f * float64(unit))
+		rest = rest[n:]
+	}
+
+	loc := time.UTC
+	if rest != `Z` {
+		offset := (num(rest[1:3]) * 60) * 60
+		if len(rest) == 5 {
+			offset += num(rest[3:5]) * 60
+		}
+		if rest[0] == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone(``, offset)
+	}
+
+	t = time.Date(year, time.Month(month), day, hour, min, sec, 0, loc).Add(frac)
+
+	return
+}
+
+/*
+matchDN returns a Boolean value indicative of two distinguished names
+being equal.  Attribute types are compared without regard for case and
+values are compared according to caseIgnoreMatch.  The order of values
+within a multi-valued RDN is not significant.
+*/
+func matchDN(a, b string) bool {
+	x, _ := splitDN(a)
+	y, _ := splitDN(b)
+	if len(x) != len(y) {
+		return false
+	}
+
+	for i := range x {
+		if len(x[i]) != len(y[i]) {
+			return false
+		}
+
+		rx, ry := prepareRDN(x[i]), prepareRDN(y[i])
+		for j := range rx {
+			if rx[j] != ry[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+/*
+prepareRDN returns the attribute type and value pairs of rdn in prepared,
+sorted string form.
+*/
+func prepareRDN(rdn [][2]string) (avas []string) {
+	for _, ava := range rdn {
+		val := ava[1]
+		if hasPfx(val, `#`) {
+			val = lc(val)
+		} else if prepared, err := CaseIgnorePrep(unescapeRDNValue(val), ValuePrep); err == nil {
+			val = prepared
+		}
+		avas = append(avas, lc(ava[0])+`=`+val)
+	}
+	sort.Strings(avas)
+
+	return
+}
+
+/*
+matchUniqueMember returns a Boolean value indicative of two Name And
+Optional UID values being equal, per RFC 4517 Section 4.2.31.
+*/
+func matchUniqueMember(a, b string) bool {
+	dnA, uidA := splitNameAndOptionalUID(a)
+	dnB, uidB := splitNameAndOptionalUID(b)
+
+	return uidA == uidB && matchDN(dnA, dnB)
+}
+
+/*
+splitNameAndOptionalUID returns the DN and optional UID of s.
+*/
+func splitNameAndOptionalUID(s string) (dn, uid string) {
+	dn = s
+	if idx := lastIndexByte(s, '#'); idx != -1 && hasSfx(s, `'B`) {
+		if err := checkBitString(s[idx+1:]); err == nil {
+			dn, uid = s[:idx], s[idx+1:]
+		}
+	}
+
+	return
+}
+
+/*
+splitPostalAddress returns the lines of the Postal Address value s, less
+their escapes.
+*/
+func splitPostalAddress(s string) (lines []string) {
+	for _, line := range split(s, `$`) {
+		lines = append(lines, unescapeRDNValue(line))
+	}
+
+	return
+}
+
+/*
+matchCaseIgnoreList returns a Boolean value indicative of two Postal
+Address values bearing the same lines once prepared, alongside an error.
+*/
+func matchCaseIgnoreList(a, b string, prep StringPreparer) (bool, error) {
+	x, y := splitPostalAddress(a), splitPostalAddress(b)
+	if len(x) != len(y) {
+		return false, nil
+	}
+
+	for i := range x {
+		if p, q, err := prepareValues(x[i], y[i], prep); err != nil || p != q {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+/*
+matchCaseIgnoreListSubstrings returns a Boolean value indicative of the
+Postal Address value a matching the Substring Assertion value b, per RFC
+4517 Section 4.2.12, alongside an error.  No substring may span more than
+one line.
+*/
+func matchCaseIgnoreListSubstrings(a, b string, prep StringPreparer) (bool, error) {
+	if prep == nil {
+		return false, ErrNilStringPreparer
+	}
+
+	var lines []string
+	for _, line := range splitPostalAddress(a) {
+		prepared, err := prep(line, ValuePrep)
+		if err != nil {
+			return false, err
+		}
+		lines = append(lines, prepared)
+	}
+
+	// NUL is mapped to nothing during preparation, and thus
+	// cannot appear within any prepared substring.
+	return matchSubstrings(join(lines, string(rune(0))), b, prep)
+}
+
+/*
+matchWord returns a closure which returns a Boolean value indicative of
+the prepared assertion value matching any of the prepared words of the
+attribute value as delimited by delim, alongside an error.
+*/
+func matchWord(delim func(rune) bool) func(string, string, StringPreparer) (bool, error) {
+	return func(a, b string, prep StringPreparer) (bool, error) {
+		for _, word := range fieldsFunc(a, delim) {
+			if x, y, err := prepareValues(word, b, prep); err != nil || x == y {
+				return err == nil, err
+			}
+		}
+
+		return false, nil
+	}
+}
+
+/*
+firstComponent returns the first component of the parenthetical
+description s, less any enclosing single quotes.
+*/
+func firstComponent(s string) (comp string) {
+	s = trimS(s)
+	if !hasPfx(s, `(`) {
+		return
+	}
+
+	s = trimS(s[1:])
+	if hasPfx(s, `'`) {
+		if idx := stridx(s[1:], `'`); idx != -1 {
+			comp = s[1 : idx+1]
+		}
+	} else if fields := fieldsFunc(s, func(c rune) bool {
+		return unicode.IsSpace(c) || c == ')'
+	}); len(fields) > 0 {
+		comp = fields[0]
+	}
+
+	return
+}
+
+func checkFirstComponent(s string) (err error) {
+	if len(firstComponent(s)) == 0 {
+		err = syntaxErr(`missing first component`)
+	}
+
+	return
+}
+
+func matchIntegerFirstComponent(a, b string) bool {
+	comp := firstComponent(a)
+	return checkInteger(comp) == nil && compareIntegers(comp, b) == 0
+}
+
+func matchOIDFirstComponent(a, b string) bool {
+	return matchOID(firstComponent(a), b)
+}
+
+func matchDirectoryStringFirstComponent(a, b string, prep StringPreparer) (bool, error) {
+	x, y, err := prepareValues(firstComponent(a), b, prep)
+	return x == y, err
+}
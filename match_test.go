@@ -0,0 +1,258 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the automatic assignment of the built-in RFC
+4517 assertion matchers through use of the [AssertionMatchers] option,
+and their subsequent use through an [AttributeType].
+*/
+func ExampleAssertionMatchers() {
+	sch := NewSchema(AssertionMatchers)
+
+	cn := sch.AttributeTypes().Get(`cn`)
+	fmt.Println(cn.EqualityAssertion(`Jesse  Coretta`, ` jesse coretta`))
+	fmt.Println(cn.SubstringAssertion(`Jesse Coretta`, `jess*cor*`))
+	fmt.Println(cn.EqualityAssertion(`Jesse`, `Courtney`))
+	// Output:
+	// <nil>
+	// <nil>
+	// Values do not match according to prescribed assertion match
+}
+
+/*
+This example demonstrates the manual assignment of a built-in RFC 4517
+assertion matcher to a [MatchingRule] instance.
+*/
+func ExampleRFC4517AssertionMatcher() {
+	sch := NewSchema()
+
+	mr := sch.MatchingRules().Get(`generalizedTimeOrderingMatch`)
+	mr.SetAssertionMatcher(RFC4517AssertionMatcher(mr.NumericOID()))
+
+	// TRUE if the attribute value is earlier than the assertion value
+	fmt.Println(mr.Assertion(`20240101000000Z`, `20240101003000+0100`))
+	// Output: Values do not match according to prescribed assertion match
+}
+
+func TestRFC4517AssertionMatcher(t *testing.T) {
+	sch := NewSchema()
+	for _, tc := range []struct {
+		mr      string
+		matches [][2]any
+		misses  [][2]any
+		invalid [][2]any
+	}{
+		{`objectIdentifierMatch`,
+			[][2]any{{`2.5.4.3`, `2.5.4.3`}, {`cn`, `CN`}},
+			[][2]any{{`2.5.4.3`, `2.5.4.4`}, {`cn`, `2.5.4.3`}},
+			[][2]any{{`2.5.4.`, `cn`}}},
+		{`distinguishedNameMatch`,
+			[][2]any{
+				{`uid=jesse,dc=example,dc=com`, `UID=Jesse,DC=Example,DC=COM`},
+				{`cn=Jesse  Coretta+uid=jesse,dc=com`, `uid=jesse+cn=jesse coretta,dc=com`},
+				{`cn=Smith\, John,dc=com`, `cn=smith\2C john,dc=com`},
+				{`cn=#04024869`, `cn=#04024869`},
+				{``, ``}},
+			[][2]any{
+				{`uid=jesse,dc=example,dc=com`, `uid=jesse,dc=example`},
+				{`uid=jesse+cn=jc,dc=com`, `uid=jesse,dc=com`},
+				{`uid=jesse,dc=com`, `uid=courtney,dc=com`}},
+			[][2]any{{`uid=jesse,`, `uid=jesse`}}},
+		{`caseIgnoreMatch`,
+			[][2]any{{`Jesse Coretta`, `  jesse   CORETTA `}, {`ÉCOLE`, `école`},
+				{[]byte(`Jesse`), `jesse`}, {"Jesse Coretta", `jesse coretta`},
+				{"Jes­se", `jesse`}},
+			[][2]any{{`Jesse`, `Jesse Coretta`}, {`JesseCoretta`, `Jesse Coretta`}},
+			[][2]any{{``, `jesse`}}},
+		{`caseIgnoreOrderingMatch`,
+			[][2]any{{`abc`, `ABD`}},
+			[][2]any{{`abd`, `ABC`}, {`abc`, `ABC`}},
+			nil},
+		{`caseIgnoreSubstringsMatch`,
+			[][2]any{{`Jesse Coretta`, `jess*`}, {`Jesse Coretta`, `*CORETTA`},
+				{`Jesse Coretta`, `*e c*`}, {`Jesse Coretta`, `j*s*e*a`},
+				{`Jesse Coretta`, `*`}, {`a*b`, `a\2A*`}},
+			[][2]any{{`Jesse Coretta`, `esse*`}, {`Jesse Coretta`, `*Corett`},
+				{`Jesse Coretta`, `jesse c*e c*`}, {`Jesse`, `jes*sse`}},
+			[][2]any{{`Jesse`, `jesse`}}},
+		{`caseExactMatch`,
+			[][2]any{{`Jesse  Coretta`, `Jesse Coretta`}},
+			[][2]any{{`Jesse`, `jesse`}},
+			nil},
+		{`caseExactOrderingMatch`,
+			[][2]any{{`Jesse`, `jesse`}},
+			[][2]any{{`jesse`, `Jesse`}},
+			nil},
+		{`caseExactSubstringsMatch`,
+			[][2]any{{`Jesse`, `J*e`}},
+			[][2]any{{`Jesse`, `j*e`}},
+			nil},
+		{`numericStringMatch`,
+			[][2]any{{`15 079 672 281`, `15079672281`}},
+			[][2]any{{`15 079`, `15 078`}},
+			[][2]any{{`15-079`, `15079`}}},
+		{`numericStringOrderingMatch`,
+			[][2]any{{`1 5`, `16`}},
+			[][2]any{{`16`, `15`}},
+			nil},
+		{`numericStringSubstringsMatch`,
+			[][2]any{{`15 079 672 281`, `150*281`}},
+			[][2]any{{`15 079 672 281`, `*0796*73`}},
+			nil},
+		{`caseIgnoreListMatch`,
+			[][2]any{{`1234 Main St.$Anytown, CA 12345$USA`, `1234  main st.$ANYTOWN, CA 12345$usa`}},
+			[][2]any{{`1234 Main St.$USA`, `1234 Main St.$Anytown$USA`},
+				{`1234 Main St.$USA`, `1234 Main St.$Canada`}},
+			[][2]any{{`1234 Main St.$$USA`, `USA`}}},
+		{`caseIgnoreListSubstringsMatch`,
+			[][2]any{{`1234 Main St.$Anytown, CA 12345$USA`, `1234*anytown*usa`},
+				{`1234 Main St.$Anytown, CA 12345$USA`, `*ca 1*`}},
+			[][2]any{{`1234 Main St.$Anytown, CA 12345$USA`, `*st.anytown*`},
+				{`1234 Main St.$Anytown, CA 12345$USA`, `*st. anytown*`}},
+			nil},
+		{`booleanMatch`,
+			[][2]any{{`TRUE`, `TRUE`}},
+			[][2]any{{`TRUE`, `FALSE`}},
+			[][2]any{{`TRUE`, `true`}}},
+		{`integerMatch`,
+			[][2]any{{`123456789012345678901234567890`, `123456789012345678901234567890`}},
+			[][2]any{{`-1`, `1`}},
+			[][2]any{{`01`, `1`}}},
+		{`integerOrderingMatch`,
+			[][2]any{{`-10`, `-9`}, {`9`, `10`}},
+			[][2]any{{`10`, `9`}, {`10`, `10`}},
+			nil},
+		{`bitStringMatch`,
+			[][2]any{{`'0101'B`, `'0101'B`}},
+			[][2]any{{`'0101'B`, `'01010'B`}},
+			[][2]any{{`'0102'B`, `'0101'B`}}},
+		{`octetStringMatch`,
+			[][2]any{{[]byte{0x00, 0x01}, []byte{0x00, 0x01}}},
+			[][2]any{{`a`, `A`}},
+			[][2]any{{``, `a`}}},
+		{`octetStringOrderingMatch`,
+			[][2]any{{`ab`, `abc`}, {[]byte{0x01}, []byte{0x02}}},
+			[][2]any{{`b`, `abc`}},
+			nil},
+		{`telephoneNumberMatch`,
+			[][2]any{{`+1 512-315-0280`, `+15123150280`}},
+			[][2]any{{`+1 512 315 0280`, `+1 512 315 0281`}},
+			nil},
+		{`telephoneNumberSubstringsMatch`,
+			[][2]any{{`+1 512-315-0280`, `+1512*0280`}},
+			[][2]any{{`+1 512-315-0280`, `*999*`}},
+			nil},
+		{`uniqueMemberMatch`,
+			[][2]any{{`uid=jesse,dc=com#'0101'B`, `UID=Jesse,dc=com#'0101'B`},
+				{`uid=jesse,dc=com`, `uid=jesse,dc=com`}},
+			[][2]any{{`uid=jesse,dc=com#'0101'B`, `uid=jesse,dc=com`},
+				{`uid=jesse,dc=com#'0101'B`, `uid=jesse,dc=com#'0110'B`}},
+			nil},
+		{`generalizedTimeMatch`,
+			[][2]any{{`20240101000000Z`, `20240101013000+0130`}, {`2024010112Z`, `202401011200Z`},
+				{`2024010112.5Z`, `202401011230Z`}, {`202401011230,5Z`, `20240101123030Z`},
+				{`20240101123030.25Z`, `20240101123030.250Z`}, {`2024010112-05`, `2024010117Z`}},
+			[][2]any{{`20240101000000Z`, `20240101000000+0100`}},
+			[][2]any{{`20240101000000`, `20240101000000Z`}}},
+		{`generalizedTimeOrderingMatch`,
+			[][2]any{{`20231231235959Z`, `20240101000000Z`}},
+			[][2]any{{`20240101000000Z`, `20231231235959Z`}},
+			nil},
+		{`integerFirstComponentMatch`,
+			[][2]any{{`( 1 NAME 'domainStructureRule' FORM domainNameForm )`, `1`}},
+			[][2]any{{`( 1 NAME 'domainStructureRule' FORM domainNameForm )`, `2`},
+				{`( cn NAME 'bogus' )`, `2`}},
+			[][2]any{{`1`, `1`}}},
+		{`objectIdentifierFirstComponentMatch`,
+			[][2]any{{`( 2.5.4.3 NAME 'cn' SUP name )`, `2.5.4.3`}},
+			[][2]any{{`( 2.5.4.3 NAME 'cn' SUP name )`, `2.5.4.4`}},
+			[][2]any{{`()`, `2.5.4.3`}}},
+		{`directoryStringFirstComponentMatch`,
+			[][2]any{{`( 'Jesse Coretta' 'other' )`, `jesse coretta`}, {`( Jesse )`, `JESSE`}},
+			[][2]any{{`( 'Jesse Coretta' 'other' )`, `other`}},
+			nil},
+		{`wordMatch`,
+			[][2]any{{`The quick brown fox`, `QUICK`}},
+			[][2]any{{`The quick brown fox`, `quick brown`}, {`The quick-brown fox`, `brown`}},
+			nil},
+		{`keywordMatch`,
+			[][2]any{{`The quick-brown fox`, `brown`}},
+			[][2]any{{`The quick brown fox`, `qui`}},
+			nil},
+		{`caseExactIA5Match`,
+			[][2]any{{`jesse@example.com`, `jesse@example.com`}},
+			[][2]any{{`jesse@example.com`, `Jesse@example.com`}},
+			[][2]any{{`léon@example.com`, `leon@example.com`}}},
+		{`caseIgnoreIA5Match`,
+			[][2]any{{`jesse@example.com`, `Jesse@Example.COM`}},
+			[][2]any{{`jesse@example.com`, `jesse@example.org`}},
+			nil},
+		{`caseIgnoreIA5SubstringsMatch`,
+			[][2]any{{`jesse@example.com`, `JESSE@*`}},
+			[][2]any{{`jesse@example.com`, `*.org`}},
+			nil},
+	} {
+		mr := sch.MatchingRules().Get(tc.mr)
+		funk := RFC4517AssertionMatcher(mr.NumericOID())
+		if funk == nil {
+			t.Errorf("%s failed: no matcher for %s", t.Name(), tc.mr)
+			continue
+		}
+
+		for _, pair := range tc.matches {
+			if err := funk(pair[0], pair[1]); err != nil {
+				t.Errorf("%s[%s] failed: %q vs. %q: unexpected error: %v",
+					t.Name(), tc.mr, pair[0], pair[1], err)
+			}
+		}
+
+		for _, pair := range tc.misses {
+			if err := funk(pair[0], pair[1]); !errors.Is(err, ErrNoMatch) {
+				t.Errorf("%s[%s] failed: %q vs. %q: expected %v, got %v",
+					t.Name(), tc.mr, pair[0], pair[1], ErrNoMatch, err)
+			}
+		}
+
+		for _, pair := range tc.invalid {
+			if err := funk(pair[0], pair[1]); !errors.Is(err, ErrInvalidSyntax) {
+				t.Errorf("%s[%s] failed: %q vs. %q: expected %v, got %v",
+					t.Name(), tc.mr, pair[0], pair[1], ErrInvalidSyntax, err)
+			}
+		}
+	}
+}
+
+func TestAssertionMatchers_codecov(t *testing.T) {
+	if funk := RFC4517AssertionMatcher(`2.5.13.22`); funk != nil {
+		t.Errorf("%s failed: unexpected matcher", t.Name())
+	}
+
+	funk := RFC4517AssertionMatcher(`2.5.13.2`)
+	if err := funk(3.14, `x`); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrInvalidType, err)
+	}
+	if err := funk(`x`, 3.14); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrInvalidType, err)
+	}
+
+	// matchers must not be assigned without the option
+	sch := NewSchema()
+	if err := sch.AttributeTypes().Get(`cn`).
+		EqualityAssertion(`a`, `a`); !errors.Is(err, ErrNilAssertionMatcher) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilAssertionMatcher, err)
+	}
+
+	// pre-existing matchers must not be overwritten
+	sch = NewSchema(AssertionMatchers)
+	mr := sch.MatchingRules().Get(`caseExactMatch`)
+	mr.SetAssertionMatcher(func(any, any) error { return nil })
+	sch.assignRFC4517AssertionMatchers()
+	if err := mr.Assertion(`a`, `b`); err != nil {
+		t.Errorf("%s failed: matcher was overwritten: %v", t.Name(), err)
+	}
+}
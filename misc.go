@@ -188,7 +188,9 @@ func condenseWHSP(b string) (a string) {
 			if last {
 				last = false
 			}
-			a += string(c)
+			// slice rather than convert, so as to
+			// preserve multi-byte UTF-8 sequences.
+			a += b[i : i+1]
 		}
 	}
 
@@ -248,17 +250,14 @@ An LDAP distinguished name, such as "uid=jesse+gidNumber=5042,ou=People,dc=examp
 	  },
 	}
 
-Please note that this function is NOT considered a true parser. If actual
-parsing of component attribute values within a given DN is either desired
-or required, consider use of a proper parser such as [go-ldap/v3's ParseDN]
-function.
+Attribute values are returned in their original (escaped) form. A DN
+which is not a valid RFC 4514 distinguished name returns a zero instance.
+See [ParseDN] for a public means of DN parsing.
 
 flat is an integer value that describes the flattened root suffix "length".
 For instance, given the root suffix of "dc=example,dc=com" -- which is a
 single entry and not two separate entries -- the input value should be the
 integer 1.
-
-[go-ldap/v3's ParseDN]: https://pkg.go.dev/github.com/go-ldap/ldap/v3#ParseDN
 */
 func tokenizeDN(d string, flat ...int) (x *governedDistinguishedName) {
 	if len(d) == 0 {
@@ -273,23 +272,19 @@ func tokenizeDN(d string, flat ...int) (x *governedDistinguishedName) {
 		x.flat = flat[0]
 	}
 
-	rdns := splitUnescaped(d, `,`, `\`)
+	rdns, err := splitDN(d)
 	lr := len(rdns)
 
-	if lr == x.flat || x.flat < 0 {
-		// bogus depth
+	if err != nil || lr == x.flat || x.flat < 0 {
+		// bogus DN or depth
+		x.components = nil
 		return
 	}
 
 	for i := 0; i < lr; i++ {
 		var atvs [][]string = make([][]string, 0)
-		srdns := splitUnescaped(rdns[i], `+`, `\`)
-		for j := 0; j < len(srdns); j++ {
-			if atv := split(srdns[j], `=`); len(atv) == 2 {
-				atvs = append(atvs, atv)
-			} else {
-				atvs = append(atvs, []string{})
-			}
+		for j := 0; j < len(rdns[i]); j++ {
+			atvs = append(atvs, []string{rdns[i][j][0], rdns[i][j][1]})
 		}
 
 		x.components = append(x.components, atvs)
@@ -331,18 +326,6 @@ func detokenizeDN(x *governedDistinguishedName) (dtkz string) {
 	return
 }
 
-func splitUnescaped(str, sep, esc string) (slice []string) {
-	slice = split(str, sep)
-	for i := len(slice) - 2; i >= 0; i-- {
-		if hasSfx(slice[i], esc) {
-			slice[i] = slice[i][:len(slice[i])-len(esc)] + sep + slice[i+1]
-			slice = append(slice[:i+1], slice[i+2:]...)
-		}
-	}
-
-	return
-}
-
 /*
 strInSlice returns a Boolean value indicative of whether the
 specified string (str) is present within slice. Please note
@@ -54,6 +54,7 @@ a [MatchingRule] instance.
 func NewMatchingRule() MatchingRule {
 	mr := MatchingRule{newMatchingRule()}
 	mr.matchingRule.Extensions.setDefinition(mr)
+	mr.matchingRule.Name.setDefinition(mr)
 	return mr
 }
 
@@ -69,6 +70,9 @@ E returns the underlying error instance.
 */
 func (r MatchingRule) E() (err error) {
 	if !r.IsZero() {
+		runlock := r.matchingRule.schema.rlock()
+		defer runlock()
+
 		err = r.matchingRule.err
 	} else {
 		err = ErrNilReceiver
@@ -97,7 +101,7 @@ func (r MatchingRule) Replace(x MatchingRule) MatchingRule {
 		return r
 	}
 
-	if !r.IsZero() && x.Compliant() {
+	if !r.IsZero() && x.compliant() {
 		r.matchingRule.replace(x)
 	}
 
@@ -105,6 +109,15 @@ func (r MatchingRule) Replace(x MatchingRule) MatchingRule {
 }
 
 func (r *matchingRule) replace(x MatchingRule) {
+	sch := r.schema
+	unlock, ok := sch.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if r.OID == `` {
 		r.err = ErrMissingNumericOID
 		return
@@ -116,6 +129,7 @@ func (r *matchingRule) replace(x MatchingRule) {
 	r.OID = x.matchingRule.OID
 	r.Macro = x.matchingRule.Macro
 	r.Name = x.matchingRule.Name
+	r.Name.setDefinition(MatchingRule{r})
 	r.Desc = x.matchingRule.Desc
 	r.Obsolete = x.matchingRule.Obsolete
 	r.Syntax = x.matchingRule.Syntax
@@ -125,6 +139,39 @@ func (r *matchingRule) replace(x MatchingRule) {
 	r.stringer = x.matchingRule.stringer
 	r.data = x.matchingRule.data
 	r.assMatch = x.matchingRule.assMatch
+	r.prep = x.matchingRule.prep
+
+	sch.reindex(MatchingRule{r})
+}
+
+/*
+Clone returns a deep copy of the receiver instance.  Modification of
+the return instance -- such as by way of the [MatchingRule.SetName]
+method -- shall not influence the receiver instance.
+
+The referenced SYNTAX is NOT cloned; use [Schema.Clone] to clone an
+entire [Schema] along with all internal references.
+
+Note that use of the [MatchingRule.SetStringer] method may be needed
+following any changes to the return instance.
+*/
+func (r MatchingRule) Clone() (c MatchingRule) {
+	if r.IsZero() {
+		return
+	}
+
+	_c := *r.matchingRule
+	c = MatchingRule{&_c}
+	_c.Macro = cloneStrings(r.matchingRule.Macro)
+	_c.Name = cloneName(r.matchingRule.Name, c)
+	_c.Extensions = cloneExtensions(r.matchingRule.Extensions, c)
+
+	return
+}
+
+func (r *matchingRule) rewire(sch Schema) {
+	r.schema = sch
+	r.Syntax = r.Syntax.counterpart(sch)
 }
 
 /*
@@ -175,7 +222,7 @@ func (r MatchingRule) Marshal(def any) error {
 		}
 	}
 
-	if !r.Compliant() {
+	if !r.compliant() {
 		return ErrDefNonCompliant
 	}
 	r.SetStringer()
@@ -285,6 +332,14 @@ func (r MatchingRule) SetData(x any) MatchingRule {
 }
 
 func (r *matchingRule) setData(x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.data = x
 }
 
@@ -318,6 +373,14 @@ func (r MatchingRule) SetNumericOID(id string) MatchingRule {
 }
 
 func (r *matchingRule) setNumericOID(id string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
@@ -416,9 +479,72 @@ func (r MatchingRule) SetAssertionMatcher(function AssertionMatcher) MatchingRul
 }
 
 func (r *matchingRule) setAssertionMatcher(function AssertionMatcher) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.assMatch = function
 }
 
+/*
+Prepare returns value following preparation by the [StringPreparer]
+instance previously assigned to the receiver instance, alongside an
+error.  The kind value shall be one of [ValuePrep], [InitialPrep],
+[AnyPrep] or [FinalPrep].
+
+If a [StringPreparer] is not assigned to the receiver instance, the
+[ErrNilStringPreparer] error is returned.
+
+See the [MatchingRule.SetStringPreparer] method for information regarding
+the assignment of an instance of [StringPreparer] to the receiver.
+*/
+func (r MatchingRule) Prepare(value string, kind uint) (prepared string, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+	} else if r.matchingRule.prep == nil {
+		err = ErrNilStringPreparer
+	} else {
+		prepared, err = r.matchingRule.prep(value, kind)
+	}
+
+	return
+}
+
+/*
+SetStringPreparer assigns an instance of [StringPreparer] to the receiver
+instance. A nil value may be passed to disable string preparation.
+
+The built-in [AssertionMatcher] instances assigned through use of the
+[AssertionMatchers] option honor the [StringPreparer] assigned to their
+respective [MatchingRule] instances, thus string preparation may be
+selected on a per-rule basis.
+
+This is a fluent method.
+*/
+func (r MatchingRule) SetStringPreparer(function StringPreparer) MatchingRule {
+	if !r.IsZero() {
+		r.matchingRule.setStringPreparer(function)
+	}
+
+	return r
+}
+
+func (r *matchingRule) setStringPreparer(function StringPreparer) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	r.prep = function
+}
+
 func (r *matchingRule) setSyntax(x any) {
 	var def LDAPSyntax
 	switch tv := x.(type) {
@@ -430,6 +556,14 @@ func (r *matchingRule) setSyntax(x any) {
 		def = tv
 	}
 
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if !def.IsZero() {
 		r.Syntax = def
 	} else {
@@ -457,6 +591,14 @@ func (r MatchingRule) SetSchema(schema Schema) MatchingRule {
 }
 
 func (r *matchingRule) setSchema(schema Schema) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -495,7 +637,15 @@ func (r MatchingRule) SetDescription(desc string) MatchingRule {
 }
 
 func (r *matchingRule) setDescription(desc string) {
-	if len(desc) < 3 {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if len(desc) == 0 {
 		return
 	}
 
@@ -503,7 +653,7 @@ func (r *matchingRule) setDescription(desc string) {
 		desc = desc[1:]
 	}
 
-	if rune(desc[len(desc)-1]) == rune(39) {
+	if len(desc) > 0 && rune(desc[len(desc)-1]) == rune(39) {
 		desc = desc[:len(desc)-1]
 	}
 
@@ -625,6 +775,14 @@ func (r MatchingRule) SetObsolete() MatchingRule {
 }
 
 func (r *matchingRule) setObsolete() {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -647,14 +805,24 @@ func (r MatchingRule) SetName(x ...string) MatchingRule {
 }
 
 func (r *matchingRule) setName(x ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	b4 := r.Name.Len()
 	for i := 0; i < len(x); i++ {
-		r.Name.Push(x[i])
+		r.Name.push(x[i])
 	}
 
 	if r.Name.Len()-len(x) != b4 {
 		r.err = ErrInvalidNames
 	}
+
+	r.schema.reindex(MatchingRule{r})
 }
 
 /*
@@ -695,6 +863,14 @@ func (r MatchingRule) SetExtension(x string, xstrs ...string) MatchingRule {
 }
 
 func (r *matchingRule) setExtension(x string, xstrs ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -804,6 +980,10 @@ func (r Schema) loadRFC4517MatchingRules() (err error) {
 		}
 	}
 
+	if err == nil && r.Options().Positive(AssertionMatchers) {
+		r.assignRFC4517AssertionMatchers()
+	}
+
 	return
 }
 
@@ -898,8 +1078,8 @@ func (r MatchingRules) canPush(x ...any) (err error) {
 		instance := x[i]
 		if mr, ok := instance.(MatchingRule); !ok || mr.IsZero() {
 			err = ErrTypeAssert
-		} else if tst := r.get(mr.NumericOID()); !tst.IsZero() {
-			err = mkerr(ErrNotUnique.Error() + ": " + mr.Type() + `, ` + mr.NumericOID())
+		} else if tst := r.lookup(mr.NumericOID()); !tst.IsZero() {
+			err = wraperr(ErrNotUnique, ": "+mr.Type()+`, `+mr.NumericOID())
 		}
 	}
 
@@ -914,6 +1094,9 @@ func (r MatchingRules) Len() int {
 }
 
 func (r MatchingRules) len() int {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
 	return r.cast().Len()
 }
 
@@ -952,7 +1135,22 @@ instance.
 
 [§ 4.1.3 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.3
 */
-func (r MatchingRule) Compliant() bool {
+func (r MatchingRule) Compliant() (ok bool) {
+	if ok = r.compliant(); ok {
+		unlock, _ := r.matchingRule.schema.lock()
+		defer unlock()
+
+		r.matchingRule.err = nil
+	}
+
+	return
+}
+
+/*
+compliant is the side-effect free counterpart of Compliant, for use
+upon paths which merely read the receiver.
+*/
+func (r MatchingRule) compliant() bool {
 	if r.IsZero() {
 		return false
 	}
@@ -961,12 +1159,7 @@ func (r MatchingRule) Compliant() bool {
 		return false
 	}
 
-	ok := r.Syntax().Compliant()
-	if ok {
-		r.matchingRule.err = nil
-	}
-
-	return ok
+	return r.Syntax().compliant()
 }
 
 /*
@@ -1007,7 +1200,7 @@ stringer.
 This is a fluent method and may be used multiple times.
 */
 func (r MatchingRule) SetStringer(function ...Stringer) MatchingRule {
-	if r.Compliant() {
+	if r.compliant() {
 		r.matchingRule.setStringer(function...)
 	}
 
@@ -1020,23 +1213,29 @@ func (r *matchingRule) setStringer(function ...Stringer) {
 		stringer = function[0]
 	}
 
+	var err error
 	if stringer == nil {
 		// no user provided closure means we
 		// defer to a general use stringer.
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		} else {
-			r.err = err
+		var str string
+		str, err = r.prepareString() // perform one-time text/template op
+		stringer = func() string {
+			// Return a preserved value.
+			return str
 		}
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	} else if err != nil {
+		r.err = err
 		return
 	}
 
-	// assign user-provided closure
 	r.stringer = stringer
 }
 
@@ -1051,7 +1250,7 @@ func (r MatchingRules) XOrigin(x string) (defs MatchingRules) {
 		def := r.Index(i)
 		if xo, found := def.Extensions().Get(`X-ORIGIN`); found {
 			if xo.Contains(x) {
-				defs.push(def)
+				defs.cast().Push(def)
 			}
 		}
 	}
@@ -1091,7 +1290,17 @@ func (r MatchingRules) Index(idx int) MatchingRule {
 	return r.index(idx)
 }
 
-func (r MatchingRules) index(idx int) (mr MatchingRule) {
+func (r MatchingRules) index(idx int) MatchingRule {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.at(idx)
+}
+
+/*
+at is the unlocked counterpart of index.
+*/
+func (r MatchingRules) at(idx int) (mr MatchingRule) {
 	slice, found := r.cast().Index(idx)
 	if found {
 		if _mr, ok := slice.(MatchingRule); ok {
@@ -1106,18 +1315,22 @@ func (r MatchingRules) index(idx int) (mr MatchingRule) {
 Push returns an error following an attempt to push a MatchingRule
 into the receiver stack instance.
 */
-func (r MatchingRules) Push(mr any) error {
-	return r.push(mr)
+func (r MatchingRules) Push(mr any) (err error) {
+	if err = r.push(mr); err == nil {
+		pushed(r, mr)
+	}
+
+	return
 }
 
 func (r MatchingRules) push(x any) (err error) {
 	switch tv := x.(type) {
 	case MatchingRule:
-		if !tv.Compliant() {
+		if !tv.compliant() {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		err = lockedPush(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -1142,7 +1355,7 @@ func (r MatchingRules) contains(id string) bool {
 Type returns the string literal "matchingRule".
 */
 func (r MatchingRule) Type() string {
-	return r.matchingRule.Type()
+	return `matchingRule`
 }
 
 func (r matchingRule) Type() string {
@@ -1189,9 +1402,28 @@ func (r MatchingRules) Get(id string) MatchingRule {
 	return r.get(id)
 }
 
-func (r MatchingRules) get(id string) (mr MatchingRule) {
-	for i := 0; i < r.len() && mr.IsZero(); i++ {
-		if _mr := r.index(i); !_mr.IsZero() {
+func (r MatchingRules) get(id string) MatchingRule {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.lookup(id)
+}
+
+/*
+lookup is the unlocked counterpart of get, for use where the lock is
+already held, such as during a push.
+*/
+func (r MatchingRules) lookup(id string) (mr MatchingRule) {
+	if slice, indexed := indexLookup(r.cast(), id, func(x any) bool {
+		_mr, _ := x.(MatchingRule)
+		return _mr.IsIdentifiedAs(id)
+	}); indexed {
+		mr, _ = slice.(MatchingRule)
+		return
+	}
+
+	for i := 0; i < r.cast().Len() && mr.IsZero(); i++ {
+		if _mr := r.at(i); !_mr.IsZero() {
 			if _mr.IsIdentifiedAs(id) {
 				mr = _mr
 			}
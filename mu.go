@@ -54,6 +54,7 @@ a [MatchingRuleUse] instance.
 func NewMatchingRuleUse() MatchingRuleUse {
 	mu := MatchingRuleUse{newMatchingRuleUse()}
 	mu.matchingRuleUse.Extensions.setDefinition(mu)
+	mu.matchingRuleUse.Name.setDefinition(mu)
 	return mu
 }
 
@@ -119,7 +120,7 @@ func (r MatchingRuleUse) Marshal(def any) error {
 		}
 	}
 
-	if !r.Compliant() {
+	if !r.compliant() {
 		return ErrDefNonCompliant
 	}
 	r.SetStringer()
@@ -186,7 +187,7 @@ func (r MatchingRuleUse) Replace(x MatchingRuleUse) MatchingRuleUse {
 		return r
 	}
 
-	if !r.IsZero() && r.Compliant() {
+	if !r.IsZero() && r.compliant() {
 		r.matchingRuleUse.replace(x)
 	}
 
@@ -194,6 +195,15 @@ func (r MatchingRuleUse) Replace(x MatchingRuleUse) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) replace(x MatchingRuleUse) {
+	sch := r.schema
+	unlock, ok := sch.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if r.OID.IsZero() {
 		r.err = ErrMissingNumericOID
 		return
@@ -212,6 +222,40 @@ func (r *matchingRuleUse) replace(x MatchingRuleUse) {
 	r.schema = x.matchingRuleUse.schema
 	r.stringer = x.matchingRuleUse.stringer
 	r.data = x.matchingRuleUse.data
+
+	sch.reindex(MatchingRuleUse{r})
+}
+
+/*
+Clone returns a deep copy of the receiver instance.  Modification of
+the return instance -- such as by way of the [MatchingRuleUse.SetApplies]
+method -- shall not influence the receiver instance.
+
+Referenced definitions, such as the [MatchingRule] and APPLIES values,
+are NOT cloned; use [Schema.Clone] to clone an entire [Schema] along
+with all internal references.
+
+Note that use of the [MatchingRuleUse.SetStringer] method may be needed
+following any changes to the return instance.
+*/
+func (r MatchingRuleUse) Clone() (c MatchingRuleUse) {
+	if r.IsZero() {
+		return
+	}
+
+	_c := *r.matchingRuleUse
+	c = MatchingRuleUse{&_c}
+	_c.Name = cloneName(r.matchingRuleUse.Name, c)
+	_c.Applies = r.matchingRuleUse.Applies.cloneOIDList()
+	_c.Extensions = cloneExtensions(r.matchingRuleUse.Extensions, c)
+
+	return
+}
+
+func (r *matchingRuleUse) rewire(sch Schema) {
+	r.schema = sch
+	r.OID = r.OID.counterpart(sch)
+	r.Applies = r.Applies.counterparts(sch)
 }
 
 /*
@@ -219,6 +263,9 @@ E returns the underlying error instance.
 */
 func (r MatchingRuleUse) E() (err error) {
 	if !r.IsZero() {
+		runlock := r.matchingRuleUse.schema.rlock()
+		defer runlock()
+
 		err = r.matchingRuleUse.err
 	} else {
 		err = ErrNilReceiver
@@ -251,7 +298,22 @@ compliant per the required clauses of [§ 4.1.4 of RFC 4512]:
 
 [§ 4.1.4 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.4
 */
-func (r MatchingRuleUse) Compliant() bool {
+func (r MatchingRuleUse) Compliant() (ok bool) {
+	if ok = r.compliant(); ok {
+		unlock, _ := r.matchingRuleUse.schema.lock()
+		defer unlock()
+
+		r.matchingRuleUse.err = nil
+	}
+
+	return
+}
+
+/*
+compliant is the side-effect free counterpart of Compliant, for use
+upon paths which merely read the receiver.
+*/
+func (r MatchingRuleUse) compliant() bool {
 	if r.IsZero() {
 		return false
 	}
@@ -262,7 +324,7 @@ func (r MatchingRuleUse) Compliant() bool {
 	)
 
 	for i := 0; i < appl.Len(); i++ {
-		if appl.Index(i).Compliant() {
+		if appl.Index(i).compliant() {
 			act++
 		}
 	}
@@ -271,12 +333,7 @@ func (r MatchingRuleUse) Compliant() bool {
 		return false
 	}
 
-	ok := act == r.Applies().Len()
-	if ok {
-		r.matchingRuleUse.err = nil
-	}
-
-	return ok
+	return act == r.Applies().Len()
 }
 
 /*
@@ -295,6 +352,14 @@ func (r MatchingRuleUse) SetData(x any) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setData(x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.data = x
 }
 
@@ -348,7 +413,7 @@ stringer.
 This is a fluent method and may be used multiple times.
 */
 func (r MatchingRuleUse) SetStringer(function ...Stringer) MatchingRuleUse {
-	if r.Compliant() {
+	if r.compliant() {
 		r.matchingRuleUse.setStringer(function...)
 	}
 
@@ -361,23 +426,29 @@ func (r *matchingRuleUse) setStringer(function ...Stringer) {
 		stringer = function[0]
 	}
 
+	var err error
 	if stringer == nil {
 		// no user provided closure means we
 		// defer to a general use stringer.
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		} else {
-			r.err = err
+		var str string
+		str, err = r.prepareString() // perform one-time text/template op
+		stringer = func() string {
+			// Return a preserved value.
+			return str
 		}
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	} else if err != nil {
+		r.err = err
 		return
 	}
 
-	// assign user-provided closure
 	r.stringer = stringer
 }
 
@@ -392,7 +463,7 @@ func (r MatchingRuleUses) XOrigin(x string) (defs MatchingRuleUses) {
 		def := r.Index(i)
 		if xo, found := def.Extensions().Get(`X-ORIGIN`); found {
 			if xo.Contains(x) {
-				defs.push(def)
+				defs.cast().Push(def)
 			}
 		}
 	}
@@ -443,6 +514,14 @@ func (r MatchingRuleUse) SetObsolete() MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setObsolete() {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -475,6 +554,11 @@ func (r MatchingRuleUse) SetApplies(m ...any) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setApplies(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -487,13 +571,20 @@ func (r *matchingRuleUse) setApplies(m ...any) {
 			err = ErrInvalidInput
 		}
 
-		if err == nil && at.Compliant() {
-			r.Applies.Push(at)
+		if err == nil && at.compliant() {
+			unlock, ok := r.schema.lock()
+			if ok {
+				// compliance already verified
+				err = lockedPush(r.Applies.cast(), at)
+			} else {
+				err = ErrReadOnlySchema
+			}
+			unlock()
 		}
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -517,6 +608,14 @@ func (r MatchingRuleUse) SetSchema(schema Schema) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setSchema(schema Schema) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -669,7 +768,7 @@ func (r MatchingRuleUses) Inventory() (inv Inventory) {
 Type returns the string literal "matchingRuleUse".
 */
 func (r MatchingRuleUse) Type() string {
-	return r.matchingRuleUse.Type()
+	return `matchingRuleUse`
 }
 
 func (r matchingRuleUse) Type() string {
@@ -689,9 +788,11 @@ func (r MatchingRuleUses) prepareStrings() (err error) {
 		var str string
 		str, err = mu.matchingRuleUse.prepareString()
 		if err == nil {
+			unlock, _ := mu.matchingRuleUse.schema.lock()
 			mu.matchingRuleUse.stringer = func() string {
 				return str
 			}
+			unlock()
 		}
 	}
 
@@ -741,6 +842,14 @@ func (r MatchingRuleUse) SetExtension(x string, xstrs ...string) MatchingRuleUse
 }
 
 func (r *matchingRuleUse) setExtension(x string, xstrs ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -776,6 +885,15 @@ func (r MatchingRuleUse) SetNumericOID(id string) MatchingRuleUse {
 
 func (r *matchingRuleUse) setNumericOID(id string) {
 	mr := r.schema.MatchingRules().Get(id)
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	// only set an OID when the receiver
 	// lacks one (iow: no modifications)
 	// and when the MR has been found.
@@ -828,14 +946,24 @@ func (r MatchingRuleUse) SetName(x ...string) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setName(x ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	b4 := r.Name.Len()
 	for i := 0; i < len(x); i++ {
-		r.Name.Push(x[i])
+		r.Name.push(x[i])
 	}
 
 	if r.Name.Len()-len(x) != b4 {
 		r.err = ErrInvalidNames
 	}
+
+	r.schema.reindex(MatchingRuleUse{r})
 }
 
 /*
@@ -863,7 +991,15 @@ func (r MatchingRuleUse) SetDescription(desc string) MatchingRuleUse {
 }
 
 func (r *matchingRuleUse) setDescription(desc string) {
-	if len(desc) < 3 {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if len(desc) == 0 {
 		return
 	}
 
@@ -871,7 +1007,7 @@ func (r *matchingRuleUse) setDescription(desc string) {
 		desc = desc[1:]
 	}
 
-	if rune(desc[len(desc)-1]) == rune(39) {
+	if len(desc) > 0 && rune(desc[len(desc)-1]) == rune(39) {
 		desc = desc[:len(desc)-1]
 	}
 
@@ -896,8 +1032,8 @@ func (r MatchingRuleUses) canPush(x ...any) (err error) {
 		mu, ok := x[i].(MatchingRuleUse)
 		if !ok || mu.IsZero() {
 			err = ErrTypeAssert
-		} else if tst := r.get(mu.NumericOID()); !tst.IsZero() {
-			err = mkerr(ErrNotUnique.Error() + ": " + mu.Type() + `, ` + mu.NumericOID())
+		} else if tst := r.lookup(mu.NumericOID()); !tst.IsZero() {
+			err = wraperr(ErrNotUnique, ": "+mu.Type()+`, `+mu.NumericOID())
 		}
 	}
 
@@ -912,6 +1048,9 @@ func (r MatchingRuleUses) Len() int {
 }
 
 func (r MatchingRuleUses) len() int {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
 	return r.cast().Len()
 }
 
@@ -939,7 +1078,17 @@ func (r MatchingRuleUses) Index(idx int) MatchingRuleUse {
 	return r.index(idx)
 }
 
-func (r MatchingRuleUses) index(idx int) (mu MatchingRuleUse) {
+func (r MatchingRuleUses) index(idx int) MatchingRuleUse {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.at(idx)
+}
+
+/*
+at is the unlocked counterpart of index.
+*/
+func (r MatchingRuleUses) at(idx int) (mu MatchingRuleUse) {
 	slice, found := r.cast().Index(idx)
 	if found {
 		if _mu, ok := slice.(MatchingRuleUse); ok {
@@ -954,8 +1103,12 @@ func (r MatchingRuleUses) index(idx int) (mu MatchingRuleUse) {
 Push returns an error following an attempt to push a [MatchingRuleUse]
 instance into the receiver instance.
 */
-func (r MatchingRuleUses) Push(mu any) error {
-	return r.push(mu)
+func (r MatchingRuleUses) Push(mu any) (err error) {
+	if err = r.push(mu); err == nil {
+		pushed(r, mu)
+	}
+
+	return
 }
 
 func (r MatchingRuleUses) push(x any) (err error) {
@@ -965,7 +1118,7 @@ func (r MatchingRuleUses) push(x any) (err error) {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		err = lockedPush(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -1001,9 +1154,28 @@ func (r MatchingRuleUses) Get(id string) MatchingRuleUse {
 	return r.get(id)
 }
 
-func (r MatchingRuleUses) get(id string) (mu MatchingRuleUse) {
-	for i := 0; i < r.len() && mu.IsZero(); i++ {
-		if _mu := r.index(i); !_mu.IsZero() {
+func (r MatchingRuleUses) get(id string) MatchingRuleUse {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.lookup(id)
+}
+
+/*
+lookup is the unlocked counterpart of get, for use where the lock is
+already held, such as during a push.
+*/
+func (r MatchingRuleUses) lookup(id string) (mu MatchingRuleUse) {
+	if slice, indexed := indexLookup(r.cast(), id, func(x any) bool {
+		_mu, _ := x.(MatchingRuleUse)
+		return _mu.IsIdentifiedAs(id)
+	}); indexed {
+		mu, _ = slice.(MatchingRuleUse)
+		return
+	}
+
+	for i := 0; i < r.cast().Len() && mu.IsZero(); i++ {
+		if _mu := r.at(i); !_mu.IsZero() {
 			if _mu.IsIdentifiedAs(id) {
 				mu = _mu
 			}
@@ -1020,7 +1192,7 @@ assuming a nil error condition, may have its APPLIES clause populated
 with "users" (AttributeType instances) of the indicated matchingRule.
 */
 func (r MatchingRule) makeMatchingRuleUse() (mu MatchingRuleUse, err error) {
-	if !r.Compliant() {
+	if !r.compliant() {
 		err = ErrDefNonCompliant
 		return
 	}
@@ -1046,6 +1218,10 @@ func (r Schema) updateMatchingRuleUses(ats AttributeTypes) (err error) {
 		return
 	}
 
+	// Preserve the original states for any observers.
+	states := r.matchingRuleUseStates()
+	defer func() { r.matchingRuleUseChanges(states) }()
+
 	for i := 0; i < ats.len() && err == nil; i++ {
 		if at := ats.index(i); !at.IsZero() {
 			for _, funk := range []func(AttributeType) error{
@@ -25,8 +25,50 @@ Push returns an error following an attempt to push name into
 the receiver instance.  The name value must be non-zero in
 length and must be a valid RFC 4512 "descr" qualifier.
 */
-func (r QuotedDescriptorList) Push(name string) error {
-	return r.push(name)
+func (r QuotedDescriptorList) Push(name string) (err error) {
+	if err = r.push(name); err == nil {
+		r.announce()
+	}
+
+	return
+}
+
+/*
+announce informs the collection index of the [Definition] to which the
+receiver is assigned, if any, that the names of the [Definition] have
+changed.  Names assigned by way of the various SetName methods are
+announced by the setter itself.
+*/
+func (r QuotedDescriptorList) announce() {
+	if def := r.definition(); def != nil {
+		sch := def.Schema()
+		runlock := sch.rlock()
+		defer runlock()
+
+		sch.reindex(def)
+	}
+}
+
+/*
+definition returns the [Definition] to which the receiver is assigned, or
+nil if the receiver is not assigned to any [Definition].
+*/
+func (r QuotedDescriptorList) definition() (def Definition) {
+	if aux := r.cast().Auxiliary(); aux != nil {
+		def, _ = aux[`def`].(Definition)
+	}
+
+	return
+}
+
+/*
+setDefinition assigns input [Definition] x to the receiver instance.
+
+This is a fluent method.
+*/
+func (r QuotedDescriptorList) setDefinition(x Definition) QuotedDescriptorList {
+	r.cast().SetAuxiliary(map[string]any{`def`: x})
+	return r
 }
 
 func (r QuotedDescriptorList) push(name string) (err error) {
@@ -36,6 +78,9 @@ func (r QuotedDescriptorList) push(name string) (err error) {
 	} else if !isDescriptor(name) {
 		err = mkerr("Invalid RFC 4512 descriptor '" + name + `'`)
 		return
+	} else if r.cast().IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
 	}
 
 	r.cast().Push(name)
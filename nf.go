@@ -87,6 +87,9 @@ E returns the underlying error instance.
 */
 func (r NameForm) E() (err error) {
 	if !r.IsZero() {
+		runlock := r.nameForm.schema.rlock()
+		defer runlock()
+
 		err = r.nameForm.err
 	} else {
 		err = ErrNilReceiver
@@ -141,6 +144,7 @@ a [NameForm] instance.
 func NewNameForm() NameForm {
 	nf := NameForm{newNameForm()}
 	nf.nameForm.Extensions.setDefinition(nf)
+	nf.nameForm.Name.setDefinition(nf)
 	return nf
 }
 
@@ -173,7 +177,7 @@ func (r NameForm) Replace(x NameForm) NameForm {
 		return r
 	}
 
-	if !r.IsZero() && x.Compliant() {
+	if !r.IsZero() && x.compliant() {
 		r.nameForm.replace(x)
 	}
 
@@ -181,6 +185,15 @@ func (r NameForm) Replace(x NameForm) NameForm {
 }
 
 func (r *nameForm) replace(x NameForm) {
+	sch := r.schema
+	unlock, ok := sch.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if r.OID == `` {
 		r.err = ErrMissingNumericOID
 		return
@@ -192,6 +205,7 @@ func (r *nameForm) replace(x NameForm) {
 	r.OID = x.nameForm.OID
 	r.Macro = x.nameForm.Macro
 	r.Name = x.nameForm.Name
+	r.Name.setDefinition(NameForm{r})
 	r.Desc = x.nameForm.Desc
 	r.Obsolete = x.nameForm.Obsolete
 	r.Structural = x.nameForm.Structural
@@ -202,6 +216,43 @@ func (r *nameForm) replace(x NameForm) {
 	r.schema = x.nameForm.schema
 	r.stringer = x.nameForm.stringer
 	r.data = x.nameForm.data
+
+	sch.reindex(NameForm{r})
+}
+
+/*
+Clone returns a deep copy of the receiver instance.  Modification of
+the return instance -- such as by way of the [NameForm.SetMust] method
+-- shall not influence the receiver instance.
+
+Referenced definitions, such as the OC, MUST and MAY values, are NOT
+cloned; use [Schema.Clone] to clone an entire [Schema] along with all
+internal references.
+
+Note that use of the [NameForm.SetStringer] method may be needed
+following any changes to the return instance.
+*/
+func (r NameForm) Clone() (c NameForm) {
+	if r.IsZero() {
+		return
+	}
+
+	_c := *r.nameForm
+	c = NameForm{&_c}
+	_c.Macro = cloneStrings(r.nameForm.Macro)
+	_c.Name = cloneName(r.nameForm.Name, c)
+	_c.Must = r.nameForm.Must.cloneOIDList()
+	_c.May = r.nameForm.May.cloneOIDList()
+	_c.Extensions = cloneExtensions(r.nameForm.Extensions, c)
+
+	return
+}
+
+func (r *nameForm) rewire(sch Schema) {
+	r.schema = sch
+	r.Structural = r.Structural.counterpart(sch)
+	r.Must = r.Must.counterparts(sch)
+	r.May = r.May.counterparts(sch)
 }
 
 /*
@@ -271,7 +322,22 @@ compliant per the required clauses of [§ 4.1.7.2 of RFC 4512]:
 
 [§ 4.1.7.2 of RFC 4512]: https://datatracker.ietf.org/doc/html/rfc4512#section-4.1.7.2
 */
-func (r NameForm) Compliant() bool {
+func (r NameForm) Compliant() (ok bool) {
+	if ok = r.compliant(); ok {
+		unlock, _ := r.nameForm.schema.lock()
+		defer unlock()
+
+		r.nameForm.err = nil
+	}
+
+	return
+}
+
+/*
+compliant is the side-effect free counterpart of Compliant, for use
+upon paths which merely read the receiver.
+*/
+func (r NameForm) compliant() bool {
 	if r.IsZero() {
 		return false
 	}
@@ -280,7 +346,7 @@ func (r NameForm) Compliant() bool {
 		return false
 	}
 
-	if !r.OC().Compliant() {
+	if !r.OC().compliant() {
 		return false
 	}
 
@@ -291,24 +357,19 @@ func (r NameForm) Compliant() bool {
 	)
 
 	for i := 0; i < must.Len(); i++ {
-		if !must.Index(i).Compliant() {
+		if !must.Index(i).compliant() {
 			return false
 		}
 		mct++
 	}
 
 	for i := 0; i < may.Len(); i++ {
-		if !may.Index(i).Compliant() {
+		if !may.Index(i).compliant() {
 			return false
 		}
 	}
 
-	ok := mct > 0
-	if ok {
-		r.nameForm.err = nil
-	}
-
-	return ok
+	return mct > 0
 }
 
 /*
@@ -327,6 +388,14 @@ func (r NameForm) SetData(x any) NameForm {
 }
 
 func (r *nameForm) setData(x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.data = x
 }
 
@@ -362,6 +431,14 @@ func (r NameForm) SetSchema(schema Schema) NameForm {
 }
 
 func (r *nameForm) setSchema(schema Schema) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -400,14 +477,24 @@ func (r NameForm) SetName(name ...string) NameForm {
 }
 
 func (r *nameForm) setName(x ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	b4 := r.Name.Len()
 	for i := 0; i < len(x); i++ {
-		r.Name.Push(x[i])
+		r.Name.push(x[i])
 	}
 
 	if r.Name.Len()-len(x) != b4 {
 		r.err = ErrInvalidNames
 	}
+
+	r.schema.reindex(NameForm{r})
 }
 
 /*
@@ -428,6 +515,14 @@ func (r NameForm) SetNumericOID(id string) NameForm {
 }
 
 func (r *nameForm) setNumericOID(id string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if isNumericOID(id) {
 		// only set an OID when the receiver
 		// lacks one (iow: no modifications)
@@ -474,6 +569,14 @@ func (r NameForm) SetObsolete() NameForm {
 }
 
 func (r *nameForm) setObsolete() {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -536,6 +639,11 @@ func (r NameForm) SetMay(m ...any) NameForm {
 }
 
 func (r *nameForm) setMay(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -554,7 +662,7 @@ func (r *nameForm) setMay(m ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -573,6 +681,14 @@ func (r NameForm) SetExtension(x string, xstrs ...string) NameForm {
 }
 
 func (r *nameForm) setExtension(x string, xstrs ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -607,6 +723,11 @@ func (r NameForm) SetMust(m ...any) NameForm {
 }
 
 func (r *nameForm) setMust(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -625,7 +746,7 @@ func (r *nameForm) setMust(m ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -702,7 +823,7 @@ stringer.
 This is a fluent method and may be used multiple times.
 */
 func (r NameForm) SetStringer(function ...Stringer) NameForm {
-	if r.Compliant() {
+	if r.compliant() {
 		r.nameForm.setStringer(function...)
 	}
 
@@ -715,17 +836,26 @@ func (r *nameForm) setStringer(function ...Stringer) {
 		stringer = function[0]
 	}
 
+	var err error
 	if stringer == nil {
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		} else {
-			r.err = err
+		// no user provided closure means we
+		// defer to a general use stringer.
+		var str string
+		str, err = r.prepareString() // perform one-time text/template op
+		stringer = func() string {
+			// Return a preserved value.
+			return str
 		}
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	} else if err != nil {
+		r.err = err
 		return
 	}
 
@@ -743,7 +873,7 @@ func (r NameForms) XOrigin(x string) (defs NameForms) {
 		def := r.Index(i)
 		if xo, found := def.Extensions().Get(`X-ORIGIN`); found {
 			if xo.Contains(x) {
-				defs.push(def)
+				defs.cast().Push(def)
 			}
 		}
 	}
@@ -837,7 +967,15 @@ func (r NameForm) SetDescription(desc string) NameForm {
 }
 
 func (r *nameForm) setDescription(desc string) {
-	if len(desc) < 3 {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if len(desc) == 0 {
 		return
 	}
 
@@ -845,7 +983,7 @@ func (r *nameForm) setDescription(desc string) {
 		desc = desc[1:]
 	}
 
-	if rune(desc[len(desc)-1]) == rune(39) {
+	if len(desc) > 0 && rune(desc[len(desc)-1]) == rune(39) {
 		desc = desc[:len(desc)-1]
 	}
 
@@ -898,7 +1036,7 @@ func (r NameForm) Marshal(def any) error {
 		}
 	}
 
-	if !r.Compliant() {
+	if !r.compliant() {
 		return ErrDefNonCompliant
 	}
 	r.SetStringer()
@@ -1045,8 +1183,8 @@ func (r NameForms) canPush(x ...any) (err error) {
 		nf, ok := x[i].(NameForm)
 		if !ok || nf.IsZero() {
 			err = ErrTypeAssert
-		} else if tst := r.get(nf.NumericOID()); !tst.IsZero() {
-			err = mkerr(ErrNotUnique.Error() + ": " + nf.Type() + `, ` + nf.NumericOID())
+		} else if tst := r.lookup(nf.NumericOID()); !tst.IsZero() {
+			err = wraperr(ErrNotUnique, ": "+nf.Type()+`, `+nf.NumericOID())
 		}
 	}
 
@@ -1061,6 +1199,9 @@ func (r NameForms) Len() int {
 }
 
 func (r NameForms) len() int {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
 	return r.cast().Len()
 }
 
@@ -1088,7 +1229,17 @@ func (r NameForms) Index(idx int) NameForm {
 	return r.index(idx)
 }
 
-func (r NameForms) index(idx int) (nf NameForm) {
+func (r NameForms) index(idx int) NameForm {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.at(idx)
+}
+
+/*
+at is the unlocked counterpart of index.
+*/
+func (r NameForms) at(idx int) (nf NameForm) {
 	slice, found := r.cast().Index(idx)
 	if found {
 		if _nf, ok := slice.(NameForm); ok {
@@ -1103,18 +1254,22 @@ func (r NameForms) index(idx int) (nf NameForm) {
 Push returns an error following an attempt to push a [NameForm]
 instance into the receiver stack instance.
 */
-func (r NameForms) Push(nf any) error {
-	return r.push(nf)
+func (r NameForms) Push(nf any) (err error) {
+	if err = r.push(nf); err == nil {
+		pushed(r, nf)
+	}
+
+	return
 }
 
 func (r NameForms) push(x any) (err error) {
 	switch tv := x.(type) {
 	case NameForm:
-		if !tv.Compliant() {
+		if !tv.compliant() {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		err = lockedPush(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -1150,9 +1305,28 @@ func (r NameForms) Get(id string) NameForm {
 	return r.get(id)
 }
 
-func (r NameForms) get(id string) (nf NameForm) {
-	for i := 0; i < r.len() && nf.IsZero(); i++ {
-		if _nf := r.index(i); !_nf.IsZero() {
+func (r NameForms) get(id string) NameForm {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.lookup(id)
+}
+
+/*
+lookup is the unlocked counterpart of get, for use where the lock is
+already held, such as during a push.
+*/
+func (r NameForms) lookup(id string) (nf NameForm) {
+	if slice, indexed := indexLookup(r.cast(), id, func(x any) bool {
+		_nf, _ := x.(NameForm)
+		return _nf.IsIdentifiedAs(id)
+	}); indexed {
+		nf, _ = slice.(NameForm)
+		return
+	}
+
+	for i := 0; i < r.cast().Len() && nf.IsZero(); i++ {
+		if _nf := r.at(i); !_nf.IsZero() {
 			if _nf.nameForm.OID == id {
 				nf = _nf
 			} else if _nf.nameForm.Name.contains(id) {
@@ -1197,6 +1371,14 @@ func (r *nameForm) setOC(x any) {
 		oc = tv
 	}
 
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if !oc.IsZero() && oc.Kind() == StructuralKind {
 		r.Structural = oc
 	} else {
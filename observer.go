@@ -0,0 +1,390 @@
+package schemax
+
+/*
+observer.go contains facilities for the observation of changes made to the
+definitions of a Schema.
+*/
+
+import (
+	"sort"
+	"sync"
+)
+
+/*
+Change operations, as conveyed through the Operation field of [ChangeEvent].
+*/
+const (
+	DefinitionAdded    uint = iota + 1 // definition was pushed into the Schema
+	DefinitionReplaced                 // definition was replaced in place
+	DefinitionRemoved                  // definition was removed from the Schema
+)
+
+var changeOperationLabels map[uint]string = map[uint]string{
+	DefinitionAdded:    `added`,
+	DefinitionReplaced: `replaced`,
+	DefinitionRemoved:  `removed`,
+}
+
+/*
+Change sources, as conveyed through the Source field of [ChangeEvent].
+*/
+const (
+	PushSource            uint = iota + 1 // Push method of a collection or Schema
+	ParseSource                           // parsing or loading (including JSON and YAML) of a definition
+	MacroSource                           // parsing of a definition whose numeric OID was resolved from a macro
+	ReplaceSource                         // Schema.Replace
+	RemoveSource                          // Schema.Remove or Schema.RemoveCascade
+	MatchingRuleUseSource                 // (re)generation of MatchingRuleUse instances
+)
+
+var changeSourceLabels map[uint]string = map[uint]string{
+	PushSource:            `push`,
+	ParseSource:           `parse`,
+	MacroSource:           `macro`,
+	ReplaceSource:         `replace`,
+	RemoveSource:          `remove`,
+	MatchingRuleUseSource: `matchingRuleUse`,
+}
+
+/*
+ChangeEvent describes a single change made to the definitions of a [Schema],
+as delivered to each [ChangeObserver] registered by way of [Schema.Observe].
+*/
+type ChangeEvent struct {
+	// Operation describes the nature of the change, such
+	// as DefinitionAdded.
+	Operation uint
+
+	// Source describes the means by which the change was
+	// made, such as ParseSource.
+	Source uint
+
+	// Old contains a copy of the definition as it was prior
+	// to its replacement, or the definition removed.  It is
+	// nil in the case of DefinitionAdded.
+	Old Definition
+
+	// New contains the definition added, or the definition
+	// as it is following its replacement.  It is nil in the
+	// case of DefinitionRemoved.
+	New Definition
+}
+
+/*
+Definition returns the [Definition] to which the receiver instance pertains,
+which is the New field value unless nil, in which case the Old field value
+is returned.
+*/
+func (r ChangeEvent) Definition() Definition {
+	if r.New != nil {
+		return r.New
+	}
+
+	return r.Old
+}
+
+/*
+String returns the string representation of the receiver instance, such
+as "added attributeType 2.5.4.3 (parse)".
+*/
+func (r ChangeEvent) String() (s string) {
+	if def := r.Definition(); def != nil {
+		s = changeOperationLabels[r.Operation] + ` ` + def.Type() + ` ` +
+			defIdentity(def) + ` (` + changeSourceLabels[r.Source] + `)`
+	}
+
+	return
+}
+
+/*
+ChangeObserver is a closure function which receives each [ChangeEvent]
+produced by a [Schema].  See [Schema.Observe] for details.
+*/
+type ChangeObserver func(ChangeEvent)
+
+/*
+changeObservers contains the [ChangeObserver] instances registered with a
+single [Schema], in order of registration.
+*/
+type changeObservers struct {
+	mutex sync.Mutex
+	next  uint64
+	list  []registeredObserver
+}
+
+type registeredObserver struct {
+	id uint64
+	fn ChangeObserver
+}
+
+func newChangeObservers() *changeObservers {
+	return &changeObservers{}
+}
+
+/*
+Observe registers fn to receive a [ChangeEvent] for each definition added
+to, replaced within or removed from the receiver instance, returning the
+closure by which fn is unregistered.  This is useful for the precise
+invalidation of caches derived from the receiver, such as compiled value
+validators.
+
+Events are delivered synchronously, in order of registration, by the
+goroutine which made the change.  Delivery occurs once the change is
+complete and all locks have been released, thus fn may freely query the
+receiver.  Changes made by fn are themselves observed.
+
+Events are produced for all changes made by way of the Push, Parse, Load,
+Replace, Remove and RemoveCascade methods, as well as by way of a committed
+[Transaction].  The (re)generation of [MatchingRuleUse] instances -- such as
+through [Schema.UpdateMatchingRuleUses], or following the removal of an
+[AttributeType] listed within an APPLIES clause -- is likewise observed.
+Renames and other direct modifications of a definition are not.
+
+Observers are not copied by [Schema.Clone] or [Schema.Snapshot].
+*/
+func (r Schema) Observe(fn ChangeObserver) (cancel func()) {
+	cancel = func() {}
+
+	obs := r.changeObservers()
+	if obs == nil || fn == nil {
+		return
+	}
+
+	obs.mutex.Lock()
+	defer obs.mutex.Unlock()
+
+	obs.next++
+	id := obs.next
+	obs.list = append(obs.list, registeredObserver{id: id, fn: fn})
+
+	cancel = func() {
+		obs.mutex.Lock()
+		defer obs.mutex.Unlock()
+
+		for i, o := range obs.list {
+			if o.id == id {
+				obs.list = append(obs.list[:i:i], obs.list[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+func (r Schema) changeObservers() (obs *changeObservers) {
+	if !r.IsZero() {
+		obs, _ = r.cast().Auxiliary()[`observers`].(*changeObservers)
+	}
+
+	return
+}
+
+/*
+observed returns a Boolean value indicative of whether any observers are
+registered with the receiver instance.  This is used to avoid the cost of
+preserving prior definition states which no one will receive.
+*/
+func (r Schema) observed() (ok bool) {
+	if obs := r.changeObservers(); obs != nil {
+		obs.mutex.Lock()
+		ok = len(obs.list) > 0
+		obs.mutex.Unlock()
+	}
+
+	return
+}
+
+/*
+notify delivers each event to each observer of the receiver instance. The
+caller shall not hold the write lock.
+*/
+func (r Schema) notify(events ...ChangeEvent) {
+	obs := r.changeObservers()
+	if obs == nil || len(events) == 0 {
+		return
+	}
+
+	obs.mutex.Lock()
+	list := append([]registeredObserver{}, obs.list...)
+	obs.mutex.Unlock()
+
+	for _, event := range events {
+		for _, o := range list {
+			o.fn(event)
+		}
+	}
+}
+
+/*
+added notifies the observers of the receiver instance of the addition of
+def, provided def is a member of the receiver instance.  This guards against
+pushes which were silently refused, such as those of duplicates.
+*/
+func (r Schema) added(def Definition, src uint) {
+	if r.observed() && r.member(def) == def {
+		r.notify(ChangeEvent{
+			Operation: DefinitionAdded,
+			Source:    src,
+			New:       def,
+		})
+	}
+}
+
+/*
+pushed notifies the observers of the [Schema] in which defs resides of
+the addition of x by way of a Push method.  Collections which do not reside
+within a [Schema] (e.g.: the MUST clause of an [ObjectClass]) are ignored.
+*/
+func pushed(defs Definitions, x any) {
+	def, ok := x.(Definition)
+	if !ok {
+		return
+	}
+
+	if sch := collectionSchema(defs); !sch.IsZero() {
+		if coll := sch.collectionOf(def); coll != nil && coll.cast() == defs.cast() {
+			sch.added(def, PushSource)
+		}
+	}
+}
+
+/*
+parseSource returns [MacroSource] if the numeric OID of a parsed definition
+was resolved from macro, or [ParseSource] otherwise.
+*/
+func parseSource(macro []string, oid string) uint {
+	if len(oid) == 0 && len(macro) == 2 {
+		return MacroSource
+	}
+
+	return ParseSource
+}
+
+/*
+pushDefinition returns an error following an attempt to push def into the
+appropriate collection of the receiver instance.  Observers are notified
+of the addition as originating from src.
+*/
+func (r Schema) pushDefinition(def Definition, src uint) (err error) {
+	switch tv := def.(type) {
+	case LDAPSyntax:
+		err = r.LDAPSyntaxes().push(tv)
+	case MatchingRule:
+		err = r.MatchingRules().push(tv)
+	case AttributeType:
+		err = r.AttributeTypes().push(tv)
+	case MatchingRuleUse:
+		err = r.MatchingRuleUses().push(tv)
+	case ObjectClass:
+		err = r.ObjectClasses().push(tv)
+	case DITContentRule:
+		err = r.DITContentRules().push(tv)
+	case NameForm:
+		err = r.NameForms().push(tv)
+	case DITStructureRule:
+		err = r.DITStructureRules().push(tv)
+	default:
+		err = ErrInvalidType
+	}
+
+	if err == nil {
+		r.added(def, src)
+	}
+
+	return
+}
+
+/*
+matchingRuleUseStates returns copies of all [MatchingRuleUse] instances
+within the receiver instance, keyed by numeric OID, for comparison by way
+of [Schema.matchingRuleUseChanges].  A nil map is returned if the receiver
+is not observed.
+*/
+func (r Schema) matchingRuleUseStates() (states map[string]MatchingRuleUse) {
+	if !r.observed() {
+		return
+	}
+
+	states = make(map[string]MatchingRuleUse)
+	mus := r.MatchingRuleUses()
+	for i := 0; i < mus.len(); i++ {
+		mu := mus.index(i)
+		states[mu.NumericOID()] = mu.Clone()
+	}
+
+	return
+}
+
+/*
+matchingRuleUseChanges notifies the observers of the receiver instance of
+each [MatchingRuleUse] added, modified or removed since states was produced.
+A [MatchingRuleUse] is regarded as modified if the numeric OIDs of its
+applied [AttributeType] instances differ from those of its original state.
+*/
+func (r Schema) matchingRuleUseChanges(states map[string]MatchingRuleUse) {
+	if states == nil {
+		return
+	}
+
+	var events []ChangeEvent
+	seen := make(map[string]bool)
+	mus := r.MatchingRuleUses()
+	for i := 0; i < mus.len(); i++ {
+		mu := mus.index(i)
+		seen[mu.NumericOID()] = true
+		if old, found := states[mu.NumericOID()]; !found {
+			events = append(events, ChangeEvent{
+				Operation: DefinitionAdded,
+				Source:    MatchingRuleUseSource,
+				New:       mu,
+			})
+		} else if !sameApplies(old, mu) {
+			events = append(events, ChangeEvent{
+				Operation: DefinitionReplaced,
+				Source:    MatchingRuleUseSource,
+				Old:       old,
+				New:       mu,
+			})
+		}
+	}
+
+	var gone []string
+	for oid := range states {
+		if !seen[oid] {
+			gone = append(gone, oid)
+		}
+	}
+	sort.Strings(gone)
+
+	for _, oid := range gone {
+		events = append(events, ChangeEvent{
+			Operation: DefinitionRemoved,
+			Source:    MatchingRuleUseSource,
+			Old:       states[oid],
+		})
+	}
+
+	r.notify(events...)
+}
+
+/*
+sameApplies returns a Boolean value indicative of whether a and b apply
+the same set of [AttributeType] instances, as identified by numeric OID.
+Order is not significant.
+*/
+func sameApplies(a, b MatchingRuleUse) bool {
+	oids := func(mu MatchingRuleUse) (list []string) {
+		aps := mu.Applies()
+		for i := 0; i < aps.len(); i++ {
+			list = append(list, aps.index(i).NumericOID())
+		}
+		return
+	}
+
+	x, y := oids(a), oids(b)
+
+	return len(x) == len(y) &&
+		len(sliceDifference(x, y)) == 0 &&
+		len(sliceDifference(y, x)) == 0
+}
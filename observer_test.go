@@ -0,0 +1,287 @@
+package schemax
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the registration of a [ChangeObserver], which
+receives a [ChangeEvent] for each definition added, replaced or removed.
+*/
+func ExampleSchema_Observe() {
+	sch := NewSchema(AllowOverride)
+	cancel := sch.Observe(func(event ChangeEvent) {
+		fmt.Println(event)
+	})
+	defer cancel()
+
+	if err := sch.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.99.1
+		NAME 'observedAttr'
+		SUP name )`); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	rep := sch.AttributeTypes().Get(`observedAttr`).Clone()
+	rep.attributeType.Desc = `replaced`
+	sch.Replace(rep.SetStringer())
+
+	if err := sch.Remove(rep); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// added attributeType 1.3.6.1.4.1.56521.999.99.1 (parse)
+	// replaced attributeType 1.3.6.1.4.1.56521.999.99.1 (replace)
+	// removed attributeType 1.3.6.1.4.1.56521.999.99.1 (remove)
+}
+
+func TestSchema_Observe(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+	sch.Macros().Set(`fakeMacro`, `1.3.6.1.4.1.56521.999`)
+
+	var events []ChangeEvent
+	cancel := sch.Observe(func(event ChangeEvent) {
+		// observers may freely query the schema
+		_ = sch.Counters()
+		events = append(events, event)
+	})
+
+	expect := func(idx int, want ...string) {
+		t.Helper()
+		if len(events) != len(want) {
+			t.Fatalf("%s[%d] failed: want %d events, got %d: %v", t.Name(), idx, len(want), len(events), events)
+		}
+		for i := range want {
+			if got := events[i].String(); got != want[i] {
+				t.Errorf("%s[%d] failed:\nwant: %s\ngot:  %s", t.Name(), idx, want[i], got)
+			}
+		}
+		events = nil
+	}
+
+	// push
+	at := sch.NewAttributeType().
+		SetNumericOID(`1.3.6.1.4.1.56521.999.99.2`).
+		SetName(`pushedAttr`).
+		SetSuperType(`name`).
+		SetStringer()
+	if err := sch.AttributeTypes().Push(at); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	expect(0, `added attributeType 1.3.6.1.4.1.56521.999.99.2 (push)`)
+
+	// a refused duplicate produces no event
+	sch.AttributeTypes().Push(at.Clone())
+	expect(1)
+
+	// macro resolution
+	if err := sch.ParseAttributeType(`( fakeMacro:99.3
+		NAME 'macroAttr'
+		SUP name )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	expect(2, `added attributeType 1.3.6.1.4.1.56521.999.99.3 (macro)`)
+
+	// parse, followed by MatchingRuleUse regeneration
+	if err := sch.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.99.4
+	NAME 'parsedAttr'
+	EQUALITY caseExactMatch
+	SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if err = sch.UpdateMatchingRuleUses(); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	expect(3, `added attributeType 1.3.6.1.4.1.56521.999.99.4 (parse)`,
+		`replaced matchingRuleUse 2.5.13.5 (matchingRuleUse)`)
+	if mu := sch.MatchingRuleUses().Get(`2.5.13.5`); !mu.Applies().Contains(`parsedAttr`) {
+		t.Fatalf("%s failed: MatchingRuleUse not updated", t.Name())
+	}
+
+	// replace, with preservation of the original
+	rep := sch.AttributeTypes().Get(`pushedAttr`).Clone()
+	rep.attributeType.Desc = `replaced`
+	sch.Replace(rep.SetStringer())
+	if len(events) != 1 {
+		t.Fatalf("%s failed: want 1 event, got %d", t.Name(), len(events))
+	} else if ev := events[0]; ev.Old.(AttributeType).Description() == `replaced` {
+		t.Errorf("%s failed: original state not preserved", t.Name())
+	} else if ev.New.(AttributeType).Description() != `replaced` || ev.New.Schema() != sch {
+		t.Errorf("%s failed: unexpected replacement %v", t.Name(), ev.New)
+	}
+	expect(4, `replaced attributeType 1.3.6.1.4.1.56521.999.99.2 (replace)`)
+
+	// remove, including the dropping of APPLIES values
+	if err := sch.Remove(sch.AttributeTypes().Get(`parsedAttr`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	if len(events) == 2 && !events[0].Old.(MatchingRuleUse).Applies().Contains(`parsedAttr`) {
+		t.Errorf("%s failed: original APPLIES clause not preserved", t.Name())
+	}
+	expect(5, `replaced matchingRuleUse 2.5.13.5 (matchingRuleUse)`,
+		`removed attributeType 1.3.6.1.4.1.56521.999.99.4 (remove)`)
+
+	// transaction
+	tx := sch.Begin()
+	if err := tx.ParseRaw([]byte(`objectClass ( 1.3.6.1.4.1.56521.999.99.5
+	NAME 'txObservedClass'
+	SUP top
+	AUXILIARY
+	MAY pushedAttr )`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	expect(6)
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	expect(7, `added objectClass 1.3.6.1.4.1.56521.999.99.5 (parse)`)
+
+	// cancellation
+	cancel()
+	cancel()
+	sch.ObjectClasses().Push(NewObjectClass())
+	if err := sch.Remove(sch.ObjectClasses().Get(`txObservedClass`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	expect(8)
+}
+
+func TestSchema_Observe_matchingRuleUses(t *testing.T) {
+	sch := NewSchema()
+
+	var events []ChangeEvent
+	sch.Observe(func(event ChangeEvent) {
+		events = append(events, event)
+	})
+
+	// A new MatchingRuleUse is produced for a matching
+	// rule not previously in use, and removed once its
+	// sole APPLIES value is removed.
+	if err := sch.ParseMatchingRule(`( 1.3.6.1.4.1.56521.999.99.6
+		NAME 'observedMatch'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if err = sch.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.99.7
+		NAME 'observedMatchAttr'
+		EQUALITY observedMatch
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if err = sch.UpdateMatchingRuleUses(); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	var got []string
+	for _, ev := range events {
+		got = append(got, ev.String())
+	}
+	want := []string{
+		`added matchingRule 1.3.6.1.4.1.56521.999.99.6 (parse)`,
+		`added attributeType 1.3.6.1.4.1.56521.999.99.7 (parse)`,
+		`added matchingRuleUse 1.3.6.1.4.1.56521.999.99.6 (matchingRuleUse)`,
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("%s failed:\nwant: %v\ngot:  %v", t.Name(), want, got)
+	}
+
+	events = nil
+	if _, err := sch.RemoveCascade(sch.AttributeTypes().Get(`observedMatchAttr`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if len(events) != 2 || events[0].Operation != DefinitionRemoved ||
+		events[0].Source != MatchingRuleUseSource || events[0].New != nil ||
+		events[0].Definition().NumericOID() != `1.3.6.1.4.1.56521.999.99.6` {
+		t.Errorf("%s failed: unexpected events %v", t.Name(), events)
+	}
+}
+
+func TestSchema_matchingRuleUseChanges(t *testing.T) {
+	sch := NewSchema()
+	if err := sch.ParseMatchingRule(`( 1.3.6.1.4.1.56521.999.99.8
+		NAME 'vanishedMatch'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	var events []ChangeEvent
+	sch.Observe(func(event ChangeEvent) {
+		events = append(events, event)
+	})
+
+	// An original state whose APPLIES clause is of the same
+	// length, but which differs in content, is still changed.
+	states := sch.matchingRuleUseStates()
+	mu := sch.MatchingRuleUses().Get(`2.5.13.5`)
+	var applies []any
+	for i := 0; i < mu.Applies().len(); i++ {
+		applies = append(applies, mu.Applies().index(i))
+	}
+	applies[0] = sch.AttributeTypes().Get(`objectClass`)
+	states[`2.5.13.5`] = sch.NewMatchingRuleUse().
+		SetNumericOID(`2.5.13.5`).
+		SetApplies(applies...)
+
+	// An original state no longer present was removed.
+	states[`1.3.6.1.4.1.56521.999.99.8`] = sch.NewMatchingRuleUse().
+		SetNumericOID(`1.3.6.1.4.1.56521.999.99.8`).
+		SetApplies(`name`)
+
+	sch.matchingRuleUseChanges(states)
+
+	var got []string
+	for _, ev := range events {
+		got = append(got, ev.String())
+	}
+	want := []string{
+		`replaced matchingRuleUse 2.5.13.5 (matchingRuleUse)`,
+		`removed matchingRuleUse 1.3.6.1.4.1.56521.999.99.8 (matchingRuleUse)`,
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("%s failed:\nwant: %v\ngot:  %v", t.Name(), want, got)
+	} else if events[1].New != nil || events[1].Old != states[`1.3.6.1.4.1.56521.999.99.8`] {
+		t.Errorf("%s failed: unexpected removal %#v", t.Name(), events[1])
+	}
+}
+
+func TestSchema_Observe_codecov(t *testing.T) {
+	var zero Schema
+	zero.Observe(func(ChangeEvent) {})()
+	zero.notify(ChangeEvent{})
+	if zero.observed() {
+		t.Errorf("%s failed: zero schema observed", t.Name())
+	}
+
+	sch := NewSchema()
+	sch.Observe(nil)()
+	if sch.observed() {
+		t.Errorf("%s failed: nil observer registered", t.Name())
+	}
+
+	if s := (ChangeEvent{}).String(); s != `` {
+		t.Errorf("%s failed: unexpected string %q", t.Name(), s)
+	}
+
+	var n int
+	sch.Observe(func(ChangeEvent) { n++ })
+	pushed(sch.AttributeTypes(), `bogus`)
+	pushed(NewAttributeTypes(), sch.AttributeTypes().Get(`cn`))
+	pushed(sch.ObjectClasses(), sch.AttributeTypes().Get(`cn`))
+	if err := sch.pushDefinition(nil, PushSource); err != ErrInvalidType {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrInvalidType, err)
+	} else if n != 0 {
+		t.Errorf("%s failed: unexpected events", t.Name())
+	}
+
+	for _, def := range []Definition{
+		sch.LDAPSyntaxes().Index(0).Clone(),
+		sch.MatchingRules().Index(0).Clone(),
+		sch.MatchingRuleUses().Index(0).Clone(),
+		sch.DITContentRules().Index(0).Clone(),
+		sch.NameForms().Index(0).Clone(),
+		sch.DITStructureRules().Index(0).Clone(),
+	} {
+		// duplicates are refused silently
+		_ = sch.pushDefinition(def, PushSource)
+	}
+	if n != 0 {
+		t.Errorf("%s failed: events produced for refused duplicates", t.Name())
+	}
+}
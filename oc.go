@@ -73,6 +73,7 @@ an [ObjectClass] instance.
 func NewObjectClass() ObjectClass {
 	oc := ObjectClass{newObjectClass()}
 	oc.objectClass.Extensions.setDefinition(oc)
+	oc.objectClass.Name.setDefinition(oc)
 	return oc
 }
 
@@ -130,7 +131,7 @@ func (r ObjectClass) Marshal(def any) error {
 		}
 	}
 
-	if !r.Compliant() {
+	if !r.compliant() {
 		return ErrDefNonCompliant
 	}
 	r.SetStringer()
@@ -290,14 +291,24 @@ func (r ObjectClass) SetName(x ...string) ObjectClass {
 }
 
 func (r *objectClass) setName(x ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	b4 := r.Name.Len()
 	for i := 0; i < len(x); i++ {
-		r.Name.Push(x[i])
+		r.Name.push(x[i])
 	}
 
 	if r.Name.Len()-len(x) != b4 {
 		r.err = ErrInvalidNames
 	}
+
+	r.schema.reindex(ObjectClass{r})
 }
 
 /*
@@ -316,6 +327,14 @@ func (r ObjectClass) SetData(x any) ObjectClass {
 }
 
 func (r *objectClass) setData(x any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.data = x
 }
 
@@ -346,6 +365,14 @@ func (r ObjectClass) SetExtension(x string, xstrs ...string) ObjectClass {
 }
 
 func (r *objectClass) setExtension(x string, xstrs ...string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.Extensions.Set(x, xstrs...)
 }
 
@@ -370,6 +397,14 @@ func (r ObjectClass) SetKind(k any) ObjectClass {
 }
 
 func (r *objectClass) setKind(k any) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	switch tv := k.(type) {
 	case string:
 		switch lc(tv) {
@@ -409,6 +444,11 @@ func (r ObjectClass) SetMust(m ...any) ObjectClass {
 }
 
 func (r *objectClass) setMust(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -427,7 +467,7 @@ func (r *objectClass) setMust(m ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -446,6 +486,11 @@ func (r ObjectClass) SetMay(m ...any) ObjectClass {
 }
 
 func (r *objectClass) setMay(m ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(m) && err == nil; i++ {
 		var at AttributeType
@@ -464,7 +509,7 @@ func (r *objectClass) setMay(m ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -485,6 +530,11 @@ func (r ObjectClass) SetSuperClass(x ...any) ObjectClass {
 }
 
 func (r *objectClass) setSuperClass(x ...any) {
+	if r.schema.IsReadOnly() {
+		r.schema.refuse(&r.err)
+		return
+	}
+
 	var err error
 	for i := 0; i < len(x) && err == nil; i++ {
 		var sup ObjectClass
@@ -505,7 +555,7 @@ func (r *objectClass) setSuperClass(x ...any) {
 	}
 
 	if err != nil {
-		r.err = err
+		r.schema.modify(func() { r.err = err })
 	}
 }
 
@@ -529,7 +579,7 @@ func (r ObjectClass) Replace(x ObjectClass) ObjectClass {
 		return r
 	}
 
-	if !r.IsZero() && x.Compliant() {
+	if !r.IsZero() && x.compliant() {
 		r.objectClass.replace(x)
 	}
 
@@ -537,6 +587,15 @@ func (r ObjectClass) Replace(x ObjectClass) ObjectClass {
 }
 
 func (r *objectClass) replace(x ObjectClass) {
+	sch := r.schema
+	unlock, ok := sch.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if r.OID == `` {
 		r.err = ErrMissingNumericOID
 		return
@@ -548,6 +607,7 @@ func (r *objectClass) replace(x ObjectClass) {
 	r.OID = x.objectClass.OID
 	r.Macro = x.objectClass.Macro
 	r.Name = x.objectClass.Name
+	r.Name.setDefinition(ObjectClass{r})
 	r.Desc = x.objectClass.Desc
 	r.Obsolete = x.objectClass.Obsolete
 	r.Kind = x.objectClass.Kind
@@ -559,6 +619,44 @@ func (r *objectClass) replace(x ObjectClass) {
 	r.schema = x.objectClass.schema
 	r.stringer = x.objectClass.stringer
 	r.data = x.objectClass.data
+
+	sch.reindex(ObjectClass{r})
+}
+
+/*
+Clone returns a deep copy of the receiver instance.  Modification of
+the return instance -- such as by way of the [ObjectClass.SetMust]
+method -- shall not influence the receiver instance.
+
+Referenced definitions, such as the SUP, MUST and MAY values, are NOT
+cloned; use [Schema.Clone] to clone an entire [Schema] along with all
+internal references.
+
+Note that use of the [ObjectClass.SetStringer] method may be needed
+following any changes to the return instance.
+*/
+func (r ObjectClass) Clone() (c ObjectClass) {
+	if r.IsZero() {
+		return
+	}
+
+	_c := *r.objectClass
+	c = ObjectClass{&_c}
+	_c.Macro = cloneStrings(r.objectClass.Macro)
+	_c.Name = cloneName(r.objectClass.Name, c)
+	_c.SuperClasses = r.objectClass.SuperClasses.cloneOIDList()
+	_c.Must = r.objectClass.Must.cloneOIDList()
+	_c.May = r.objectClass.May.cloneOIDList()
+	_c.Extensions = cloneExtensions(r.objectClass.Extensions, c)
+
+	return
+}
+
+func (r *objectClass) rewire(sch Schema) {
+	r.schema = sch
+	r.SuperClasses = r.SuperClasses.counterparts(sch)
+	r.Must = r.Must.counterparts(sch)
+	r.May = r.May.counterparts(sch)
 }
 
 /*
@@ -578,7 +676,7 @@ Map marshals the receiver instance into an instance of
 [DefinitionMap].
 */
 func (r ObjectClass) Map() (def DefinitionMap) {
-	if !r.Compliant() {
+	if !r.compliant() {
 		return
 	}
 
@@ -655,7 +753,22 @@ compliant per the required clauses of [§ 4.1.1 of RFC 4512]:
 
 [§ 4.1.1 of RFC 4512]: https://rfc-editor.org/rfc/rfc4512.html#section-4.1.1
 */
-func (r ObjectClass) Compliant() bool {
+func (r ObjectClass) Compliant() (ok bool) {
+	if ok = r.compliant(); ok {
+		unlock, _ := r.objectClass.schema.lock()
+		defer unlock()
+
+		r.objectClass.err = nil
+	}
+
+	return
+}
+
+/*
+compliant is the side-effect free counterpart of Compliant, for use
+upon paths which merely read the receiver.
+*/
+func (r ObjectClass) compliant() bool {
 	if r.IsZero() {
 		return false
 	}
@@ -666,24 +779,18 @@ func (r ObjectClass) Compliant() bool {
 	)
 
 	for i := 0; i < must.Len(); i++ {
-		if !must.Index(i).Compliant() || must.Index(i).Collective() {
+		if !must.Index(i).compliant() || must.Index(i).Collective() {
 			return false
 		}
 	}
 
 	for i := 0; i < may.Len(); i++ {
-		if !may.Index(i).Compliant() || may.Index(i).Collective() {
+		if !may.Index(i).compliant() || may.Index(i).Collective() {
 			return false
 		}
 	}
 
-	ok := isNumericOID(r.NumericOID())
-	if ok {
-		r.objectClass.err = nil
-	}
-
-	return ok
-
+	return isNumericOID(r.NumericOID())
 }
 
 /*
@@ -691,6 +798,9 @@ E returns the underlying error instance.
 */
 func (r ObjectClass) E() (err error) {
 	if !r.IsZero() {
+		runlock := r.objectClass.schema.rlock()
+		defer runlock()
+
 		err = r.objectClass.err
 	} else {
 		err = ErrNilReceiver
@@ -774,6 +884,14 @@ func (r ObjectClass) SetObsolete() ObjectClass {
 }
 
 func (r *objectClass) setObsolete() {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if !r.Obsolete {
 		r.Obsolete = true
 	}
@@ -855,8 +973,8 @@ func (r ObjectClass) SuperChain() (sups ObjectClasses) {
 	if !r.IsZero() {
 		sups = NewObjectClasses()
 		_sups := r.objectClass.SuperClasses.superChain()
-		for i := 0; i < _sups.Len(); i++ {
-			sups.Push(_sups.Index(i))
+		for i := 0; i < _sups.cast().Len(); i++ {
+			sups.cast().Push(_sups.at(i))
 		}
 	}
 
@@ -864,12 +982,14 @@ func (r ObjectClass) SuperChain() (sups ObjectClasses) {
 }
 
 func (r ObjectClasses) superChain() (sups ObjectClasses) {
+	// sups is private to the caller, thus it
+	// is populated and read without locking.
 	sups = NewObjectClasses()
 	for i := 0; i < r.Len(); i++ {
-		sups.Push(r.Index(i))
+		sups.cast().Push(r.Index(i))
 		_sups := r.Index(i).SuperChain()
-		for j := 0; j < _sups.Len(); j++ {
-			sups.Push(_sups.Index(j))
+		for j := 0; j < _sups.cast().Len(); j++ {
+			sups.cast().Push(_sups.at(j))
 		}
 	}
 
@@ -928,6 +1048,14 @@ func (r ObjectClass) SetSchema(schema Schema) ObjectClass {
 }
 
 func (r *objectClass) setSchema(schema Schema) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	r.schema = schema
 }
 
@@ -964,7 +1092,7 @@ func (r ObjectClass) AllMust() (must AttributeTypes) {
 			sm := sup.index(i)
 			if sc := sm.AllMust(); !sc.IsZero() {
 				for j := 0; j < sc.len(); j++ {
-					must.push(sc.index(i))
+					must.push(sc.index(j))
 				}
 			}
 		}
@@ -1005,7 +1133,7 @@ func (r ObjectClass) AllMay() (may AttributeTypes) {
 			sm := sup.index(i)
 			if sc := sm.AllMay(); !sc.IsZero() {
 				for j := 0; j < sc.len(); j++ {
-					may.push(sc.index(i))
+					may.push(sc.index(j))
 				}
 			}
 		}
@@ -1174,6 +1302,14 @@ func (r ObjectClass) SetNumericOID(id string) ObjectClass {
 }
 
 func (r *objectClass) setNumericOID(id string) {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
 	if isNumericOID(id) {
 		if len(r.OID) == 0 {
 			r.OID = id
@@ -1233,7 +1369,7 @@ stringer.
 This is a fluent method and may be used multiple times.
 */
 func (r ObjectClass) SetStringer(function ...Stringer) ObjectClass {
-	if r.Compliant() {
+	if r.compliant() {
 		r.objectClass.setStringer(function...)
 	}
 
@@ -1246,17 +1382,26 @@ func (r *objectClass) setStringer(function ...Stringer) {
 		stringer = function[0]
 	}
 
+	var err error
 	if stringer == nil {
-		str, err := r.prepareString() // perform one-time text/template op
-		if err == nil {
-			// Save the stringer
-			r.stringer = func() string {
-				// Return a preserved value.
-				return str
-			}
-		} else {
-			r.err = err
+		// no user provided closure means we
+		// defer to a general use stringer.
+		var str string
+		str, err = r.prepareString() // perform one-time text/template op
+		stringer = func() string {
+			// Return a preserved value.
+			return str
 		}
+	}
+
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	} else if err != nil {
+		r.err = err
 		return
 	}
 
@@ -1274,7 +1419,7 @@ func (r ObjectClasses) XOrigin(x string) (defs ObjectClasses) {
 		def := r.Index(i)
 		if xo, found := def.Extensions().Get(`X-ORIGIN`); found {
 			if xo.Contains(x) {
-				defs.push(def)
+				defs.cast().Push(def)
 			}
 		}
 	}
@@ -1377,7 +1522,15 @@ func (r ObjectClass) SetDescription(desc string) ObjectClass {
 }
 
 func (r *objectClass) setDescription(desc string) {
-	if len(desc) < 3 {
+	unlock, ok := r.schema.lock()
+	defer unlock()
+
+	if !ok {
+		r.err = ErrReadOnlySchema
+		return
+	}
+
+	if len(desc) == 0 {
 		return
 	}
 
@@ -1385,7 +1538,7 @@ func (r *objectClass) setDescription(desc string) {
 		desc = desc[1:]
 	}
 
-	if rune(desc[len(desc)-1]) == rune(39) {
+	if len(desc) > 0 && rune(desc[len(desc)-1]) == rune(39) {
 		desc = desc[:len(desc)-1]
 	}
 
@@ -1517,8 +1670,8 @@ func (r ObjectClasses) canPush(x ...any) (err error) {
 			break
 		}
 
-		if r.contains(oc.objectClass.OID) {
-			err = mkerr(ErrNotUnique.Error() + ": " + oc.Type() + `, ` + oc.NumericOID())
+		if !r.lookup(oc.objectClass.OID).IsZero() {
+			err = wraperr(ErrNotUnique, ": "+oc.Type()+`, `+oc.NumericOID())
 			break
 		}
 	}
@@ -1534,6 +1687,9 @@ func (r ObjectClasses) Len() int {
 }
 
 func (r ObjectClasses) len() int {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
 	return r.cast().Len()
 }
 
@@ -1554,7 +1710,17 @@ func (r ObjectClasses) Index(idx int) ObjectClass {
 	return r.index(idx)
 }
 
-func (r ObjectClasses) index(idx int) (oc ObjectClass) {
+func (r ObjectClasses) index(idx int) ObjectClass {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.at(idx)
+}
+
+/*
+at is the unlocked counterpart of index.
+*/
+func (r ObjectClasses) at(idx int) (oc ObjectClass) {
 	slice, found := r.cast().Index(idx)
 	if found {
 		if _oc, ok := slice.(ObjectClass); ok {
@@ -1569,18 +1735,22 @@ func (r ObjectClasses) index(idx int) (oc ObjectClass) {
 Push returns an error following an attempt to push an [ObjectClass]
 into the receiver instance.
 */
-func (r ObjectClasses) Push(oc any) error {
-	return r.push(oc)
+func (r ObjectClasses) Push(oc any) (err error) {
+	if err = r.push(oc); err == nil {
+		pushed(r, oc)
+	}
+
+	return
 }
 
 func (r ObjectClasses) push(x any) (err error) {
 	switch tv := x.(type) {
 	case ObjectClass:
-		if !tv.Compliant() {
+		if !tv.compliant() {
 			err = ErrDefNonCompliant
 			break
 		}
-		r.cast().Push(tv)
+		err = lockedPush(r.cast(), tv)
 	default:
 		err = ErrInvalidType
 	}
@@ -1616,9 +1786,28 @@ func (r ObjectClasses) Get(id string) ObjectClass {
 	return r.get(id)
 }
 
-func (r ObjectClasses) get(id string) (oc ObjectClass) {
-	for i := 0; i < r.len() && oc.IsZero(); i++ {
-		if _oc := r.index(i); !_oc.IsZero() {
+func (r ObjectClasses) get(id string) ObjectClass {
+	runlock := rlockCollection(r.cast())
+	defer runlock()
+
+	return r.lookup(id)
+}
+
+/*
+lookup is the unlocked counterpart of get, for use where the lock is
+already held, such as during a push.
+*/
+func (r ObjectClasses) lookup(id string) (oc ObjectClass) {
+	if slice, indexed := indexLookup(r.cast(), id, func(x any) bool {
+		_oc, _ := x.(ObjectClass)
+		return _oc.IsIdentifiedAs(id)
+	}); indexed {
+		oc, _ = slice.(ObjectClass)
+		return
+	}
+
+	for i := 0; i < r.cast().Len() && oc.IsZero(); i++ {
+		if _oc := r.at(i); !_oc.IsZero() {
 			if _oc.objectClass.OID == id {
 				oc = _oc
 			} else if _oc.objectClass.Name.contains(id) {
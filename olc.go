@@ -0,0 +1,217 @@
+package schemax
+
+/*
+olc.go contains facilities for the reading and writing of OpenLDAP
+cn=config schema entries (e.g.: "cn={4}nis.ldif").
+*/
+
+import "os"
+
+/*
+olcAttributes contains the cn=config attribute types which bear schema
+content, alongside the definition type (or directive) each describes.
+The order of this slice is the order in which values are written.
+*/
+var olcAttributes [][2]string = [][2]string{
+	{`olcObjectIdentifier`, `objectIdentifier`},
+	{`olcLdapSyntaxes`, `ldapSyntax`},
+	{`olcAttributeTypes`, `attributeType`},
+	{`olcObjectClasses`, `objectClass`},
+	{`olcDitContentRules`, `dITContentRule`},
+}
+
+/*
+olcType returns the definition type (or directive) associated with the
+cn=config attribute type attr, or a zero string if attr does not bear
+schema content.  Case is not significant, and attribute options (e.g.:
+";x-foo") are ignored.
+*/
+func olcType(attr string) (typ string) {
+	if idx := stridx(attr, `;`); idx != -1 {
+		attr = attr[:idx]
+	}
+
+	for _, pair := range olcAttributes {
+		if eq(attr, pair[0]) {
+			typ = pair[1]
+			break
+		}
+	}
+
+	return
+}
+
+/*
+ParseOLC returns an error following an attempt to parse raw, which must
+contain one or more RFC 2849 LDIF records as stored by the cn=config backend
+of OpenLDAP, e.g.:
+
+	dn: cn=nis,cn=schema,cn=config
+	objectClass: olcSchemaConfig
+	cn: nis
+	olcObjectIdentifier: {0}nisSchema 1.3.6.1.1.1
+	olcAttributeTypes: {0}( 1.3.6.1.1.1.1.0 NAME 'uidNumber'
+	  DESC 'An integer uniquely identifying a user in an administrative domain'
+	  EQUALITY integerMatch ORDERING integerOrderingMatch
+	  SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 SINGLE-VALUE )
+
+Values of the olcObjectIdentifier, olcLdapSyntaxes, olcAttributeTypes,
+olcObjectClasses and olcDitContentRules attribute types are processed.
+All other content, such as the DN and the olcSchemaConfig object class, is
+ignored.  Folded lines are unfolded, base64 values are decoded and the
+"{N}" ordering prefix of each value is removed.
+
+Definitions are written to the receiver instance in the manner described
+by the [Schema.ParseRaw] method, thus the order of values is not significant
+and olcObjectIdentifier values may be referenced by any definition.
+
+Failures are returned as [ParseError] instances bearing the line number of
+the value in question.  Parsing ceases upon the first error unless the
+[ContinueOnError] option is in effect, in which case all failures are
+returned.
+*/
+func (r Schema) ParseOLC(raw []byte) error {
+	return r.parseOLC(raw, ``)
+}
+
+/*
+ParseOLCFile returns an error following an attempt to parse file in the
+manner described by the [Schema.ParseOLC] method. Only files ending in
+".ldif" will be considered.
+*/
+func (r Schema) ParseOLCFile(file string) (err error) {
+	if !hasSfx(file, `.ldif`) {
+		err = mkerr("Filename '" + file + "' does not end in '.ldif'; will not parse")
+		return
+	}
+
+	var raw []byte
+	if raw, err = os.ReadFile(file); err == nil {
+		err = r.parseOLC(raw, file)
+	}
+
+	return
+}
+
+func (r Schema) parseOLC(raw []byte, file string) (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	defs, errs := scanOLC(string(raw), file)
+	err = joinErr(r.incorporateRaw(defs, errs,
+		r.Options().Positive(ContinueOnError))...)
+
+	return
+}
+
+/*
+scanOLC returns the schema values found within the LDIF content held by
+raw in the form of definitions and directives, less their "{N}" ordering
+prefixes, alongside any failures encountered.
+*/
+func scanOLC(raw, file string) (defs []rawDefinition, errs []error) {
+	defs, _, errs = scanLDIF(raw, file, olcType)
+	for i := range defs {
+		defs[i].Text = trimOLCOrder(defs[i].Text)
+	}
+
+	return
+}
+
+/*
+trimOLCOrder returns val less any leading "{N}" ordering prefix.
+*/
+func trimOLCOrder(val string) string {
+	if hasPfx(val, `{`) {
+		if idx := stridx(val, `}`); idx > 1 {
+			if _, ok := atoui(val[1:idx]); ok {
+				val = trimS(val[idx+1:])
+			}
+		}
+	}
+
+	return val
+}
+
+/*
+OLCLDIF returns an RFC 2849 LDIF entry, alongside an error, suitable for
+the addition of the definitions within defs to the cn=config backend of
+OpenLDAP as a schema entry named cn, e.g.:
+
+	dn: cn=myschema,cn=schema,cn=config
+	objectClass: olcSchemaConfig
+	cn: myschema
+	olcAttributeTypes: {0}( 1.3.6.1.4.1.56521.999.1 NAME 'myAttr' SUP name )
+	olcObjectClasses: {0}( 1.3.6.1.4.1.56521.999.2 NAME 'myClass' ... )
+
+Only [LDAPSyntaxes], [AttributeTypes], [ObjectClasses] and [DITContentRules]
+instances may be submitted, as cn=config offers no means of storing other
+definition types.  Should defs be omitted, the respective collections of the
+receiver instance are used.  This is useful when the receiver instance was
+initialized via [NewEmptySchema] and populated with a subset of definitions.
+
+Values are written in order of type precedence and, within each type, in
+order of appearance.  Each value bears a "{N}" ordering prefix.  Numeric
+OIDs are always written, thus no olcObjectIdentifier values are produced.
+
+Lines longer than seventy-six (76) characters are folded, and values which
+do not qualify as SAFE-STRING are base64 encoded, per RFC 2849.
+*/
+func (r Schema) OLCLDIF(cn string, defs ...Definitions) (ldif string, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	} else if len(cn) == 0 {
+		err = wraperr(ErrNilInput, ": missing schema entry name")
+		return
+	}
+
+	if len(defs) == 0 {
+		defs = []Definitions{
+			r.LDAPSyntaxes(),
+			r.AttributeTypes(),
+			r.ObjectClasses(),
+			r.DITContentRules(),
+		}
+	}
+
+	vals := make(map[string][]string)
+	for _, d := range defs {
+		for _, def := range collectionDefinitions(d) {
+			typ := def.Type()
+			switch typ {
+			case `ldapSyntax`, `attributeType`, `objectClass`, `dITContentRule`:
+			default:
+				err = wraperr(ErrInvalidType, `: `+typ+
+					` definitions cannot be stored within cn=config`)
+				return
+			}
+
+			val := condenseWHSP(def.String())
+			if len(val) == 0 {
+				err = wraperr(ErrDefNonCompliant, ": no string value for "+
+					typ+` `+defIdentity(def))
+				return
+			}
+			vals[typ] = append(vals[typ], `{`+itoa(len(vals[typ]))+`}`+val)
+		}
+	}
+
+	lines := []string{
+		ldifLine(`dn`, `cn=`+cn+`,cn=schema,cn=config`),
+		ldifLine(`objectClass`, `olcSchemaConfig`),
+		ldifLine(`cn`, cn),
+	}
+
+	for _, pair := range olcAttributes {
+		for _, val := range vals[pair[1]] {
+			lines = append(lines, ldifLine(pair[0], val))
+		}
+	}
+
+	ldif = join(lines, string(rune(10))) + string(rune(10))
+
+	return
+}
@@ -0,0 +1,204 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+This example demonstrates the parsing of an OpenLDAP cn=config schema
+entry bearing folded lines, ordering prefixes and macro-based OIDs.
+*/
+func ExampleSchema_ParseOLC() {
+	sch := NewSchema()
+
+	raw := []byte(`# AUTO-GENERATED FILE - DO NOT EDIT!!
+dn: cn={4}fake
+objectClass: olcSchemaConfig
+cn: {4}fake
+olcObjectIdentifier: {0}fakeRoot 1.3.6.1.4.1.56521.999.85
+olcObjectClasses: {0}( fakeRoot:2 NAME 'fakeClass' SUP top AUXILIARY
+  MAY fakeAttr )
+olcAttributeTypes: {0}( fakeRoot:1 NAME 'fakeAttr' DESC 'Fake attrib
+ ute' SUP name )
+structuralObjectClass: olcSchemaConfig`)
+
+	if err := sch.ParseOLC(raw); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(sch.AttributeTypes().Get(`fakeAttr`))
+	fmt.Println(sch.ObjectClasses().Get(`fakeClass`))
+	// Output:
+	// ( 1.3.6.1.4.1.56521.999.85.1 NAME 'fakeAttr' DESC 'Fake attribute' SUP name )
+	// ( 1.3.6.1.4.1.56521.999.85.2 NAME 'fakeClass' SUP top AUXILIARY MAY fakeAttr )
+}
+
+/*
+This example demonstrates the rendering of a subset of definitions as an
+OpenLDAP cn=config schema entry.
+*/
+func ExampleSchema_OLCLDIF() {
+	sch := NewSchema()
+	if err := sch.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.85.3 NAME 'fakeOLCAttr' SUP name )`)); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	ats := NewAttributeTypes()
+	ats.Push(sch.AttributeTypes().Get(`fakeOLCAttr`))
+
+	ldif, err := sch.OLCLDIF(`fake`, ats)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Print(ldif)
+	// Output:
+	// dn: cn=fake,cn=schema,cn=config
+	// objectClass: olcSchemaConfig
+	// cn: fake
+	// olcAttributeTypes: {0}( 1.3.6.1.4.1.56521.999.85.3 NAME 'fakeOLCAttr' SUP na
+	//  me )
+}
+
+func TestSchema_OLC(t *testing.T) {
+	sch := NewSchema()
+	if err := sch.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.85.4
+	NAME 'fakeOLCAttr'
+	DESC 'A rather long description of a fake attribute, bearing non-ASCII content: é'
+	EQUALITY caseIgnoreMatch
+	SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{64} )
+
+objectClass ( 1.3.6.1.4.1.56521.999.85.5
+	NAME 'fakeOLCClass'
+	SUP top AUXILIARY
+	MAY fakeOLCAttr )
+
+dITContentRule ( 2.5.6.6
+	NAME 'personContentRule'
+	AUX fakeOLCClass )`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	subset := NewEmptySchema()
+	subset.Push(sch.AttributeTypes().Get(`fakeOLCAttr`))
+	subset.Push(sch.ObjectClasses().Get(`fakeOLCClass`))
+	subset.Push(sch.DITContentRules().Get(`personContentRule`))
+
+	ldif, err := subset.OLCLDIF(`fake`)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	tempDir, err := os.MkdirTemp("", "schemax_olc")
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+
+	file := filepath.Join(tempDir, `cn={4}fake.ldif`)
+	if err = os.WriteFile(file, []byte(ldif), 0644); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	imported := NewSchema()
+	if err = imported.ParseOLCFile(file); err != nil {
+		t.Errorf("%s failed: %v\n%s", t.Name(), err, ldif)
+		return
+	}
+
+	for _, pair := range [][]Definition{
+		{sch.AttributeTypes().Get(`fakeOLCAttr`), imported.AttributeTypes().Get(`fakeOLCAttr`)},
+		{sch.ObjectClasses().Get(`fakeOLCClass`), imported.ObjectClasses().Get(`fakeOLCClass`)},
+		{sch.DITContentRules().Get(`personContentRule`), imported.DITContentRules().Get(`personContentRule`)},
+	} {
+		if want, got := pair[0].String(), pair[1].String(); want != got {
+			t.Errorf("%s failed:\nwant: %s\ngot:  %s", t.Name(), want, got)
+			return
+		}
+	}
+}
+
+func TestSchema_ParseOLC_errors(t *testing.T) {
+	raw := []byte(`dn: cn=fake,cn=schema,cn=config
+olcAttributeTypes: {0}( 1.3.6.1.4.1.56521.999.85.6 NAME 'fakeAttr' SUP bogusAttr )
+olcAttributeTypes:: !!!
+olcObjectClasses:< file:///tmp/fake
+bogus line`)
+
+	if err := NewSchema().ParseOLC(raw); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+		return
+	}
+
+	err := NewSchema(ContinueOnError).ParseOLC(raw)
+	for _, want := range []error{
+		ErrAttributeTypeNotFound,
+		ErrUnrecognizedContent,
+	} {
+		if !errors.Is(err, want) {
+			t.Errorf("%s failed: expected %v, got %v", t.Name(), want, err)
+			return
+		}
+	}
+
+	var perr ParseError
+	if !errors.As(err, &perr) || perr.Line != 2 {
+		t.Errorf("%s failed: unexpected first error: %#v", t.Name(), perr)
+		return
+	}
+}
+
+func TestOLC_codecov(t *testing.T) {
+	var sch Schema
+	if err := sch.ParseOLC(nil); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+	if _, err := sch.OLCLDIF(`fake`); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	sch = NewSchema()
+	if err := sch.ParseOLCFile(`fake.schema`); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+	if err := sch.ParseOLCFile(`/nonexistent.ldif`); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+	if _, err := sch.OLCLDIF(``); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+	if _, err := sch.OLCLDIF(`fake`, sch.MatchingRules()); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrInvalidType, err)
+	}
+
+	// modify records and attribute options
+	if err := sch.ParseOLC([]byte(`dn: cn={4}fake,cn=schema,cn=config
+changetype: modify
+add: olcAttributeTypes
+olcAttributeTypes;x-fake: ( 1.3.6.1.4.1.56521.999.85.7 NAME 'fakeModAttr' SUP name )
+-
+`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+	}
+
+	for in, want := range map[string]string{
+		`{0}( 1.2.3 )`: `( 1.2.3 )`,
+		`{x}( 1.2.3 )`: `{x}( 1.2.3 )`,
+		`{}`:           `{}`,
+	} {
+		if got := trimOLCOrder(in); got != want {
+			t.Errorf("%s failed: want %q, got %q", t.Name(), want, got)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package schemax
 
 import (
+	"io/fs"
+	"os"
+	"path/filepath"
 	"sort"
 
 	"github.com/JesseCoretta/go-antlr4512"
@@ -28,6 +31,7 @@ func (r Schema) ParseLDAPSyntax(raw string) error {
 		var _def LDAPSyntax
 		if _def, err = r.marshalLS(def); err == nil {
 			r.LDAPSyntaxes().push(_def)
+			r.added(_def, parseSource(def.Macro, def.OID))
 		}
 	}
 
@@ -44,6 +48,7 @@ func (r Schema) ParseMatchingRule(raw string) error {
 		var _def MatchingRule
 		if _def, err = r.marshalMR(def); err == nil {
 			r.MatchingRules().push(_def)
+			r.added(_def, parseSource(def.Macro, def.OID))
 		}
 	}
 
@@ -60,6 +65,7 @@ func (r Schema) ParseMatchingRuleUse(raw string) error {
 		var _def MatchingRuleUse
 		if _def, err = r.marshalMU(def); err == nil {
 			r.MatchingRuleUses().push(_def)
+			r.added(_def, parseSource(def.Macro, def.OID))
 		}
 	}
 
@@ -76,6 +82,7 @@ func (r Schema) ParseAttributeType(raw string) error {
 		var _def AttributeType
 		if _def, err = r.marshalAT(def); err == nil {
 			r.AttributeTypes().push(_def)
+			r.added(_def, parseSource(def.Macro, def.OID))
 		}
 	}
 
@@ -92,6 +99,7 @@ func (r Schema) ParseObjectClass(raw string) error {
 		var _def ObjectClass
 		if _def, err = r.marshalOC(def); err == nil {
 			r.ObjectClasses().push(_def)
+			r.added(_def, parseSource(def.Macro, def.OID))
 		}
 	}
 
@@ -108,6 +116,7 @@ func (r Schema) ParseDITContentRule(raw string) error {
 		var _def DITContentRule
 		if _def, err = r.marshalDC(def); err == nil {
 			r.DITContentRules().push(_def)
+			r.added(_def, parseSource(def.Macro, def.OID))
 		}
 	}
 
@@ -124,6 +133,7 @@ func (r Schema) ParseNameForm(raw string) error {
 		var _def NameForm
 		if _def, err = r.marshalNF(def); err == nil {
 			r.NameForms().push(_def)
+			r.added(_def, parseSource(def.Macro, def.OID))
 		}
 	}
 
@@ -140,6 +150,7 @@ func (r Schema) ParseDITStructureRule(raw string) error {
 		var _def DITStructureRule
 		if _def, err = r.marshalDS(def); err == nil {
 			r.DITStructureRules().push(_def)
+			r.added(_def, ParseSource)
 		}
 	}
 
@@ -152,20 +163,32 @@ of s into r. The concept of "incorporation" is another term for post-parsing
 "hand-off" from antlr4512 to schemax, and is the last of two (2) phases
 involving the antlr4512 package.
 
+Definitions are incorporated in order of type precedence, namely syntaxes,
+matching rules, attribute types, matching rule uses, object classes, DIT
+content rules, name forms and, lastly, DIT structure rules.  Within each
+type, definitions are ordered such that superior definitions (e.g.: a
+supertype) are incorporated before their subordinates, regardless of the
+order in which they were originally parsed.  Circular and unsatisfiable
+superior references result in an error.
+
 Empty slice types within s shall not result in an error.
 */
 func (r Schema) incorporate(s antlr4512.Schema) (err error) {
-	for _, err := range []error{
-		r.incorporateLS(s.LS),
-		r.incorporateMR(s.MR),
-		r.incorporateAT(s.AT),
-		r.incorporateMU(s.MU),
-		r.incorporateOC(s.OC),
-		r.incorporateDC(s.DC),
-		r.incorporateNF(s.NF),
-		r.incorporateDS(s.DS),
+	if err = r.orderDefinitions(&s); err != nil {
+		return
+	}
+
+	for _, funk := range []func() error{
+		func() error { return r.incorporateLS(s.LS) },
+		func() error { return r.incorporateMR(s.MR) },
+		func() error { return r.incorporateAT(s.AT) },
+		func() error { return r.incorporateMU(s.MU) },
+		func() error { return r.incorporateOC(s.OC) },
+		func() error { return r.incorporateDC(s.DC) },
+		func() error { return r.incorporateNF(s.NF) },
+		func() error { return r.incorporateDS(s.DS) },
 	} {
-		if err != nil {
+		if err = funk(); err != nil {
 			break
 		}
 	}
@@ -178,6 +201,7 @@ func (r Schema) incorporateLS(s antlr4512.LDAPSyntaxes) (err error) {
 		var def LDAPSyntax
 		if def, err = r.marshalLS(s[i]); err == nil {
 			r.LDAPSyntaxes().push(def)
+			r.added(def, parseSource(s[i].Macro, s[i].OID))
 		}
 	}
 
@@ -185,6 +209,11 @@ func (r Schema) incorporateLS(s antlr4512.LDAPSyntaxes) (err error) {
 }
 
 func (r Schema) marshalLS(s antlr4512.LDAPSyntax) (def LDAPSyntax, err error) {
+	if r.IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
+	}
+
 	// try to resolve a macro only if no
 	// numeric OID is present.
 	if len(s.Macro) == 2 && len(s.OID) == 0 {
@@ -233,6 +262,7 @@ func (r Schema) incorporateMR(s antlr4512.MatchingRules) (err error) {
 		var def MatchingRule
 		if def, err = r.marshalMR(s[i]); err == nil {
 			r.MatchingRules().push(def)
+			r.added(def, parseSource(s[i].Macro, s[i].OID))
 		}
 	}
 
@@ -240,6 +270,11 @@ func (r Schema) incorporateMR(s antlr4512.MatchingRules) (err error) {
 }
 
 func (r Schema) marshalMR(s antlr4512.MatchingRule) (def MatchingRule, err error) {
+	if r.IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
+	}
+
 	// try to resolve a macro only if no
 	// numeric OID is present.
 	if len(s.Macro) == 2 && len(s.OID) == 0 {
@@ -274,7 +309,7 @@ func (r Schema) marshalMR(s antlr4512.MatchingRule) (def MatchingRule, err error
 	syn := r.LDAPSyntaxes().get(s.Syntax)
 	if syn.IsZero() {
 		// throw an error due to bad syntax ref
-		err = mkerr(ErrLDAPSyntaxNotFound.Error() + `(` + s.Syntax + `)`)
+		err = wraperr(ErrLDAPSyntaxNotFound, `(`+s.Syntax+`)`)
 		return
 	}
 
@@ -285,6 +320,7 @@ func (r Schema) marshalMR(s antlr4512.MatchingRule) (def MatchingRule, err error
 	_def.schema = r
 	_def.Syntax = syn
 	_def.Extensions.setDefinition(MatchingRule{_def})
+	_def.Name.setDefinition(MatchingRule{_def})
 
 	// Marshal our extensions, taking our sorting preference into account
 	marshalExt(s.Extensions, _def.Extensions,
@@ -312,6 +348,7 @@ func (r Schema) incorporateMU(s antlr4512.MatchingRuleUses) (err error) {
 		var def MatchingRuleUse
 		if def, err = r.marshalMU(s[i]); err == nil {
 			r.MatchingRuleUses().push(def)
+			r.added(def, parseSource(s[i].Macro, s[i].OID))
 		}
 	}
 
@@ -319,6 +356,11 @@ func (r Schema) incorporateMU(s antlr4512.MatchingRuleUses) (err error) {
 }
 
 func (r Schema) marshalMU(s antlr4512.MatchingRuleUse) (def MatchingRuleUse, err error) {
+	if r.IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
+	}
+
 	if !isNumericOID(s.OID) {
 		err = ErrMissingNumericOID
 		return
@@ -342,6 +384,7 @@ func (r Schema) marshalMU(s antlr4512.MatchingRuleUse) (def MatchingRuleUse, err
 	_def.Obsolete = s.Obsolete
 	_def.schema = r
 	_def.Extensions.setDefinition(MatchingRuleUse{_def})
+	_def.Name.setDefinition(MatchingRuleUse{_def})
 
 	sortL := r.Options().Positive(SortLists)
 
@@ -381,6 +424,7 @@ func (r Schema) incorporateAT(s antlr4512.AttributeTypes) (err error) {
 		var def AttributeType
 		if def, err = r.marshalAT(s[i]); err == nil {
 			r.AttributeTypes().push(def)
+			r.added(def, parseSource(s[i].Macro, s[i].OID))
 		}
 	}
 
@@ -388,6 +432,11 @@ func (r Schema) incorporateAT(s antlr4512.AttributeTypes) (err error) {
 }
 
 func (r Schema) marshalAT(s antlr4512.AttributeType) (def AttributeType, err error) {
+	if r.IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
+	}
+
 	// try to resolve a macro only if no numeric OID is present.
 	if s.OID = handleMacro(r, s.Macro, s.OID); !isNumericOID(s.OID) {
 		err = ErrMissingNumericOID
@@ -411,6 +460,7 @@ func (r Schema) marshalAT(s antlr4512.AttributeType) (def AttributeType, err err
 	_def.MUB = s.MUB
 	_def.schema = r
 	_def.Extensions.setDefinition(AttributeType{_def})
+	_def.Name.setDefinition(AttributeType{_def})
 
 	// avoid bogus Boolean states
 	if _def.Single && _def.Collective {
@@ -423,7 +473,7 @@ func (r Schema) marshalAT(s antlr4512.AttributeType) (def AttributeType, err err
 		llup := r.LDAPSyntaxes().get(syn)
 		if llup.IsZero() {
 			// throw an error due to bad syntax ref
-			err = mkerr(ErrLDAPSyntaxNotFound.Error() + `(` + syn + `)`)
+			err = wraperr(ErrLDAPSyntaxNotFound, `(`+syn+`)`)
 			return
 		}
 		_def.Syntax = llup
@@ -439,7 +489,7 @@ func (r Schema) marshalAT(s antlr4512.AttributeType) (def AttributeType, err err
 	if _sup := s.SuperType; len(_sup) > 0 {
 		sup := r.AttributeTypes().get(_sup)
 		if sup.IsZero() {
-			err = mkerr(ErrAttributeTypeNotFound.Error() + `( supertype: ` + _sup + `)`)
+			err = wraperr(ErrAttributeTypeNotFound, `( supertype: `+_sup+`)`)
 			return
 		}
 		_def.SuperType = sup
@@ -484,7 +534,7 @@ func (r *attributeType) marshalMRs(s antlr4512.AttributeType) (err error) {
 			// otherwise.
 			llup := r.schema.MatchingRules().get(mrl)
 			if llup.IsZero() {
-				err = mkerr(ErrMatchingRuleNotFound.Error() + `(` + mrl + `)`)
+				err = wraperr(ErrMatchingRuleNotFound, `(`+mrl+`)`)
 				break
 			}
 
@@ -505,7 +555,14 @@ func (r *attributeType) marshalMRs(s antlr4512.AttributeType) (err error) {
 func (r *attributeType) marshalUsage(s antlr4512.AttributeType) {
 	// verify and set usage IF NOT default ("userApplications")
 	if len(s.Usage) > 0 {
-		switch lc(s.Usage) {
+		// Folded definitions may leave leading whitespace,
+		// and thus the USAGE keyword, in the parsed value.
+		usage := lc(trimS(s.Usage))
+		if hasPfx(usage, `usage`) {
+			usage = trimS(usage[5:])
+		}
+
+		switch usage {
 		case `directoryoperation`:
 			r.Usage = DirectoryOperationUsage
 		case `distributedoperation`:
@@ -521,6 +578,7 @@ func (r Schema) incorporateOC(s antlr4512.ObjectClasses) (err error) {
 		var def ObjectClass
 		if def, err = r.marshalOC(s[i]); err == nil {
 			r.ObjectClasses().push(def)
+			r.added(def, parseSource(s[i].Macro, s[i].OID))
 		}
 	}
 
@@ -528,6 +586,11 @@ func (r Schema) incorporateOC(s antlr4512.ObjectClasses) (err error) {
 }
 
 func (r Schema) marshalOC(s antlr4512.ObjectClass) (def ObjectClass, err error) {
+	if r.IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
+	}
+
 	// try to resolve a macro only if no numeric OID is present.
 	if s.OID = handleMacro(r, s.Macro, s.OID); !isNumericOID(s.OID) {
 		err = ErrMissingNumericOID
@@ -546,6 +609,7 @@ func (r Schema) marshalOC(s antlr4512.ObjectClass) (def ObjectClass, err error)
 	_def.Obsolete = s.Obsolete
 	_def.schema = r
 	_def.Extensions.setDefinition(ObjectClass{_def})
+	_def.Name.setDefinition(ObjectClass{_def})
 
 	sortL := r.Options().Positive(SortLists)
 
@@ -616,6 +680,7 @@ func (r Schema) incorporateDC(s antlr4512.DITContentRules) (err error) {
 		var def DITContentRule
 		if def, err = r.marshalDC(s[i]); err == nil {
 			r.DITContentRules().push(def)
+			r.added(def, parseSource(s[i].Macro, s[i].OID))
 		}
 	}
 
@@ -624,7 +689,7 @@ func (r Schema) incorporateDC(s antlr4512.DITContentRules) (err error) {
 
 func (r Schema) checkDCOID(s antlr4512.DITContentRule) (err error) {
 	if soc := r.ObjectClasses().Get(s.OID); soc.IsZero() {
-		err = mkerr(ErrObjectClassNotFound.Error() + `( superclass: ` + s.OID + `)`)
+		err = wraperr(ErrObjectClassNotFound, `( superclass: `+s.OID+`)`)
 	} else if lup := r.DITContentRules().get(s.OID); !lup.IsZero() {
 		// fail attempts to marshal a duplicate definition.
 		err = ErrDuplicateDef
@@ -634,6 +699,11 @@ func (r Schema) checkDCOID(s antlr4512.DITContentRule) (err error) {
 }
 
 func (r Schema) marshalDC(s antlr4512.DITContentRule) (def DITContentRule, err error) {
+	if r.IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
+	}
+
 	if !isNumericOID(s.OID) {
 		err = ErrMissingNumericOID
 		return
@@ -651,6 +721,7 @@ func (r Schema) marshalDC(s antlr4512.DITContentRule) (def DITContentRule, err e
 	_def.Obsolete = s.Obsolete
 	_def.schema = r
 	_def.Extensions.setDefinition(DITContentRule{_def})
+	_def.Name.setDefinition(DITContentRule{_def})
 
 	sortL := r.Options().Positive(SortLists)
 
@@ -707,7 +778,7 @@ func (r Schema) marshalDC(s antlr4512.DITContentRule) (def DITContentRule, err e
 		}
 
 		def = DITContentRule{_def}
-		if !def.Compliant() {
+		if !def.compliant() {
 			err = ErrDefNonCompliant
 		}
 	}
@@ -720,6 +791,7 @@ func (r Schema) incorporateNF(s antlr4512.NameForms) (err error) {
 		var def NameForm
 		if def, err = r.marshalNF(s[i]); err == nil {
 			r.NameForms().push(def)
+			r.added(def, parseSource(s[i].Macro, s[i].OID))
 		}
 	}
 
@@ -727,6 +799,11 @@ func (r Schema) incorporateNF(s antlr4512.NameForms) (err error) {
 }
 
 func (r Schema) marshalNF(s antlr4512.NameForm) (def NameForm, err error) {
+	if r.IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
+	}
+
 	if !isNumericOID(s.OID) {
 		err = ErrMissingNumericOID
 		return
@@ -744,12 +821,13 @@ func (r Schema) marshalNF(s antlr4512.NameForm) (def NameForm, err error) {
 	_def.Obsolete = s.Obsolete
 	_def.schema = r
 	_def.Extensions.setDefinition(NameForm{_def})
+	_def.Name.setDefinition(NameForm{_def})
 
 	sortL := r.Options().Positive(SortLists)
 
 	oc := r.ObjectClasses().get(s.OC)
 	if oc.IsZero() {
-		err = mkerr(ErrObjectClassNotFound.Error() + `( structural: ` + s.OC + `)`)
+		err = wraperr(ErrObjectClassNotFound, `( structural: `+s.OC+`)`)
 		return
 	}
 	_def.Structural = oc
@@ -798,6 +876,7 @@ func (r Schema) incorporateDS(s antlr4512.DITStructureRules) (err error) {
 		var def DITStructureRule
 		if def, err = r.marshalDS(s[i]); err == nil {
 			r.DITStructureRules().push(def)
+			r.added(def, ParseSource)
 		}
 	}
 
@@ -805,6 +884,11 @@ func (r Schema) incorporateDS(s antlr4512.DITStructureRules) (err error) {
 }
 
 func (r Schema) marshalDS(s antlr4512.DITStructureRule) (def DITStructureRule, err error) {
+	if r.IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
+	}
+
 	var ruleid uint
 	var ok bool
 	if ruleid, ok = atoui(s.ID); !ok {
@@ -824,12 +908,13 @@ func (r Schema) marshalDS(s antlr4512.DITStructureRule) (def DITStructureRule, e
 	_def.Obsolete = s.Obsolete
 	_def.schema = r
 	_def.Extensions.setDefinition(DITStructureRule{_def})
+	_def.Name.setDefinition(DITStructureRule{_def})
 
 	sortL := r.Options().Positive(SortLists)
 
 	nf := r.NameForms().get(s.Form)
 	if nf.IsZero() {
-		err = mkerr(ErrNameFormNotFound.Error() + `(` + s.Form + `)`)
+		err = wraperr(ErrNameFormNotFound, `(`+s.Form+`)`)
 		return
 	}
 	_def.Form = nf
@@ -915,3 +1000,382 @@ func handleMacro(r Schema, m []string, o string) (resv string) {
 
 	return
 }
+
+/*
+rawDefinition contains a single definition -- or an objectidentifier
+directive -- extracted from raw schema content, alongside the name of
+the file and the number of the line on which it begins.
+*/
+type rawDefinition struct {
+	Type string
+	Text string
+	File string
+	Line int
+}
+
+/*
+definitionTypes contains all definition types, in the form returned by
+the respective Type methods.
+*/
+var definitionTypes []string = []string{
+	`ldapSyntax`,
+	`matchingRule`,
+	`attributeType`,
+	`matchingRuleUse`,
+	`objectClass`,
+	`dITContentRule`,
+	`nameForm`,
+	`dITStructureRule`,
+}
+
+/*
+labelType returns the definition type associated with label, which may be
+singular or plural in form.  Case is not significant.  A zero string is
+returned if the label is unrecognized.
+*/
+func labelType(label string) (typ string) {
+	label = lc(label)
+	for _, t := range definitionTypes {
+		if lt := lc(t); label == lt || label == lt+`s` || label == lt+`es` {
+			typ = t
+			break
+		}
+	}
+
+	return
+}
+
+/*
+parseTolerant parses raw in a manner which does not cease upon the first
+error.  Instead, each definition is parsed on an individual basis, and any
+failures are returned as [ParseError] instances.  The file input value is
+only used for the purpose of error reporting.
+*/
+func (r Schema) parseTolerant(raw []byte, file string) (errs []error) {
+	defs, errs := scanDefinitions(string(raw), file)
+	errs = r.incorporateRaw(defs, errs, true)
+
+	return
+}
+
+/*
+incorporateRaw parses each of the previously scanned definitions within
+defs on an individual basis, returning all failures as [ParseError]
+instances ordered by file and line.  Any previously-encountered failures,
+such as those produced by scanDefinitions, may be provided via errs.
+
+All objectidentifier directives are processed first, in order of appearance.
+All definitions are then parsed in order of type precedence and, within each
+type, in order of dependency.  See [Schema.incorporate] for details.
+
+Unless tolerant is true, processing ceases upon the first failure, and
+only that failure is returned; no definitions are written thereafter.
+
+As with [Schema.incorporate], [MatchingRuleUse] instances are not
+regenerated.  See [Schema.UpdateMatchingRuleUses] for details.
+*/
+func (r Schema) incorporateRaw(defs []rawDefinition, errs []error, tolerant bool) []error {
+	if len(errs) > 0 && !tolerant {
+		return errs[:1]
+	}
+
+	funks := map[string]func(string) error{
+		`ldapSyntax`:       r.ParseLDAPSyntax,
+		`matchingRule`:     r.ParseMatchingRule,
+		`attributeType`:    r.ParseAttributeType,
+		`matchingRuleUse`:  r.ParseMatchingRuleUse,
+		`objectClass`:      r.ParseObjectClass,
+		`dITContentRule`:   r.ParseDITContentRule,
+		`nameForm`:         r.ParseNameForm,
+		`dITStructureRule`: r.ParseDITStructureRule,
+		`objectIdentifier`: r.parseObjectIdentifier,
+	}
+
+	for _, typ := range append([]string{`objectIdentifier`}, definitionTypes...) {
+		var group []rawDefinition
+		for _, def := range defs {
+			if def.Type == typ {
+				group = append(group, def)
+			}
+		}
+
+		ordered, failed := r.orderRawDefinitions(typ, group)
+		for idx, err := range failed {
+			errs = append(errs, group[idx].parseError(err))
+		}
+
+		for i := 0; i < len(ordered) && (tolerant || len(errs) == 0); i++ {
+			if err := funks[ordered[i].Type](ordered[i].Text); err != nil {
+				errs = append(errs, ordered[i].parseError(err))
+			}
+		}
+
+		if len(errs) > 0 && !tolerant {
+			sortParseErrors(errs)
+			return errs[:1]
+		}
+	}
+
+	// present failures in order of appearance
+	sortParseErrors(errs)
+
+	return errs
+}
+
+/*
+parseError returns a [ParseError] describing err with respect to the
+receiver instance.
+*/
+func (r rawDefinition) parseError(err error) ParseError {
+	var id, name string
+	if r.Type != `objectIdentifier` {
+		id, name = rawIdentity(r.Text)
+	}
+
+	return ParseError{
+		File: r.File,
+		Line: r.Line,
+		Type: r.Type,
+		ID:   id,
+		Name: name,
+		Err:  err,
+	}
+}
+
+/*
+parseFileTolerant reads and parses file, which must bear the ".schema"
+suffix, in the manner described by the parseTolerant method.
+*/
+func (r Schema) parseFileTolerant(file string) (err error) {
+	if !hasSfx(file, `.schema`) {
+		err = mkerr("Filename '" + file + "' does not end in '.schema'; will not parse")
+		return
+	}
+
+	var raw []byte
+	if raw, err = os.ReadFile(file); err == nil {
+		err = joinErr(r.parseTolerant(raw, file)...)
+	}
+
+	return
+}
+
+/*
+parseDirectoryTolerant walks dir in lexical order, scanning each file
+bearing the ".schema" suffix.  Other files are silently ignored.  Once
+all files have been scanned, the collective definitions are parsed in
+the manner described by the incorporateRaw method.
+*/
+func (r Schema) parseDirectoryTolerant(dir string) (err error) {
+	var defs []rawDefinition
+	var errs []error
+	err = filepath.WalkDir(trimR(dir, `/`), func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && hasSfx(p, `.schema`) {
+			var raw []byte
+			if raw, err = os.ReadFile(p); err == nil {
+				_defs, _errs := scanDefinitions(string(raw), p)
+				defs = append(defs, _defs...)
+				errs = append(errs, _errs...)
+			}
+		}
+
+		return err
+	})
+
+	if err == nil {
+		err = joinErr(r.incorporateRaw(defs, errs, true)...)
+	}
+
+	return
+}
+
+/*
+parseObjectIdentifier registers the "<name> <oid or macro>" directive
+held by raw as a [Macros] entry within the receiver instance.
+*/
+func (r Schema) parseObjectIdentifier(raw string) (err error) {
+	fields := split(condenseWHSP(raw), ` `)
+	if len(fields) != 2 {
+		err = ErrUnrecognizedContent
+		return
+	}
+
+	name, oid := fields[0], fields[1]
+	if !isNumericOID(oid) {
+		mc := split(oid, `:`)
+		resl, found := r.Macros().Resolve(mc[0])
+		if len(mc) != 2 || !found {
+			err = mkerr("Could not resolve macro '" + oid + `'`)
+			return
+		}
+		oid = resl + `.` + mc[1]
+	}
+
+	r.Macros().Set(name, oid)
+
+	return
+}
+
+/*
+rawIdentity returns the leading identifier (numeric OID, macro or rule ID)
+and the principal NAME value found within raw, if present.
+*/
+func rawIdentity(raw string) (id, name string) {
+	fields := split(condenseWHSP(trimL(trimS(raw), `(`)), ` `)
+	if len(fields) > 0 {
+		id = fields[0]
+	}
+
+	for i := 1; i < len(fields)-1; i++ {
+		if fields[i] == `NAME` {
+			name = fields[i+1]
+			if name == `(` && i+2 < len(fields) {
+				name = fields[i+2]
+			}
+			name = trim(trimL(name, `(`), `'`)
+			break
+		}
+	}
+
+	return
+}
+
+/*
+scanDefinitions splits raw into individual definitions and directives,
+discarding comments and any schema DN line in the process.
+
+Unrecognized content, as well as definitions bearing unbalanced parentheses,
+are returned as [ParseError] instances.
+*/
+func scanDefinitions(raw, file string) (defs []rawDefinition, errs []error) {
+	var line int = 1
+	for i := 0; i < len(raw); {
+		switch c := raw[i]; c {
+		case '\n':
+			line++
+			i++
+		case ' ', '\t', '\r':
+			i++
+		case '#':
+			i = skipLine(raw, i)
+		default:
+			start := i
+			for i < len(raw) && isLabelChar(raw[i]) {
+				i++
+			}
+			label := raw[start:i]
+
+			switch lc(label) {
+			case `dn`:
+				i = skipLine(raw, i)
+				continue
+			case `objectidentifier`:
+				end := skipLine(raw, i)
+				defs = append(defs, rawDefinition{
+					Type: `objectIdentifier`,
+					Text: trimS(stripComment(raw[i:end])),
+					File: file,
+					Line: line,
+				})
+				i = end
+				continue
+			}
+
+			typ := labelType(label)
+			if typ == `` {
+				errs = append(errs, ParseError{File: file, Line: line,
+					Err: ErrUnrecognizedContent})
+				i = skipLine(raw, i+1)
+				continue
+			}
+
+			begin := line
+			var text string
+			var ok bool
+			if i, line, text, ok = scanDescription(raw, i, line); !ok {
+				errs = append(errs, ParseError{File: file, Line: begin,
+					Type: typ, Err: ErrIncompleteDefinition})
+				continue
+			}
+
+			defs = append(defs, rawDefinition{Type: typ, Text: text,
+				File: file, Line: begin})
+		}
+	}
+
+	return
+}
+
+/*
+scanDescription scans the parenthetical description which follows a
+definition label within raw, beginning at index i on the given line.
+Comments are removed from the resulting text.
+
+The index and line number following the description are returned,
+alongside the description text and a Boolean value indicative of a
+complete (balanced) description.
+*/
+func scanDescription(raw string, i, line int) (next, nline int, text string, ok bool) {
+	// skip the label delimiter (e.g.: ':', '=' or WHSP)
+	for i < len(raw) && strInSlice(string(raw[i]), []string{` `, "\t", "\r", "\n", `:`, `=`}) {
+		if raw[i] == '\n' {
+			line++
+		}
+		i++
+	}
+
+	if i >= len(raw) || raw[i] != '(' {
+		next, nline = skipLine(raw, i), line
+		return
+	}
+
+	bld := newStringBuilder()
+	var depth int
+	var quoted bool
+
+	for ; i < len(raw) && !ok; i++ {
+		c := raw[i]
+		switch {
+		case c == '\n':
+			line++
+		case c == '\'':
+			quoted = !quoted
+		case c == '#' && !quoted:
+			i = skipLine(raw, i) - 1
+			continue
+		case c == '(' && !quoted:
+			depth++
+		case c == ')' && !quoted:
+			depth--
+			ok = depth == 0
+		}
+		bld.WriteByte(c)
+	}
+
+	next, nline, text = i, line, bld.String()
+
+	return
+}
+
+/*
+skipLine returns the index of the next newline character found within
+raw at or beyond index i, or the length of raw if none is found.
+*/
+func skipLine(raw string, i int) int {
+	for i < len(raw) && raw[i] != '\n' {
+		i++
+	}
+
+	return i
+}
+
+/*
+stripComment removes any trailing bash-style comment from s.
+*/
+func stripComment(s string) string {
+	return split(s, `#`)[0]
+}
+
+func isLabelChar(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,383 @@
+package schemax
+
+/*
+registry.go contains the SchemaRegistry type, which publishes successive,
+immutable versions of a Schema by way of copy-on-write.
+*/
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/*
+maxSharedVersions is the number of prior versions with which a draft may
+share definitions before it is compacted into a complete copy.  This bounds
+the number of superseded versions kept alive by shared definitions.
+*/
+const maxSharedVersions = 8
+
+/*
+SchemaVersion describes a single published version of a [Schema] held by a
+[SchemaRegistry].
+*/
+type SchemaVersion struct {
+	// Schema is the read-only Schema which embodies this version.
+	Schema Schema
+
+	// Version is a monotonically increasing version number,
+	// beginning at one (1).
+	Version uint64
+
+	// Timestamp is the time at which this version was published.
+	Timestamp time.Time
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state.
+*/
+func (r SchemaVersion) IsZero() bool {
+	return r.Schema.IsZero()
+}
+
+/*
+SchemaRegistry holds the current version of a [Schema] behind an atomic
+pointer.  Instances of this type are produced by way of the
+[Schema.NewSchemaRegistry] method.
+
+Readers obtain the current version by way of [SchemaRegistry.Current] or
+[SchemaRegistry.Schema], which never block and never observe a partially
+applied change.  Each version is read-only, in the manner of a [Schema]
+produced by [Schema.Snapshot].
+
+Writers submit changes by way of [SchemaRegistry.Update], which applies
+them to a writable draft of the current version.  The draft shares all
+unchanged definitions with the current version, thus the cost of a change
+is not proportional to the size of the [Schema].  Upon success, the draft
+is made read-only and published as the next version in a single atomic
+step.  Concurrent writers are serialized.
+
+Definitions shared with a prior version are read-only: their setters leave
+them unchanged and record [ErrReadOnlySchema] instead, such that the E
+method of the definition reports it, and pushes into their clauses (e.g.:
+[ObjectClass.May]) fail with [ErrReadOnlySchema].  Thus a failed update
+never influences the content of a prior version.  Within an update, such definitions are changed by way of
+[Schema.Replace], which substitutes a private copy of the definition --
+and of each definition which depends upon it, directly or indirectly --
+before applying the replacement.  [MatchingRuleUse] instances, which are
+maintained in place by the [Schema.UpdateMatchingRuleUses] method, are
+never shared.
+*/
+type SchemaRegistry struct {
+	*schemaRegistry
+}
+
+type schemaRegistry struct {
+	mutex   sync.Mutex // serializes writers
+	current atomic.Pointer[SchemaVersion]
+}
+
+/*
+NewSchemaRegistry returns a new instance of [SchemaRegistry], bearing a
+read-only snapshot of the receiver instance as version one (1).
+
+Subsequent modification of the receiver does not influence the return
+instance in any way.
+*/
+func (r Schema) NewSchemaRegistry() SchemaRegistry {
+	if r.IsZero() {
+		r = NewEmptySchema()
+	}
+
+	reg := SchemaRegistry{&schemaRegistry{}}
+	reg.publish(r.Snapshot(), 1)
+
+	return reg
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state.
+*/
+func (r SchemaRegistry) IsZero() bool {
+	return r.schemaRegistry == nil
+}
+
+/*
+Current returns the current [SchemaVersion] of the receiver instance.
+*/
+func (r SchemaRegistry) Current() (v SchemaVersion) {
+	if !r.IsZero() {
+		if cur := r.schemaRegistry.current.Load(); cur != nil {
+			v = *cur
+		}
+	}
+
+	return
+}
+
+/*
+Schema returns the read-only [Schema] of the current [SchemaVersion] of
+the receiver instance.
+*/
+func (r SchemaRegistry) Schema() Schema {
+	return r.Current().Schema
+}
+
+/*
+Version returns the version number of the current [SchemaVersion] of the
+receiver instance.
+*/
+func (r SchemaRegistry) Version() uint64 {
+	return r.Current().Version
+}
+
+/*
+Update executes fn upon a writable draft of the current version of the
+receiver instance.  If fn returns a nil error, the draft is published as
+the next version, which is returned.  Otherwise the draft is discarded,
+and the current version is returned alongside the error.
+
+For example, a directory of additional schema files may be loaded as
+follows, without readers ever observing a partially loaded [Schema]:
+
+	v, err := reg.Update(func(draft Schema) error {
+		return draft.ParseDirectory(dir)
+	})
+
+The draft shall not be retained by fn, as it is read-only once published.
+To reload a complete set of schema files, parse them into a new [Schema]
+and submit it to [SchemaRegistry.Publish].
+*/
+func (r SchemaRegistry) Update(fn func(Schema) error) (v SchemaVersion, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	} else if fn == nil {
+		err = ErrNilInput
+		return
+	}
+
+	r.schemaRegistry.mutex.Lock()
+	defer r.schemaRegistry.mutex.Unlock()
+
+	cur := r.Current()
+	draft := cur.Schema.fork()
+	if err = fn(draft); err != nil {
+		v = cur
+		return
+	}
+
+	if draft.sharedVersions() > maxSharedVersions {
+		draft = draft.Clone()
+	}
+	draft.freeze()
+	v = r.publish(draft, cur.Version+1)
+
+	return
+}
+
+/*
+Publish publishes a read-only snapshot of sch as the next version of the
+receiver instance, which is returned.  This is useful when an entirely new
+[Schema] has been assembled independently of the receiver.
+
+Subsequent modification of sch does not influence the return instance in
+any way.
+*/
+func (r SchemaRegistry) Publish(sch Schema) (v SchemaVersion, err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	} else if sch.IsZero() {
+		err = ErrNilInput
+		return
+	}
+
+	r.schemaRegistry.mutex.Lock()
+	defer r.schemaRegistry.mutex.Unlock()
+
+	v = r.publish(sch.Snapshot(), r.Version()+1)
+
+	return
+}
+
+func (r SchemaRegistry) publish(sch Schema, version uint64) SchemaVersion {
+	v := &SchemaVersion{
+		Schema:    sch,
+		Version:   version,
+		Timestamp: time.Now(),
+	}
+	r.schemaRegistry.current.Store(v)
+
+	return *v
+}
+
+/*
+fork returns a writable draft of the receiver, which must be read-only.
+All definitions except [MatchingRuleUse] instances are shared with the
+receiver.
+*/
+func (r Schema) fork() (c Schema) {
+	runlock := r.rlock()
+	defer runlock()
+
+	c = initSchema()
+	r.copySettings(c)
+
+	for _, pair := range [][2]Definitions{
+		{r.LDAPSyntaxes(), c.LDAPSyntaxes()},
+		{r.MatchingRules(), c.MatchingRules()},
+		{r.AttributeTypes(), c.AttributeTypes()},
+		{r.MatchingRuleUses(), c.MatchingRuleUses()},
+		{r.ObjectClasses(), c.ObjectClasses()},
+		{r.DITContentRules(), c.DITContentRules()},
+		{r.NameForms(), c.NameForms()},
+		{r.DITStructureRules(), c.DITStructureRules()},
+	} {
+		for _, def := range collectionDefinitions(pair[0]) {
+			if mu, ok := def.(MatchingRuleUse); ok {
+				// MatchingRuleUse instances are updated
+				// in place, thus they are never shared.
+				def = mu.Clone()
+			}
+			pair[1].cast().Push(def)
+		}
+	}
+
+	for _, def := range collectionDefinitions(c.MatchingRuleUses()) {
+		rewireDefinition(def, c)
+	}
+
+	return
+}
+
+/*
+shares returns a Boolean value indicative of whether def is shared with a
+prior version, and must therefore be copied before it may be modified.
+*/
+func (r Schema) shares(def Definition) bool {
+	return !r.IsReadOnly() && def.Schema().IsReadOnly()
+}
+
+/*
+sharedVersions returns the number of distinct read-only Schema instances
+with which the receiver shares definitions.
+*/
+func (r Schema) sharedVersions() int {
+	owners := make(map[Schema]struct{})
+	for _, def := range r.collectionMembers() {
+		if r.shares(def) {
+			owners[def.Schema()] = struct{}{}
+		}
+	}
+
+	return len(owners)
+}
+
+/*
+unshare substitutes the member of the receiver bearing the identity of def
+with a private copy, if the member is shared with a prior version.  Each
+dependent of the member is likewise unshared, or rewired to reference the
+new copy.
+*/
+func (r Schema) unshare(def Definition) {
+	orig := r.member(def)
+	if orig == nil || !r.shares(orig) {
+		return
+	}
+
+	c := cloneDefinition(orig)
+	unlock, _ := r.lock()
+	swapDefinition(r.collectionOf(orig), orig, c)
+	rewireDefinition(c, r)
+	unlock()
+
+	for _, dep := range r.Dependents(c) {
+		if r.shares(dep) {
+			r.unshare(dep)
+		} else {
+			unlock, _ = r.lock()
+			rewireDefinition(dep, r)
+			unlock()
+		}
+	}
+}
+
+/*
+member returns the member of the receiver bearing the same type and
+identity as def, or nil if not found.
+*/
+func (r Schema) member(def Definition) (m Definition) {
+	runlock := r.rlock()
+	defer runlock()
+
+	return r.lookupMember(def)
+}
+
+/*
+lookupMember is the unlocked counterpart of member, for use where the lock
+is already held.
+*/
+func (r Schema) lookupMember(def Definition) (m Definition) {
+	switch tv := def.(type) {
+	case LDAPSyntax:
+		if x := r.LDAPSyntaxes().lookup(tv.NumericOID()); !x.IsZero() {
+			m = x
+		}
+	case MatchingRule:
+		if x := r.MatchingRules().lookup(tv.NumericOID()); !x.IsZero() {
+			m = x
+		}
+	case AttributeType:
+		if x := r.AttributeTypes().lookup(tv.NumericOID()); !x.IsZero() {
+			m = x
+		}
+	case MatchingRuleUse:
+		if x := r.MatchingRuleUses().lookup(tv.NumericOID()); !x.IsZero() {
+			m = x
+		}
+	case ObjectClass:
+		if x := r.ObjectClasses().lookup(tv.NumericOID()); !x.IsZero() {
+			m = x
+		}
+	case DITContentRule:
+		if x := r.DITContentRules().lookup(tv.NumericOID()); !x.IsZero() {
+			m = x
+		}
+	case NameForm:
+		if x := r.NameForms().lookup(tv.NumericOID()); !x.IsZero() {
+			m = x
+		}
+	case DITStructureRule:
+		if x := r.DITStructureRules().lookup(tv.RuleID()); !x.IsZero() {
+			m = x
+		}
+	}
+
+	return
+}
+
+/*
+swapDefinition replaces the first member of defs which bears the same
+identity as def with c, returning a Boolean value indicative of success.
+The caller is expected to hold the write lock.
+*/
+func swapDefinition(defs Definitions, def, c Definition) (ok bool) {
+	stk := defs.cast()
+	id := defIdentity(def)
+	for i := 0; i < stk.Len() && !ok; i++ {
+		slice, _ := stk.Index(i)
+		if d, is := slice.(Definition); is && d.Type() == def.Type() {
+			if defIdentity(d) == id {
+				if ok = stk.Replace(c, i); ok {
+					if idx := collectionIndexOf(stk); idx != nil {
+						idx.reset()
+					}
+				}
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,303 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+/*
+This example demonstrates the publication of a new [SchemaVersion] by way
+of the [SchemaRegistry.Update] method.  The prior version remains intact.
+*/
+func ExampleSchemaRegistry_Update() {
+	reg := NewSchema().NewSchemaRegistry()
+	prev := reg.Current()
+
+	v, err := reg.Update(func(draft Schema) error {
+		return draft.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.95.1
+			NAME 'registryAttr'
+			SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(v.Version, v.Schema.AttributeTypes().Contains(`registryAttr`))
+	fmt.Println(prev.Version, prev.Schema.AttributeTypes().Contains(`registryAttr`))
+	// Output:
+	// 2 true
+	// 1 false
+}
+
+func TestSchemaRegistry_Update(t *testing.T) {
+	reg := NewSchema(AllowOverride).NewSchemaRegistry()
+	v1 := reg.Current()
+	if v1.Version != 1 || !v1.Schema.IsReadOnly() {
+		t.Fatalf("%s failed: unexpected initial version %d", t.Name(), v1.Version)
+	}
+
+	// Push: unchanged definitions are shared with the prior version.
+	v2, err := reg.Update(func(draft Schema) error {
+		return draft.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.95.2
+			NAME 'sharedTest'
+			SUP name )`)
+	})
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if v2.Version != 2 || v2.Timestamp.Before(v1.Timestamp) {
+		t.Fatalf("%s failed: unexpected version %d (%s)", t.Name(), v2.Version, v2.Timestamp)
+	} else if reg.Version() != 2 || !reg.Schema().IsReadOnly() {
+		t.Fatalf("%s failed: version 2 not published", t.Name())
+	} else if v1.Schema.AttributeTypes().Contains(`sharedTest`) {
+		t.Errorf("%s failed: prior version modified", t.Name())
+	}
+
+	cn1, cn2 := v1.Schema.AttributeTypes().Get(`cn`), v2.Schema.AttributeTypes().Get(`cn`)
+	if cn1.attributeType != cn2.attributeType {
+		t.Errorf("%s failed: unchanged definition not shared", t.Name())
+	} else if at := v2.Schema.AttributeTypes().Get(`sharedTest`); at.Schema() != v2.Schema {
+		t.Errorf("%s failed: new definition not associated with its version", t.Name())
+	} else if at.SuperType().attributeType != v2.Schema.AttributeTypes().Get(`name`).attributeType {
+		t.Errorf("%s failed: new definition does not reference shared SUP", t.Name())
+	}
+
+	// Replace: the replaced definition, and all which depend upon
+	// it, are copied; the prior version remains untouched.
+	v3, err := reg.Update(func(draft Schema) error {
+		rep := draft.AttributeTypes().Get(`name`).Clone()
+		rep.attributeType.Desc = `replaced`
+		rep.attributeType.schema = draft
+		draft.Replace(rep.SetStringer())
+		return rep.attributeType.err
+	})
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	name2, name3 := v2.Schema.AttributeTypes().Get(`name`), v3.Schema.AttributeTypes().Get(`name`)
+	cn3 := v3.Schema.AttributeTypes().Get(`cn`)
+	if name2.Description() == `replaced` || name3.Description() != `replaced` {
+		t.Errorf("%s failed: replacement not isolated to version 3", t.Name())
+	} else if cn3.attributeType == cn2.attributeType {
+		t.Errorf("%s failed: dependent definition still shared", t.Name())
+	} else if cn3.SuperType().attributeType != name3.attributeType {
+		t.Errorf("%s failed: dependent definition not rewired", t.Name())
+	} else if cn2.SuperType().Description() == `replaced` {
+		t.Errorf("%s failed: prior dependent definition influenced", t.Name())
+	} else if person := v3.Schema.ObjectClasses().Get(`person`); !person.Must().Contains(`cn`) ||
+		person.Must().Get(`cn`).attributeType != cn3.attributeType {
+		t.Errorf("%s failed: indirect dependent definition not rewired", t.Name())
+	} else if v3.Schema.ObjectClasses().Get(`top`).objectClass != v2.Schema.ObjectClasses().Get(`top`).objectClass {
+		t.Errorf("%s failed: unrelated definition not shared", t.Name())
+	}
+
+	// Remove: the MatchingRuleUse instances of the draft are
+	// private, and thus may be updated in place.
+	if _, err = reg.Update(func(draft Schema) error {
+		if err := draft.UpdateMatchingRuleUses(); err != nil {
+			return err
+		}
+		return draft.Remove(draft.AttributeTypes().Get(`sharedTest`))
+	}); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if reg.Schema().AttributeTypes().Contains(`sharedTest`) {
+		t.Errorf("%s failed: removed definition still present", t.Name())
+	} else if !v3.Schema.AttributeTypes().Contains(`sharedTest`) {
+		t.Errorf("%s failed: removal visible within prior version", t.Name())
+	}
+
+	// Failure: nothing is published.
+	want := errors.New(`abort`)
+	v, err := reg.Update(func(draft Schema) error {
+		_ = draft.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.95.3 NAME 'abortedAttr' SUP name )`)
+		return want
+	})
+	if !errors.Is(err, want) || v.Version != 4 || reg.Version() != 4 {
+		t.Errorf("%s failed: unexpected result %d, %v", t.Name(), v.Version, err)
+	} else if reg.Schema().AttributeTypes().Contains(`abortedAttr`) {
+		t.Errorf("%s failed: aborted change was published", t.Name())
+	}
+}
+
+/*
+Definitions shared with a prior version cannot be modified by way of the
+draft, thus a failed update leaves the prior version byte-identical.
+*/
+func TestSchemaRegistry_Update_sharedImmutable(t *testing.T) {
+	reg := NewSchema().NewSchemaRegistry()
+	v1 := reg.Current()
+	want, err := v1.Schema.MarshalJSON()
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	var errs []error
+	_, err = reg.Update(func(d Schema) error {
+		cn := d.AttributeTypes().Get(`cn`).
+			SetDescription(`MUTATED`).
+			SetName(`mutatedName`).
+			SetExtension(`X-MUTATED`, `TRUE`).
+			SetStringer()
+		person := d.ObjectClasses().Get(`person`)
+		person.SetObsolete()
+		errs = append(errs, cn.E(), person.E(),
+			person.May().Push(d.AttributeTypes().Get(`uid`)),
+			person.Must().Push(d.AttributeTypes().Get(`uid`)),
+			person.Names().Push(`mutatedPerson`))
+		return errors.New(`abort`)
+	})
+	if err == nil || reg.Version() != 1 {
+		t.Fatalf("%s failed: aborted update was published", t.Name())
+	}
+
+	for idx, err := range errs {
+		if !errors.Is(err, ErrReadOnlySchema) {
+			t.Errorf("%s[%d] failed: want %v, got %v", t.Name(), idx, ErrReadOnlySchema, err)
+		}
+	}
+
+	if got, _ := v1.Schema.MarshalJSON(); string(got) != string(want) {
+		t.Errorf("%s failed: prior version modified", t.Name())
+	} else if cn := v1.Schema.AttributeTypes().Get(`cn`); cn.Description() == `MUTATED` {
+		t.Errorf("%s failed: shared attributeType modified", t.Name())
+	} else if v1.Schema.ObjectClasses().Get(`person`).May().Contains(`uid`) {
+		t.Errorf("%s failed: shared objectClass modified", t.Name())
+	}
+}
+
+func TestSchemaRegistry_compaction(t *testing.T) {
+	reg := NewSchema().NewSchemaRegistry()
+	for i := 0; i < maxSharedVersions*2; i++ {
+		if _, err := reg.Update(func(draft Schema) error {
+			return draft.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.96.` + itoa(i) + `
+				NAME 'compactAttr` + itoa(i) + `'
+				SUP name )`)
+		}); err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), i, err)
+		}
+
+		if n := reg.Schema().sharedVersions(); n > maxSharedVersions {
+			t.Fatalf("%s[%d] failed: version shares with %d prior versions", t.Name(), i, n)
+		}
+	}
+
+	if reg.Schema().AttributeTypes().Get(`compactAttr0`).IsZero() {
+		t.Errorf("%s failed: definition lost following compaction", t.Name())
+	}
+}
+
+func TestSchemaRegistry_concurrency(t *testing.T) {
+	reg := NewSchema().NewSchemaRegistry()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+
+		for i := 0; i < 20; i++ {
+			if _, err := reg.Update(func(draft Schema) error {
+				for j := 0; j < 5; j++ {
+					if err := draft.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.97.` +
+						itoa(i) + `.` + itoa(j) + ` NAME 'batchAttr` + itoa(i) + `x` +
+						itoa(j) + `' SUP name )`); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				t.Errorf("%s[%d] failed: %v", t.Name(), i, err)
+				return
+			}
+		}
+	}()
+
+	for r := 0; r < 8; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				// A batch is either entirely present, or
+				// entirely absent.
+				v := reg.Current()
+				ats := v.Schema.AttributeTypes()
+				for i := 0; i < 20; i++ {
+					var n int
+					for j := 0; j < 5; j++ {
+						if ats.Contains(`batchAttr` + itoa(i) + `x` + itoa(j)) {
+							n++
+						}
+					}
+					if n != 0 && n != 5 {
+						t.Errorf("%s failed: partial batch %d observed in version %d", t.Name(), i, v.Version)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if v := reg.Version(); v != 21 {
+		t.Errorf("%s failed: want version 21, got %d", t.Name(), v)
+	}
+}
+
+func TestSchemaRegistry_codecov(t *testing.T) {
+	var reg SchemaRegistry
+	_ = reg.Current()
+	_ = reg.Schema()
+	_ = reg.Version()
+	if _, err := reg.Update(func(Schema) error { return nil }); !errors.Is(err, ErrNilReceiver) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrNilReceiver, err)
+	} else if _, err = reg.Publish(NewSchema()); !errors.Is(err, ErrNilReceiver) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrNilReceiver, err)
+	}
+
+	var sch Schema
+	reg = sch.NewSchemaRegistry()
+	if reg.IsZero() || reg.Current().IsZero() || reg.Version() != 1 {
+		t.Fatalf("%s failed: unexpected registry from zero schema", t.Name())
+	}
+
+	if _, err := reg.Update(nil); !errors.Is(err, ErrNilInput) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrNilInput, err)
+	} else if _, err = reg.Publish(sch); !errors.Is(err, ErrNilInput) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrNilInput, err)
+	}
+
+	src := NewSchema()
+	if v, err := reg.Publish(src); err != nil || v.Version != 2 {
+		t.Errorf("%s failed: unexpected publish result %d, %v", t.Name(), v.Version, err)
+	} else if v.Schema == src || !v.Schema.AttributeTypes().Contains(`cn`) {
+		t.Errorf("%s failed: published schema is not a snapshot", t.Name())
+	}
+
+	// Definitions shared with a prior version are read-only.
+	_, _ = reg.Update(func(draft Schema) error {
+		at := draft.AttributeTypes().Get(`cn`)
+		at.SetName(`draftName`)
+		if at.Names().Contains(`draftName`) {
+			t.Errorf("%s failed: shared definition was renamed", t.Name())
+		}
+		draft.unshare(nil)
+		draft.unshare(AttributeType{})
+		return nil
+	})
+
+	if swapDefinition(NewAttributeTypes(), reg.Schema().AttributeTypes().Get(`cn`), AttributeType{}) {
+		t.Errorf("%s failed: unexpected swap", t.Name())
+	}
+}
@@ -0,0 +1,487 @@
+package schemax
+
+/*
+remove.go contains methods for the safe removal of definitions from an
+instance of Schema, including reverse-dependency (blocker) analysis and
+cascading removal.
+*/
+
+/*
+Dependents returns slices of [Definition] instances which reference def
+in some manner, such as by way of a SUP, MUST, MAY, FORM or APPLIES clause.
+
+Only immediate (direct) dependents are returned. See [Schema.RemoveCascade]
+for a means of removing def and ALL dependents, direct or indirect.
+
+The following references are honored:
+
+  - [LDAPSyntax] is referenced by the SYNTAX of [MatchingRule] and [AttributeType] instances
+  - [MatchingRule] is referenced by the EQUALITY, SUBSTR and ORDERING of [AttributeType] instances, as well as by its own [MatchingRuleUse]
+  - [AttributeType] is referenced by the SUP of [AttributeType] instances, the MUST and MAY of [ObjectClass] and [NameForm] instances, the MUST, MAY and NOT of [DITContentRule] instances and the APPLIES of [MatchingRuleUse] instances
+  - [ObjectClass] is referenced by the SUP of [ObjectClass] instances, the OC of [NameForm] instances as well as the structural class and AUX of [DITContentRule] instances
+  - [NameForm] is referenced by the FORM of [DITStructureRule] instances
+  - [DITStructureRule] is referenced by the SUP of [DITStructureRule] instances
+
+[MatchingRuleUse] and [DITContentRule] instances are never referenced by
+other definitions.
+*/
+func (r Schema) Dependents(def Definition) (deps []Definition) {
+	if r.IsZero() || def == nil || def.IsZero() {
+		return
+	}
+
+	runlock := r.rlock()
+	defer runlock()
+
+	deps = r.dependents(def)
+
+	return
+}
+
+/*
+dependents is the unlocked counterpart of Dependents, for use where the
+lock is already held.
+*/
+func (r Schema) dependents(def Definition) (deps []Definition) {
+	switch tv := def.(type) {
+	case LDAPSyntax:
+		deps = r.syntaxDependents(tv.NumericOID())
+	case MatchingRule:
+		deps = r.matchingRuleDependents(tv.NumericOID())
+	case AttributeType:
+		deps = r.attributeTypeDependents(tv.NumericOID())
+	case ObjectClass:
+		deps = r.objectClassDependents(tv.NumericOID())
+	case NameForm:
+		deps = r.nameFormDependents(tv.NumericOID())
+	case DITStructureRule:
+		deps = r.structureRuleDependents(tv.RuleID())
+	}
+
+	return
+}
+
+func (r Schema) syntaxDependents(oid string) (deps []Definition) {
+	mrs := r.MatchingRules()
+	for i := 0; i < mrs.cast().Len(); i++ {
+		if mr := mrs.at(i); mr.Syntax().NumericOID() == oid {
+			deps = append(deps, mr)
+		}
+	}
+
+	ats := r.AttributeTypes()
+	for i := 0; i < ats.cast().Len(); i++ {
+		if at := ats.at(i); at.Syntax().NumericOID() == oid {
+			deps = append(deps, at)
+		}
+	}
+
+	return
+}
+
+func (r Schema) matchingRuleDependents(oid string) (deps []Definition) {
+	ats := r.AttributeTypes()
+	for i := 0; i < ats.cast().Len(); i++ {
+		at := ats.at(i)
+		if at.Equality().NumericOID() == oid ||
+			at.Substring().NumericOID() == oid ||
+			at.Ordering().NumericOID() == oid {
+			deps = append(deps, at)
+		}
+	}
+
+	if mu := r.MatchingRuleUses().lookup(oid); !mu.IsZero() {
+		deps = append(deps, mu)
+	}
+
+	return
+}
+
+func (r Schema) attributeTypeDependents(oid string) (deps []Definition) {
+	ats := r.AttributeTypes()
+	for i := 0; i < ats.cast().Len(); i++ {
+		if at := ats.at(i); at.SuperType().NumericOID() == oid {
+			deps = append(deps, at)
+		}
+	}
+
+	mus := r.MatchingRuleUses()
+	for i := 0; i < mus.cast().Len(); i++ {
+		if mu := mus.at(i); mu.Applies().contains(oid) {
+			deps = append(deps, mu)
+		}
+	}
+
+	ocs := r.ObjectClasses()
+	for i := 0; i < ocs.cast().Len(); i++ {
+		if oc := ocs.at(i); oc.Must().contains(oid) || oc.May().contains(oid) {
+			deps = append(deps, oc)
+		}
+	}
+
+	dcs := r.DITContentRules()
+	for i := 0; i < dcs.cast().Len(); i++ {
+		dc := dcs.at(i)
+		if dc.Must().contains(oid) || dc.May().contains(oid) || dc.Not().contains(oid) {
+			deps = append(deps, dc)
+		}
+	}
+
+	nfs := r.NameForms()
+	for i := 0; i < nfs.cast().Len(); i++ {
+		if nf := nfs.at(i); nf.Must().contains(oid) || nf.May().contains(oid) {
+			deps = append(deps, nf)
+		}
+	}
+
+	return
+}
+
+func (r Schema) objectClassDependents(oid string) (deps []Definition) {
+	ocs := r.ObjectClasses()
+	for i := 0; i < ocs.cast().Len(); i++ {
+		if oc := ocs.at(i); oc.SuperClasses().contains(oid) {
+			deps = append(deps, oc)
+		}
+	}
+
+	dcs := r.DITContentRules()
+	for i := 0; i < dcs.cast().Len(); i++ {
+		dc := dcs.at(i)
+		if dc.NumericOID() == oid || dc.Aux().contains(oid) {
+			deps = append(deps, dc)
+		}
+	}
+
+	nfs := r.NameForms()
+	for i := 0; i < nfs.cast().Len(); i++ {
+		if nf := nfs.at(i); nf.OC().NumericOID() == oid {
+			deps = append(deps, nf)
+		}
+	}
+
+	return
+}
+
+func (r Schema) nameFormDependents(oid string) (deps []Definition) {
+	dss := r.DITStructureRules()
+	for i := 0; i < dss.cast().Len(); i++ {
+		if ds := dss.at(i); ds.Form().NumericOID() == oid {
+			deps = append(deps, ds)
+		}
+	}
+
+	return
+}
+
+func (r Schema) structureRuleDependents(id uint) (deps []Definition) {
+	dss := r.DITStructureRules()
+	for i := 0; i < dss.cast().Len(); i++ {
+		ds := dss.at(i)
+		if ds.RuleID() == id {
+			// recursive rules (e.g.: a rule which
+			// is its own superior) do not block.
+			continue
+		} else if ds.SuperRules().contains(uitoa(id)) {
+			deps = append(deps, ds)
+		}
+	}
+
+	return
+}
+
+/*
+Remove returns an error following an attempt to remove def from the
+receiver instance.
+
+Removal is refused if def is referenced by any other [Definition] within
+the receiver instance.  In such a case, the returned error wraps
+[ErrDefinitionInUse] -- thus [errors.Is] reports a match -- and its text
+lists each blocking [Definition] by type and identifier. Use
+[Schema.Dependents] to obtain the blockers directly, or
+[Schema.RemoveCascade] to remove def along with all dependents.
+
+When def is an [AttributeType], any [MatchingRuleUse] instances which list
+def within their APPLIES clause are NOT considered blockers, as these are
+regenerated by the [Schema.UpdateMatchingRuleUses] method.  Instead, def is
+simply dropped from such APPLIES clauses, and a [MatchingRuleUse] left with
+no APPLIES values is removed.
+
+The write lock is held across both the dependency check and the removal,
+thus no dependent may be added in between.
+*/
+func (r Schema) Remove(def Definition) (err error) {
+	var rm removal
+	err = r.removeOne(def, &rm)
+	r.finish(rm)
+
+	return
+}
+
+func (r Schema) removeOne(def Definition, rm *removal) (err error) {
+	unlock, _ := r.lock()
+	defer unlock()
+
+	if err = r.checkRemovable(def); err != nil {
+		return
+	}
+
+	var blockers []string
+	for _, dep := range r.dependents(def) {
+		if dep.Type() != `matchingRuleUse` || def.Type() != `attributeType` {
+			blockers = append(blockers, dep.Type()+` `+defIdentity(dep))
+		}
+	}
+
+	if len(blockers) > 0 {
+		err = wraperr(ErrDefinitionInUse, ": "+def.Type()+` `+
+			defIdentity(def)+` referenced by `+join(blockers, `, `))
+		return
+	}
+
+	r.remove(def, rm)
+
+	return
+}
+
+/*
+RemoveCascade returns slices of [Definition] instances removed from the
+receiver instance following an attempt to remove def and all definitions
+which depend upon it, whether directly or indirectly.
+
+Dependents are removed before the definitions they reference, thus the
+last slice of the return value is always def.  See [Schema.Dependents]
+for the dependency semantics honored.
+
+Note that when an [AttributeType] is removed, [MatchingRuleUse] instances
+which list it within their APPLIES clause are only removed if no other
+[AttributeType] remains within the clause.
+
+As with [Schema.Remove], the write lock is held throughout.
+*/
+func (r Schema) RemoveCascade(def Definition) (removed []Definition, err error) {
+	var rm removal
+	removed, err = r.removeCascade(def, &rm)
+	r.finish(rm)
+
+	return
+}
+
+func (r Schema) removeCascade(def Definition, rm *removal) (removed []Definition, err error) {
+	unlock, _ := r.lock()
+	defer unlock()
+
+	if err = r.checkRemovable(def); err != nil {
+		return
+	}
+
+	seen := make(map[string]bool, 0)
+	removed = r.cascadeOrder(def, seen)
+	for i := 0; i < len(removed); i++ {
+		r.remove(removed[i], rm)
+	}
+
+	return
+}
+
+/*
+checkRemovable returns an error if def cannot be removed from the receiver
+instance, irrespective of any dependents.  The caller shall hold the lock.
+*/
+func (r Schema) checkRemovable(def Definition) (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+	} else if r.IsReadOnly() {
+		err = ErrReadOnlySchema
+	} else if def == nil || def.IsZero() {
+		err = ErrNilInput
+	} else if r.lookupMember(def) == nil {
+		err = wraperr(ErrNilDef, ": "+def.Type()+` `+defIdentity(def))
+	}
+
+	return
+}
+
+/*
+cascadeOrder returns def and all of its dependents (recursively) in
+the order in which they must be removed, which is to say dependents
+first.
+*/
+func (r Schema) cascadeOrder(def Definition, seen map[string]bool) (order []Definition) {
+	key := def.Type() + `:` + defIdentity(def)
+	if seen[key] {
+		return
+	}
+	seen[key] = true
+
+	for _, dep := range r.dependents(def) {
+		if dep.Type() == `matchingRuleUse` && def.Type() == `attributeType` {
+			// handled during removal of def
+			continue
+		}
+		order = append(order, r.cascadeOrder(dep, seen)...)
+	}
+
+	order = append(order, def)
+
+	return
+}
+
+/*
+removal accumulates the consequences of removals made under the write
+lock, which are acted upon by way of [Schema.finish] once the lock has
+been released.
+*/
+type removal struct {
+	events []ChangeEvent     // to be delivered to observers
+	mus    []MatchingRuleUse // in need of a new string representation
+}
+
+/*
+finish renders each [MatchingRuleUse] of rm anew and delivers the events
+of rm to the observers of the receiver.  The caller shall not hold the
+lock, as rendering reads the APPLIES clause and observers may query the
+receiver.
+*/
+func (r Schema) finish(rm removal) {
+	for _, mu := range rm.mus {
+		if !mu.compliant() {
+			continue
+		}
+
+		if str, err := mu.matchingRuleUse.prepareString(); err == nil {
+			r.modify(func() {
+				mu.matchingRuleUse.stringer = func() string {
+					return str
+				}
+			})
+		}
+	}
+
+	r.notify(rm.events...)
+}
+
+/*
+remove deletes def from the appropriate collection within the receiver
+instance without any regard for dependents.  The caller shall hold the
+write lock.
+*/
+func (r Schema) remove(def Definition, rm *removal) {
+	// Preserve the original for any observers.
+	var orig Definition
+	if r.observed() {
+		orig = r.lookupMember(def)
+	}
+
+	var ok bool
+	switch tv := def.(type) {
+	case LDAPSyntax:
+		ok = removeDefinition(r.LDAPSyntaxes(), tv)
+	case MatchingRule:
+		ok = removeDefinition(r.MatchingRules(), tv)
+	case AttributeType:
+		r.dropApplies(tv, rm)
+		ok = removeDefinition(r.AttributeTypes(), tv)
+	case MatchingRuleUse:
+		ok = removeDefinition(r.MatchingRuleUses(), tv)
+	case ObjectClass:
+		ok = removeDefinition(r.ObjectClasses(), tv)
+	case DITContentRule:
+		ok = removeDefinition(r.DITContentRules(), tv)
+	case NameForm:
+		ok = removeDefinition(r.NameForms(), tv)
+	case DITStructureRule:
+		ok = removeDefinition(r.DITStructureRules(), tv)
+	}
+
+	if ok && orig != nil {
+		rm.events = append(rm.events, ChangeEvent{
+			Operation: DefinitionRemoved,
+			Source:    RemoveSource,
+			Old:       orig,
+		})
+	}
+}
+
+/*
+dropApplies removes at from the APPLIES clause of each [MatchingRuleUse]
+in which it appears. Any [MatchingRuleUse] left without any APPLIES values
+is removed altogether.  The caller shall hold the write lock.
+*/
+func (r Schema) dropApplies(at AttributeType, rm *removal) {
+	observed := r.observed()
+
+	mus := r.MatchingRuleUses()
+	for i := 0; i < mus.cast().Len(); i++ {
+		mu := mus.at(i)
+
+		var old MatchingRuleUse
+		if observed && mu.Applies().contains(at.NumericOID()) {
+			old = mu.Clone()
+		}
+
+		if !removeDefinition(mu.Applies(), at) {
+			continue
+		}
+
+		event := ChangeEvent{
+			Operation: DefinitionReplaced,
+			Source:    MatchingRuleUseSource,
+			Old:       old,
+			New:       mu,
+		}
+
+		if mu.Applies().len() == 0 {
+			removeDefinition(mus, mu)
+			i--
+			event.Operation, event.New = DefinitionRemoved, nil
+		} else {
+			rm.mus = append(rm.mus, mu)
+		}
+
+		if !old.IsZero() {
+			rm.events = append(rm.events, event)
+		}
+	}
+}
+
+/*
+removeDefinition removes the first member of defs which bears the same
+identity as def, returning a Boolean value indicative of success.  Should
+defs reside within a [Schema], the caller shall hold its write lock.
+*/
+func removeDefinition(defs Definitions, def Definition) (ok bool) {
+	stk := defs.cast()
+	headerMutex.Lock()
+	defer headerMutex.Unlock()
+
+	id := defIdentity(def)
+	for i := 0; i < stk.Len() && !ok; i++ {
+		slice, _ := stk.Index(i)
+		if d, is := slice.(Definition); is && d.Type() == def.Type() {
+			if defIdentity(d) == id {
+				if _, ok = stk.Remove(i); ok {
+					if idx := collectionIndexOf(stk); idx != nil {
+						idx.reset()
+					}
+				}
+			}
+		}
+	}
+
+	return
+}
+
+/*
+defIdentity returns the numeric identifier of def, which is the rule ID
+in the case of a [DITStructureRule] and the numeric OID in all other cases.
+*/
+func defIdentity(def Definition) (id string) {
+	if ds, ok := def.(DITStructureRule); ok {
+		id = ds.ID()
+	} else if def != nil {
+		id = def.NumericOID()
+	}
+
+	return
+}
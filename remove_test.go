@@ -0,0 +1,144 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the refusal to remove a definition that is
+still referenced by other definitions.
+*/
+func ExampleSchema_Remove() {
+	sch := NewSchema()
+	err := sch.Remove(sch.AttributeTypes().Get(`name`))
+	fmt.Println(errors.Is(err, ErrDefinitionInUse))
+	// Output: true
+}
+
+/*
+This example demonstrates the removal of an [ObjectClass] along with
+all of its dependents.
+*/
+func ExampleSchema_RemoveCascade() {
+	sch := NewSchema()
+	removed, err := sch.RemoveCascade(sch.ObjectClasses().Get(`applicationProcess`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%s removed; %d definitions total\n", removed[len(removed)-1].Name(), len(removed))
+	// Output: applicationProcess removed; 1 definitions total
+}
+
+func TestSchema_Remove(t *testing.T) {
+	sch := NewSchema()
+
+	raw := `attributeType ( 1.3.6.1.4.1.56521.999.88.1 NAME 'fakeAttr' SUP name EQUALITY caseIgnoreMatch )
+attributeType ( 1.3.6.1.4.1.56521.999.88.2 NAME 'fakeSubAttr' SUP fakeAttr )
+objectClass ( 1.3.6.1.4.1.56521.999.88.3 NAME 'fakeClass' SUP top STRUCTURAL MUST fakeAttr )
+objectClass ( 1.3.6.1.4.1.56521.999.88.4 NAME 'fakeSubClass' SUP fakeClass STRUCTURAL MAY fakeSubAttr )
+nameForm ( 1.3.6.1.4.1.56521.999.88.5 NAME 'fakeForm' OC fakeClass MUST fakeAttr )
+dITStructureRule ( 88 NAME 'fakeRule' FORM fakeForm )
+dITStructureRule ( 89 NAME 'fakeSubRule' FORM fakeForm SUP 88 )`
+
+	if err := sch.ParseRaw([]byte(raw)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+	sch.UpdateMatchingRuleUses()
+
+	at := sch.AttributeTypes().Get(`fakeAttr`)
+	if deps := sch.Dependents(at); len(deps) != 4 {
+		t.Errorf("%s failed: want 4 dependents, got %d", t.Name(), len(deps))
+		return
+	}
+
+	if err := sch.Remove(at); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+		return
+	}
+
+	// Rule 88 is blocked by rule 89; remove both.
+	if _, err := sch.RemoveCascade(sch.DITStructureRules().Get(88)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	removed, err := sch.RemoveCascade(at)
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	} else if len(removed) != 5 {
+		t.Errorf("%s failed: want 5 removals, got %d", t.Name(), len(removed))
+		return
+	}
+
+	for _, id := range []string{`fakeAttr`, `fakeSubAttr`} {
+		if sch.AttributeTypes().Contains(id) {
+			t.Errorf("%s failed: %s not removed", t.Name(), id)
+			return
+		}
+	}
+
+	for _, id := range []string{`fakeClass`, `fakeSubClass`} {
+		if sch.ObjectClasses().Contains(id) {
+			t.Errorf("%s failed: %s not removed", t.Name(), id)
+			return
+		}
+	}
+
+	if sch.NameForms().Contains(`fakeForm`) {
+		t.Errorf("%s failed: fakeForm not removed", t.Name())
+		return
+	}
+
+	// caseIgnoreMatch must survive in MRU form, but without fakeAttr
+	if mu := sch.MatchingRuleUses().Get(`caseIgnoreMatch`); mu.IsZero() {
+		t.Errorf("%s failed: caseIgnoreMatch MRU not found", t.Name())
+		return
+	} else if mu.Applies().Contains(`fakeAttr`) {
+		t.Errorf("%s failed: fakeAttr still applied to MRU", t.Name())
+		return
+	}
+
+	if err = sch.Remove(at); err == nil {
+		t.Errorf("%s failed: expected error for removed definition", t.Name())
+		return
+	}
+}
+
+func TestSchema_Remove_codecov(t *testing.T) {
+	var sch Schema
+	if err := sch.Remove(nil); !errors.Is(err, ErrNilReceiver) {
+		t.Errorf("%s failed: unexpected error %v", t.Name(), err)
+	}
+
+	sch = NewSchema()
+	_ = sch.Remove(nil)
+	_ = sch.Remove(AttributeType{})
+	_ = sch.Dependents(nil)
+
+	syn := sch.LDAPSyntaxes().Get(`1.3.6.1.4.1.1466.115.121.1.7`)
+	if len(sch.Dependents(syn)) == 0 {
+		t.Errorf("%s failed: no dependents for Boolean syntax", t.Name())
+	}
+
+	mr := sch.MatchingRules().Get(`booleanMatch`)
+	if len(sch.Dependents(mr)) == 0 {
+		t.Errorf("%s failed: no dependents for booleanMatch", t.Name())
+	}
+
+	_ = sch.Dependents(sch.NameForms().Index(0))
+	_ = sch.Dependents(sch.DITStructureRules().Index(0))
+
+	if _, err := sch.RemoveCascade(sch.DITContentRules().Index(0)); err != nil && sch.DITContentRules().Len() > 0 {
+		t.Errorf("%s failed: %v", t.Name(), err)
+	}
+
+	if err := sch.Remove(sch.MatchingRuleUses().Index(0)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+	}
+}
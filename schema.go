@@ -4,6 +4,10 @@ package schemax
 schema.go centralizes all schema operations within a single construct.
 */
 
+import (
+	"sync"
+)
+
 const (
 	ldapSyntaxesIndex      int = iota // 0
 	matchingRulesIndex                // 1
@@ -109,19 +113,21 @@ func NewEmptySchema(o ...Option) (r Schema) {
 /*
 initSchema returns an initialized instance of Schema.
 */
-func initSchema(o ...Option) Schema {
+func initSchema(o ...Option) (r Schema) {
 	opts := newOpts()
 	for i := 0; i < len(o); i++ {
 		opts.Shift(o[i])
 	}
 
-	return Schema(stackageList().
+	r = Schema(stackageList().
 		SetID(`cn=schema`).
 		SetCategory(`subschemaSubentry`).
 		SetDelimiter(rune(10)).
 		SetAuxiliary(map[string]any{
-			`macros`:  newMacros(),
-			`options`: opts,
+			`macros`:    newMacros(),
+			`options`:   opts,
+			`observers`: newChangeObservers(),
+			`mutex`:     &sync.RWMutex{},
 		}).
 		Mutex().
 		Push(NewLDAPSyntaxes(), // 0
@@ -132,6 +138,31 @@ func initSchema(o ...Option) Schema {
 			NewDITContentRules(),    // 5
 			NewNameForms(),          // 6
 			NewDITStructureRules())) // 7
+
+	// Associate each collection with the new schema,
+	// allowing definitions to be created on-demand
+	// (e.g.: during JSON decoding), and furnish each
+	// with a lookup index and the lock of the new
+	// schema.
+	mu := r.mutex()
+	for _, defs := range []Definitions{
+		r.LDAPSyntaxes(),
+		r.MatchingRules(),
+		r.AttributeTypes(),
+		r.MatchingRuleUses(),
+		r.ObjectClasses(),
+		r.DITContentRules(),
+		r.NameForms(),
+		r.DITStructureRules(),
+	} {
+		defs.cast().SetAuxiliary(map[string]any{
+			`schema`: r,
+			`index`:  newCollectionIndex(),
+			`mutex`:  mu,
+		})
+	}
+
+	return
 }
 
 /*
@@ -163,6 +194,10 @@ the current manifest of [MatchingRuleUse] instances using all of the
 time of execution.
 */
 func (r Schema) UpdateMatchingRuleUses() error {
+	if r.IsReadOnly() {
+		return ErrReadOnlySchema
+	}
+
 	return r.updateMatchingRuleUses(r.AttributeTypes())
 }
 
@@ -219,6 +254,19 @@ func (r Schema) Replace(x Definition) Schema {
 		return r
 	}
 
+	// A definition shared with a prior version (see
+	// SchemaRegistry) must be copied before it may be
+	// modified.
+	r.unshare(x)
+
+	// Preserve the original state for any observers.
+	var old Definition
+	if r.observed() {
+		if orig := r.member(x); orig != nil {
+			old = cloneDefinition(orig)
+		}
+	}
+
 	tmap := map[string]func(){
 		`ldapSyntax`: func() {
 			orig := r.LDAPSyntaxes().Get(x.NumericOID())
@@ -256,6 +304,14 @@ func (r Schema) Replace(x Definition) Schema {
 
 	if fn, ok := tmap[x.Type()]; ok {
 		fn()
+		if old != nil && !r.IsReadOnly() {
+			r.notify(ChangeEvent{
+				Operation: DefinitionReplaced,
+				Source:    ReplaceSource,
+				Old:       old,
+				New:       r.member(x),
+			})
+		}
 	}
 
 	return r
@@ -272,18 +328,22 @@ func (r Schema) IsZero() bool {
 Counters returns an instance of [Counters] bearing the current number
 of definitions by category.
 
-The return instance is merely a snapshot in time and is NOT thread-safe.
+The return instance is a consistent tally taken at a single point in time
+under the read lock.  It is not updated thereafter.
 */
 func (r Schema) Counters() Counters {
+	runlock := r.rlock()
+	defer runlock()
+
 	return Counters{
-		LS: r.LDAPSyntaxes().Len(),
-		MR: r.MatchingRules().Len(),
-		AT: r.AttributeTypes().Len(),
-		MU: r.MatchingRuleUses().Len(),
-		OC: r.ObjectClasses().Len(),
-		DC: r.DITContentRules().Len(),
-		NF: r.NameForms().Len(),
-		DS: r.DITStructureRules().Len(),
+		LS: r.LDAPSyntaxes().cast().Len(),
+		MR: r.MatchingRules().cast().Len(),
+		AT: r.AttributeTypes().cast().Len(),
+		MU: r.MatchingRuleUses().cast().Len(),
+		OC: r.ObjectClasses().cast().Len(),
+		DC: r.DITContentRules().cast().Len(),
+		NF: r.NameForms().cast().Len(),
+		DS: r.DITStructureRules().cast().Len(),
 	}
 }
 
@@ -355,9 +415,18 @@ usable schema definitions. This method operates similarly to the
 [Schema.ParseFile] method, except this method expects "pre-read" raw
 definition bytes rather than a filesystem path leading to such content.
 
-This method wraps the [antlr4512.Schema.ParseRaw] method.
+Definitions need not be ordered by dependency; see [Schema.ParseDirectory]
+for details.
+
+This method wraps the [antlr4512.Schema.ParseRaw] method, unless the
+[ContinueOnError] option is in effect.  See [ParseError] for details.
 */
 func (r Schema) ParseRaw(raw []byte) (err error) {
+	if r.Options().Positive(ContinueOnError) {
+		err = joinErr(r.parseTolerant(raw, ``)...)
+		return
+	}
+
 	s := new4512Schema()
 	if err = s.ParseRaw(raw); err == nil {
 		// begin second phase
@@ -372,9 +441,15 @@ ParseFile returns an error following an attempt to parse file. Only
 files ending in ".schema" will be considered, however submission of
 non-qualifying files shall not produce an error.
 
-This method wraps the [antlr4512.Schema.ParseFile] method.
+This method wraps the [antlr4512.Schema.ParseFile] method, unless the
+[ContinueOnError] option is in effect.  See [ParseError] for details.
 */
 func (r Schema) ParseFile(file string) (err error) {
+	if r.Options().Positive(ContinueOnError) {
+		err = r.parseFileTolerant(file)
+		return
+	}
+
 	s := new4512Schema()
 	if err = s.ParseFile(file); err == nil {
 		// begin second phase
@@ -391,9 +466,24 @@ indefinitely.  Files encountered will only be read if their name
 ends in ".schema", at which point their contents are read into
 bytes, processed using ANTLR and written to the receiver instance.
 
-This method wraps the [antlr4512.Schema.ParseDirectory] method.
+All definitions found within all files are collected before any are
+written to the receiver instance.  Definitions are written in order of
+type precedence (e.g.: attribute types before object classes) and, within
+each type, in order of dependency (e.g.: a supertype before its subtypes).
+As such, neither the names of files nor the order of definitions therein
+are significant.  Circular or unsatisfiable superior references result in
+an error.
+
+This method wraps the [antlr4512.Schema.ParseDirectory] method, unless
+the [ContinueOnError] option is in effect, in which case all failures
+across all files are returned.  See [ParseError] for details.
 */
 func (r Schema) ParseDirectory(dir string) (err error) {
+	if r.Options().Positive(ContinueOnError) {
+		err = r.parseDirectoryTolerant(dir)
+		return
+	}
+
 	s := new4512Schema()
 	if err = s.ParseDirectory(dir); err == nil {
 		// begin second phase
@@ -1,6 +1,7 @@
 package schemax
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -492,7 +493,11 @@ func TestSchema_ParseFileAndDirectory(t *testing.T) {
 		return
 	}
 
-	if err = mySchema.ParseDirectory(tempDir); err != nil {
+	// Use a separate schema, as the directory contains the file
+	// just parsed into mySchema.  Parsing it into mySchema again
+	// always failed as a duplicate, but the failure was discarded
+	// until the shadowed error within Schema.incorporate was fixed.
+	if err = NewBasicSchema().ParseDirectory(tempDir); err != nil {
 		t.Errorf("%s failed: %v", t.Name(), err)
 		return
 	}
@@ -505,6 +510,151 @@ func TestSchema_ParseFileAndDirectory(t *testing.T) {
 	_ = mySchema.ParseDirectory(bogusName)
 }
 
+/*
+This example demonstrates the collection of ALL parsing failures, with
+location details, through use of the [ContinueOnError] option.
+*/
+func ExampleParseError() {
+	sch := NewSchema(ContinueOnError)
+
+	raw := []byte(`attributeType ( 1.3.6.1.4.1.56521.999.86.1
+	NAME 'fakeAttr'
+	SUP bogusAttr )
+attributeType ( 1.3.6.1.4.1.56521.999.86.2 NAME 'goodAttr' SUP name )
+objectClass ( 1.3.6.1.4.1.56521.999.86.3 NAME 'fakeClass' MUST bogusAttr )`)
+
+	err := sch.ParseRaw(raw)
+	fmt.Println(err)
+	fmt.Println(sch.AttributeTypes().Contains(`goodAttr`))
+	// Output:
+	// 1: attributeType 1.3.6.1.4.1.56521.999.86.1 (fakeAttr): AttributeType not found(bogusAttr referenced by fakeAttr)
+	// 5: objectClass 1.3.6.1.4.1.56521.999.86.3 (fakeClass): Unknown AttributeType for MUST clause: bogusAttr
+	// true
+}
+
+func TestSchema_ParseRaw_ContinueOnError(t *testing.T) {
+	sch := NewSchema(ContinueOnError)
+
+	raw := []byte(`# leading comment
+dn: cn=schema
+objectidentifier fakeRoot 1.3.6.1.4.1.56521.999.85
+objectidentifier fakeAttrs fakeRoot:1
+objectidentifier badMacro bogusRoot:1
+attributetype ( fakeAttrs:1 NAME 'fakeMacroAttr' SUP name )	# trailing comment
+attributeTypes: ( 1.3.6.1.4.1.56521.999.85.1.1 NAME 'dupAttr' SUP name )
+attributeType ( 1.3.6.1.4.1.56521.999.85.1.2
+	NAME 'fake#Attr'    # comment
+	SUP name )
+bogusLabel ( 1.2.3 )
+objectClass ( 1.3.6.1.4.1.56521.999.85.2 NAME 'fakeClass' SUP top AUXILIARY MAY fakeMacroAttr )
+nameForm ( 1.3.6.1.4.1.56521.999.85.3 NAME 'unbalancedForm' OC fakeClass MUST cn`)
+
+	err := sch.ParseRaw(raw)
+	if err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+		return
+	}
+
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	// badMacro, dupAttr (duplicate OID), fake#Attr,
+	// bogusLabel and unbalancedForm.
+	if len(errs) != 5 {
+		t.Errorf("%s failed: want 5 errors, got %d:\n%v", t.Name(), len(errs), err)
+		return
+	}
+
+	var perr ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("%s failed: expected ParseError", t.Name())
+		return
+	}
+
+	if !errors.Is(err, ErrDuplicateDef) {
+		t.Errorf("%s failed: expected ErrDuplicateDef", t.Name())
+		return
+	} else if !errors.Is(err, ErrUnrecognizedContent) {
+		t.Errorf("%s failed: expected ErrUnrecognizedContent", t.Name())
+		return
+	} else if !errors.Is(err, ErrIncompleteDefinition) {
+		t.Errorf("%s failed: expected ErrIncompleteDefinition", t.Name())
+		return
+	}
+
+	for idx, want := range []int{5, 7, 8, 11, 13} {
+		var pe ParseError
+		if !errors.As(errs[idx], &pe) || pe.Line != want {
+			t.Errorf("%s failed: error %d: want line %d, got %v", t.Name(), idx, want, errs[idx])
+			return
+		}
+	}
+
+	if !sch.AttributeTypes().Contains(`fakeMacroAttr`) {
+		t.Errorf("%s failed: fakeMacroAttr not parsed", t.Name())
+		return
+	} else if !sch.ObjectClasses().Contains(`fakeClass`) {
+		t.Errorf("%s failed: fakeClass not parsed", t.Name())
+		return
+	}
+}
+
+func TestSchema_ParseDirectory_ContinueOnError(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+
+	for name, text := range map[string]string{
+		`00-good.schema`: "attributeType ( 1.3.6.1.4.1.56521.999.84.1 NAME 'fakeAttr' SUP name )\n",
+		`01-bad.schema`:  "attributeType ( 1.3.6.1.4.1.56521.999.84.2 NAME 'badAttr' SUP bogusAttr )\n",
+		`02-sub.schema`:  "\nattributeType ( 1.3.6.1.4.1.56521.999.84.3 NAME 'fakeSubAttr' SUP fakeAttr )\n",
+		`README`:         "not a schema file",
+	} {
+		if err = ioutil.WriteFile(filepath.Join(tempDir, name), []byte(text), 0644); err != nil {
+			t.Errorf("%s failed: %v", t.Name(), err)
+			return
+		}
+	}
+
+	sch := NewSchema(ContinueOnError)
+	err = sch.ParseDirectory(tempDir)
+
+	var perr ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("%s failed: expected ParseError, got %v", t.Name(), err)
+		return
+	} else if filepath.Base(perr.File) != `01-bad.schema` || perr.Line != 1 || perr.Name != `badAttr` {
+		t.Errorf("%s failed: unexpected error details: %v", t.Name(), perr)
+		return
+	} else if !errors.Is(err, ErrAttributeTypeNotFound) {
+		t.Errorf("%s failed: expected ErrAttributeTypeNotFound, got %v", t.Name(), err)
+		return
+	}
+
+	if !sch.AttributeTypes().Contains(`fakeSubAttr`) {
+		t.Errorf("%s failed: fakeSubAttr not parsed", t.Name())
+		return
+	}
+
+	if err = sch.ParseFile(filepath.Join(tempDir, `README`)); err == nil {
+		t.Errorf("%s failed: expected error for non-schema file", t.Name())
+		return
+	} else if err = sch.ParseFile(filepath.Join(tempDir, `02-sub.schema`)); !errors.Is(err, ErrDuplicateDef) {
+		t.Errorf("%s failed: expected ErrDuplicateDef, got %v", t.Name(), err)
+		return
+	} else if err = sch.ParseDirectory(randString(8)); err == nil {
+		t.Errorf("%s failed: expected error for bogus directory", t.Name())
+		return
+	}
+
+	_ = ParseError{}.Error()
+}
+
 func TestLoads_codecov(t *testing.T) {
 	coolSchema := NewEmptySchema()
 	coolSchema.LoadRFC4517Syntaxes()
@@ -3211,6 +3361,12 @@ nameform ( 1.3.6.1.4.1.56521.101.2.7.3
 #    been added for the sake of testing and examples within the go-schemax
 #    package.
 #
+#    Rules 21 and 22 originally named the nonexistent rule 0 as their
+#    superior, and thus always failed to incorporate.  The failure went
+#    unnoticed as ParseRaw discarded incorporation errors, leaving both
+#    rules absent.  They now name 'rootArcStructure' (20), the superior
+#    rule evidently intended.
+#
 # 2.8.1.  'rootArcStructure'
 #
 ditstructurerule ( 20
@@ -3225,7 +3381,7 @@ ditstructurerule ( 21
 	NAME 'arcStructure'
 	DESC 'structure rule for three dimensional arc entries; FOR DEMONSTRATION USE ONLY'
 	FORM nArcForm
-	SUP 0
+	SUP 20
 	X-ORIGIN 'draft-coretta-oiddir-schema; unofficial supplement' )
 #
 # 2.8.3  'dotNotArcStructure'
@@ -3234,7 +3390,7 @@ ditstructurerule ( 22
 	NAME 'dotNotArcStructure'
 	DESC 'structure rule for two dimensional arc entries; FOR DEMONSTRATION USE ONLY'
 	FORM dotNotationArcForm
-	SUP 0
+	SUP 20
 	X-ORIGIN 'draft-coretta-oiddir-schema; unofficial supplement' )
 #
 # END OF DEFINITIONS
@@ -0,0 +1,281 @@
+package schemax
+
+/*
+snapshot.go contains facilities relating to the concurrent use of Schema
+instances, including read-only snapshots.
+*/
+
+import (
+	"sync"
+
+	"github.com/JesseCoretta/go-stackage"
+)
+
+/*
+Snapshot returns an immutable, read-only deep copy of the receiver instance
+which may be queried by any number of goroutines.
+
+Each [Schema] instance created by way of [NewSchema], [NewEmptySchema] or
+[NewBasicSchema] bears its own lock, and employs the following concurrency
+model, which favors many concurrent readers alongside a single (e.g.:
+administrative) writer:
+
+  - The Len, Index, Get and Contains methods of each collection -- as well as [Schema.Exists] and [Schema.Counters] -- acquire a read lock
+  - Pushes (including those performed through parsing and loading), removals and replacements acquire the write lock, as does the updating of [MatchingRuleUse] instances
+  - The setters of each [Definition] (e.g.: [AttributeType.SetDescription]) acquire the write lock; goroutines which read the clauses of a [Definition] which may be concurrently modified should query a snapshot instead
+  - The E method of each [Definition] acquires a read lock, as setters record their errors under the write lock
+
+The return instance is produced under the read lock, thus it represents a
+consistent point-in-time view.  All attempts to push into, remove from,
+replace within or parse into the return instance shall fail with
+[ErrReadOnlySchema].  Its definitions are likewise immutable: setters leave
+them unchanged, save for the recording of [ErrReadOnlySchema] (see, e.g.:
+[AttributeType.E]), and pushes into their clauses (e.g.: [ObjectClass.May])
+fail with [ErrReadOnlySchema].  Subsequent modification of the receiver does
+not influence the return instance in any way.
+
+Execution of this method upon a read-only (snapshot) receiver returns the
+receiver as-is.
+*/
+func (r Schema) Snapshot() (s Schema) {
+	if r.IsZero() || r.IsReadOnly() {
+		return r
+	}
+
+	s = r.Clone()
+	s.freeze()
+
+	return
+}
+
+/*
+freeze renders the receiver, each of its collections and the clauses of
+each of its definitions read-only.
+*/
+func (r Schema) freeze() {
+	for _, defs := range []Definitions{
+		r.LDAPSyntaxes(),
+		r.MatchingRules(),
+		r.AttributeTypes(),
+		r.MatchingRuleUses(),
+		r.ObjectClasses(),
+		r.DITContentRules(),
+		r.NameForms(),
+		r.DITStructureRules(),
+	} {
+		defs.cast().ReadOnly(true)
+	}
+
+	for _, def := range r.collectionMembers() {
+		freezeDefinition(def)
+	}
+
+	r.cast().ReadOnly(true)
+}
+
+/*
+freezeDefinition renders the NAME and extension clauses of def, as well as
+any clauses which contain other definitions (e.g.: MUST), read-only.
+*/
+func freezeDefinition(def Definition) {
+	var stks []stackage.Stack
+	switch tv := def.(type) {
+	case LDAPSyntax:
+		stks = append(stks, tv.lDAPSyntax.Extensions.cast())
+	case MatchingRule:
+		stks = append(stks, tv.matchingRule.Name.cast(),
+			tv.matchingRule.Extensions.cast())
+	case AttributeType:
+		stks = append(stks, tv.attributeType.Name.cast(),
+			tv.attributeType.Extensions.cast())
+	case MatchingRuleUse:
+		stks = append(stks, tv.matchingRuleUse.Name.cast(),
+			tv.matchingRuleUse.Applies.cast(),
+			tv.matchingRuleUse.Extensions.cast())
+	case ObjectClass:
+		stks = append(stks, tv.objectClass.Name.cast(),
+			tv.objectClass.SuperClasses.cast(),
+			tv.objectClass.Must.cast(),
+			tv.objectClass.May.cast(),
+			tv.objectClass.Extensions.cast())
+	case DITContentRule:
+		stks = append(stks, tv.dITContentRule.Name.cast(),
+			tv.dITContentRule.Aux.cast(),
+			tv.dITContentRule.Must.cast(),
+			tv.dITContentRule.May.cast(),
+			tv.dITContentRule.Not.cast(),
+			tv.dITContentRule.Extensions.cast())
+	case NameForm:
+		stks = append(stks, tv.nameForm.Name.cast(),
+			tv.nameForm.Must.cast(),
+			tv.nameForm.May.cast(),
+			tv.nameForm.Extensions.cast())
+	case DITStructureRule:
+		stks = append(stks, tv.dITStructureRule.Name.cast(),
+			tv.dITStructureRule.SuperRules.cast(),
+			tv.dITStructureRule.Extensions.cast())
+	}
+
+	for _, stk := range stks {
+		stk.ReadOnly(true)
+	}
+}
+
+/*
+IsReadOnly returns a Boolean value indicative of whether the receiver
+instance is read-only, such as one produced by [Schema.Snapshot].
+*/
+func (r Schema) IsReadOnly() bool {
+	return r.cast().IsReadOnly()
+}
+
+/*
+headerMutex guards the slice header of every collection, which is written
+by each push and removal.  It is held only for the duration of one such
+mutation, or while the lock of the [Schema] to which a collection belongs
+is retrieved from the collection's [stackage.Auxiliary], as this cannot
+otherwise be read safely alongside a concurrent push.  Collections which
+belong to no [Schema], such as the MUST clause of an [ObjectClass], are
+guarded by headerMutex alone.
+
+The contents of each [Schema] are guarded by its own lock; see mutex.
+*/
+var headerMutex sync.RWMutex
+
+/*
+mutex returns the lock which guards the collections -- and the member
+definitions -- of the receiver instance, or nil if the receiver is zero.
+*/
+func (r Schema) mutex() (mu *sync.RWMutex) {
+	if !r.IsZero() {
+		mu, _ = r.cast().Auxiliary()[`mutex`].(*sync.RWMutex)
+	}
+
+	return
+}
+
+/*
+collectionMutex returns the lock of the [Schema] to which stk belongs, or
+nil if stk belongs to no [Schema].
+*/
+func collectionMutex(stk stackage.Stack) (mu *sync.RWMutex) {
+	headerMutex.RLock()
+	defer headerMutex.RUnlock()
+
+	if aux := stk.Auxiliary(); aux != nil {
+		mu, _ = aux[`mutex`].(*sync.RWMutex)
+	}
+
+	return
+}
+
+/*
+rlockCollection acquires the read lock which guards stk, returning the
+closure by which it shall be released.
+*/
+func rlockCollection(stk stackage.Stack) (runlock func()) {
+	if mu := collectionMutex(stk); mu != nil {
+		mu.RLock()
+		runlock = mu.RUnlock
+	} else {
+		headerMutex.RLock()
+		runlock = headerMutex.RUnlock
+	}
+
+	return
+}
+
+/*
+lockCollection acquires the write lock which guards stk, alongside that
+of its slice header, returning the closure by which both shall be released.
+*/
+func lockCollection(stk stackage.Stack) (unlock func()) {
+	mu := collectionMutex(stk)
+	if mu != nil {
+		mu.Lock()
+	}
+	headerMutex.Lock()
+
+	unlock = func() {
+		headerMutex.Unlock()
+		if mu != nil {
+			mu.Unlock()
+		}
+	}
+
+	return
+}
+
+/*
+lock acquires the write lock of the receiver, returning the closure by
+which it shall be released.  A Boolean value of false is returned if the
+receiver is read-only, in which case the caller may modify nothing other
+than the error instances of its definitions.  A zero receiver is regarded
+as writable, though it has no lock to acquire.
+*/
+func (r Schema) lock() (unlock func(), ok bool) {
+	unlock = func() {}
+	if mu := r.mutex(); mu != nil {
+		mu.Lock()
+		unlock = mu.Unlock
+	}
+	ok = !r.IsReadOnly()
+
+	return
+}
+
+/*
+rlock acquires the read lock of the receiver, returning the closure by
+which it shall be released.
+*/
+func (r Schema) rlock() (runlock func()) {
+	runlock = func() {}
+	if mu := r.mutex(); mu != nil {
+		mu.RLock()
+		runlock = mu.RUnlock
+	}
+
+	return
+}
+
+/*
+modify executes fn under the write lock of the receiver, unless the
+receiver is read-only, in which case fn is not executed.
+*/
+func (r Schema) modify(fn func()) {
+	unlock, ok := r.lock()
+	defer unlock()
+
+	if ok {
+		fn()
+	}
+}
+
+/*
+refuse assigns [ErrReadOnlySchema] to the error instance referenced by
+err -- that of a definition of the receiver -- under the write lock.
+*/
+func (r Schema) refuse(err *error) {
+	unlock, _ := r.lock()
+	defer unlock()
+
+	*err = ErrReadOnlySchema
+}
+
+/*
+lockedPush pushes x into stk under the write lock.  An error is returned
+if stk is read-only.
+*/
+func lockedPush(stk stackage.Stack, x any) (err error) {
+	unlock := lockCollection(stk)
+	defer unlock()
+
+	if stk.IsReadOnly() {
+		err = ErrReadOnlySchema
+		return
+	}
+
+	stk.Push(x)
+
+	return
+}
@@ -0,0 +1,319 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+This example demonstrates the use of a read-only [Schema] snapshot, which
+remains unaffected by subsequent changes to the live [Schema].
+*/
+func ExampleSchema_Snapshot() {
+	sch := NewSchema()
+	snap := sch.Snapshot()
+
+	if err := sch.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.92.1
+		NAME 'liveOnly'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(sch.AttributeTypes().Contains(`liveOnly`), snap.AttributeTypes().Contains(`liveOnly`))
+	fmt.Println(snap.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.92.2
+		NAME 'snapOnly'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`))
+	// Output:
+	// true false
+	// Schema is read-only
+}
+
+func TestSchema_Snapshot(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+	snap := sch.Snapshot()
+
+	if !snap.IsReadOnly() || sch.IsReadOnly() {
+		t.Fatalf("%s failed: unexpected read-only states", t.Name())
+	} else if !snap.AttributeTypes().cast().IsReadOnly() || sch.AttributeTypes().cast().IsReadOnly() {
+		t.Errorf("%s failed: unexpected collection read-only states", t.Name())
+	} else if again := snap.Snapshot(); again.cast().ID() != snap.cast().ID() || !again.IsReadOnly() {
+		t.Errorf("%s failed: snapshot of snapshot not returned as-is", t.Name())
+	} else if sch.Counters() != snap.Counters() {
+		t.Errorf("%s failed: counters differ:\nwant: %v\ngot:  %v", t.Name(), sch.Counters(), snap.Counters())
+	}
+
+	cn := snap.AttributeTypes().Get(`cn`)
+	if cn.IsZero() || cn.Schema().cast().ID() != snap.cast().ID() || !cn.Schema().IsReadOnly() {
+		t.Fatalf("%s failed: snapshot definition not associated with snapshot", t.Name())
+	}
+
+	for idx, err := range []error{
+		snap.AttributeTypes().Push(cn.Clone()),
+		snap.Remove(cn),
+		snap.UpdateMatchingRuleUses(),
+		snap.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.92.3 NAME 'snapClass' SUP top AUXILIARY )`),
+	} {
+		if !errors.Is(err, ErrReadOnlySchema) {
+			t.Errorf("%s[%d] failed: want %v, got %v", t.Name(), idx, ErrReadOnlySchema, err)
+		}
+	}
+
+	if _, err := snap.RemoveCascade(cn); !errors.Is(err, ErrReadOnlySchema) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrReadOnlySchema, err)
+	}
+
+	cn.SetName(`snapName`)
+	if !errors.Is(cn.E(), ErrReadOnlySchema) || cn.Names().Contains(`snapName`) || snap.AttributeTypes().Contains(`snapName`) {
+		t.Errorf("%s failed: rename of snapshot definition not refused", t.Name())
+	}
+
+	rep := sch.AttributeTypes().Get(`cn`).Clone()
+	rep.attributeType.Desc = `replaced`
+	snap.Replace(rep)
+	if snap.AttributeTypes().Get(`cn`).Description() == `replaced` {
+		t.Errorf("%s failed: replacement within snapshot not refused", t.Name())
+	}
+
+	// Changes to the live schema must not influence the snapshot.
+	sch.Replace(rep)
+	if sch.AttributeTypes().Get(`cn`).Description() != `replaced` {
+		t.Errorf("%s failed: replacement within live schema failed", t.Name())
+	} else if snap.AttributeTypes().Get(`cn`).Description() == `replaced` {
+		t.Errorf("%s failed: live replacement visible within snapshot", t.Name())
+	}
+
+	var zero Schema
+	if !zero.Snapshot().IsZero() || zero.IsReadOnly() {
+		t.Errorf("%s failed: unexpected zero snapshot", t.Name())
+	}
+}
+
+func TestSchema_concurrency(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+	snap := sch.Snapshot()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// one writer
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+
+		for i := 0; i < 50; i++ {
+			if err := sch.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.93.` + itoa(i) + `
+				NAME 'concurrentAttr` + itoa(i) + `'
+				SUP name )`); err != nil {
+				t.Errorf("%s[%d] failed: %v", t.Name(), i, err)
+				return
+			}
+			at := sch.AttributeTypes().Get(`concurrentAttr` + itoa(i))
+			at.SetName(`concurrentAlias` + itoa(i))
+			if i%2 == 0 {
+				if err := sch.Remove(at); err != nil {
+					t.Errorf("%s[%d] failed: %v", t.Name(), i, err)
+					return
+				}
+			}
+		}
+
+		if err := sch.UpdateMatchingRuleUses(); err != nil {
+			t.Errorf("%s failed: %v", t.Name(), err)
+		}
+	}()
+
+	// many readers
+	for r := 0; r < 8; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				ats := sch.AttributeTypes()
+				_ = ats.Get(`cn`)
+				_ = ats.Contains(`concurrentAlias1`)
+				_ = ats.Index(ats.Len() - 1)
+				_ = sch.Exists(ats.Get(`name`))
+				_ = sch.Counters()
+
+				if at := snap.AttributeTypes().Get(`cn`); at.Name() != `cn` {
+					t.Errorf("%s failed: unexpected snapshot result %q", t.Name(), at.Name())
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := sch.AttributeTypes().Get(`concurrentAlias49`); got.NumericOID() != `1.3.6.1.4.1.56521.999.93.49` {
+		t.Errorf("%s failed: writer results not visible", t.Name())
+	} else if sch.AttributeTypes().Contains(`concurrentAttr48`) {
+		t.Errorf("%s failed: removed definition still visible", t.Name())
+	}
+}
+
+/*
+Readers which evaluate compliance (e.g.: SuperChain and Check), or which
+take snapshots, must not race with a concurrent writer, including one
+which modifies definitions by way of their setters.
+*/
+func TestSchema_concurrency_readers(t *testing.T) {
+	sch := NewSchema()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+
+		cn := sch.AttributeTypes().Get(`cn`)
+		for i := 0; i < 25; i++ {
+			if err := sch.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.95.` + itoa(i) + `
+	NAME 'readerAttr` + itoa(i) + `'
+	SUP cn )`)); err != nil {
+				t.Errorf("%s[%d] failed: %v", t.Name(), i, err)
+				return
+			}
+			cn.SetDescription(`writer `+itoa(i)).SetExtension(`X-WRITER`, itoa(i))
+		}
+	}()
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				if sups := sch.AttributeTypes().Get(`cn`).SuperChain(); sups.Len() != 1 {
+					t.Errorf("%s failed: unexpected super chain %v", t.Name(), sups)
+					return
+				}
+				_ = sch.Snapshot().Counters()
+				_ = sch.AttributeTypes().Get(`cn`).Check()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if desc := sch.AttributeTypes().Get(`cn`).Description(); desc != `writer 24` {
+		t.Errorf("%s failed: unexpected description %q", t.Name(), desc)
+	}
+}
+
+/*
+The definitions of a snapshot cannot be modified.
+*/
+func TestSchema_Snapshot_immutable(t *testing.T) {
+	snap := NewSchema().Snapshot()
+	want, err := snap.MarshalJSON()
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	cn := snap.AttributeTypes().Get(`cn`)
+	cn.SetDescription(`x`).SetSingleValue().SetExtension(`X-MUTATED`, `TRUE`).SetStringer()
+	if cn.Description() == `x` || cn.SingleValue() || cn.Extensions().Len() != 1 {
+		t.Errorf("%s failed: snapshot attributeType modified", t.Name())
+	}
+
+	person := snap.ObjectClasses().Get(`person`)
+	for idx, err := range []error{
+		person.May().Push(snap.AttributeTypes().Get(`uid`)),
+		person.Names().Push(`snapPerson`),
+		person.Extensions().Push(NewExtensions()),
+	} {
+		if !errors.Is(err, ErrReadOnlySchema) {
+			t.Errorf("%s[%d] failed: want %v, got %v", t.Name(), idx, ErrReadOnlySchema, err)
+		}
+	}
+	person.SetMust(`uid`).SetSuperClass(`account`).SetObsolete()
+	person.Extensions().Set(`X-ORIGIN`, `mutated`)
+	if person.May().Contains(`uid`) || person.Must().Contains(`uid`) || person.Obsolete() {
+		t.Errorf("%s failed: snapshot objectClass modified", t.Name())
+	}
+
+	if got, _ := snap.MarshalJSON(); string(got) != string(want) {
+		t.Errorf("%s failed: snapshot modified", t.Name())
+	}
+}
+
+/*
+Reindexing of a conflicting structure rule occurs during a push, and thus
+under the write lock; it must not attempt to reacquire the lock.
+*/
+func TestDITStructureRules_reindexUnderLock(t *testing.T) {
+	sch := NewSchema(AllowReindexedStructureRules)
+	if err := sch.ParseNameForm(`( 1.3.6.1.4.1.56521.999.94.1
+		NAME 'reindexForm'
+		OC account
+		MUST uid )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	if err := sch.ParseDITStructureRule(`( 94 NAME 'firstRule' FORM reindexForm )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	second := sch.NewDITStructureRule().
+		SetRuleID(94).
+		SetName(`secondRule`).
+		SetForm(`reindexForm`).
+		SetStringer()
+	if err := sch.DITStructureRules().Push(second); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	ds := sch.DITStructureRules().Get(`secondRule`)
+	if ds.RuleID() == 94 {
+		t.Fatalf("%s failed: conflicting rule ID not reindexed", t.Name())
+	} else if got := sch.DITStructureRules().Get(ds.RuleID()); got.Name() != `secondRule` {
+		t.Errorf("%s failed: reindexed rule not found by new ID", t.Name())
+	} else if !strings.Contains(ds.String(), `REINDEXED`) || !strings.HasPrefix(ds.String(), `( `+uitoa(ds.RuleID())) {
+		t.Errorf("%s failed: stringer not updated following reindex:\n%s", t.Name(), ds)
+	}
+}
+
+func TestSchema_lock_perSchema(t *testing.T) {
+	a, b := NewSchema(), NewSchema()
+
+	unlock, _ := a.lock()
+	defer unlock()
+
+	done := make(chan error)
+	go func() {
+		done <- b.ParseAttributeType(`( 1.3.6.1.4.1.56521.999.95.1
+			NAME 'otherSchema'
+			SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("%s failed: %v", t.Name(), err)
+		} else if !b.AttributeTypes().Contains(`otherSchema`) {
+			t.Errorf("%s failed: definition not pushed", t.Name())
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("%s failed: writer of one schema blocked by the lock of another", t.Name())
+	}
+}
@@ -0,0 +1,263 @@
+package schemax
+
+/*
+stringprep.go implements the LDAP Internationalized String Preparation
+procedures defined within RFC 4518.
+*/
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+/*
+String preparation kinds, for use with instances of [StringPreparer].
+
+Per RFC 4518 Section 2.6.1, the handling of insignificant space differs
+between attribute values (and non-substring assertion values) and each
+of the components of a substring assertion value.
+*/
+const (
+	ValuePrep   uint = iota // attribute values and non-substring assertion values
+	InitialPrep             // initial substring assertion components
+	AnyPrep                 // any substring assertion components
+	FinalPrep               // final substring assertion components
+)
+
+/*
+rfc4518Preparers maps the numeric OIDs of RFC 4517 matching rules to the
+[StringPreparer] appropriate for each.
+*/
+var rfc4518Preparers map[string]StringPreparer = map[string]StringPreparer{
+	`2.5.13.2`:                   CaseIgnorePrep,
+	`2.5.13.3`:                   CaseIgnorePrep,
+	`2.5.13.4`:                   CaseIgnorePrep,
+	`2.5.13.5`:                   CaseExactPrep,
+	`2.5.13.6`:                   CaseExactPrep,
+	`2.5.13.7`:                   CaseExactPrep,
+	`2.5.13.8`:                   NumericStringPrep,
+	`2.5.13.9`:                   NumericStringPrep,
+	`2.5.13.10`:                  NumericStringPrep,
+	`2.5.13.11`:                  CaseIgnorePrep,
+	`2.5.13.12`:                  CaseIgnorePrep,
+	`2.5.13.20`:                  TelephoneNumberPrep,
+	`2.5.13.21`:                  TelephoneNumberPrep,
+	`2.5.13.31`:                  CaseIgnorePrep,
+	`2.5.13.32`:                  CaseIgnorePrep,
+	`2.5.13.33`:                  CaseIgnorePrep,
+	`1.3.6.1.4.1.1466.109.114.1`: CaseExactPrep,
+	`1.3.6.1.4.1.1466.109.114.2`: CaseIgnorePrep,
+	`1.3.6.1.4.1.1466.109.114.3`: CaseIgnorePrep,
+}
+
+/*
+RFC4518Preparer returns the [StringPreparer] appropriate for the RFC 4517
+matching rule identified by the numeric OID oid, or nil if the rule does
+not operate upon prepared strings.
+*/
+func RFC4518Preparer(oid string) StringPreparer {
+	return rfc4518Preparers[oid]
+}
+
+/*
+CaseIgnorePrep implements [StringPreparer] and returns value following
+RFC 4518 preparation with case folding and insignificant space handling,
+as used by caseIgnoreMatch and related rules.
+*/
+func CaseIgnorePrep(value string, kind uint) (string, error) {
+	return prepareString(value, kind, true, insignificantSpace)
+}
+
+/*
+CaseExactPrep implements [StringPreparer] and returns value following
+RFC 4518 preparation with insignificant space handling, as used by
+caseExactMatch and related rules.
+*/
+func CaseExactPrep(value string, kind uint) (string, error) {
+	return prepareString(value, kind, false, insignificantSpace)
+}
+
+/*
+NumericStringPrep implements [StringPreparer] and returns value following
+RFC 4518 preparation with the removal of all spaces, as used by
+numericStringMatch and related rules.
+*/
+func NumericStringPrep(value string, kind uint) (string, error) {
+	return prepareString(value, kind, false, func(s string, _ uint) string {
+		return removeInsignificant(s, func(c rune) bool { return c == ' ' })
+	})
+}
+
+/*
+TelephoneNumberPrep implements [StringPreparer] and returns value following
+RFC 4518 preparation with the removal of all spaces and hyphens, as used by
+telephoneNumberMatch and related rules.
+*/
+func TelephoneNumberPrep(value string, kind uint) (string, error) {
+	return prepareString(value, kind, false, func(s string, _ uint) string {
+		return removeInsignificant(s, isTelephoneInsignificant)
+	})
+}
+
+/*
+prepareString returns s following the Transcode, Map, Normalize, Prohibit
+and Insignificant Character Handling steps of RFC 4518 Section 2.  Case
+folding is performed if fold is true.  The Check bidi step is not
+performed, as bidirectional characters are ignored per RFC 4518.
+*/
+func prepareString(s string, kind uint, fold bool,
+	handle func(string, uint) string) (string, error) {
+
+	// Transcode (Go strings are presumed to be UTF-8)
+	if !utf8.ValidString(s) {
+		return ``, syntaxErr(`invalid UTF-8`)
+	}
+
+	// Map
+	b := newStringBuilder()
+	for _, c := range s {
+		if m, ok := mapRune(c); ok {
+			b.WriteRune(m)
+		}
+	}
+
+	// Normalize (and case fold, if requested)
+	s = norm.NFKC.String(b.String())
+	if fold {
+		s = norm.NFKC.String(cases.Fold().String(s))
+	}
+
+	// Prohibit
+	for _, c := range s {
+		if isProhibitedRune(c) {
+			return ``, syntaxErr(`prohibited character U+` +
+				uc(fmtInt(int64(c), 16)))
+		}
+	}
+
+	return handle(s, kind), nil
+}
+
+/*
+mapRune returns the mapping of c per RFC 4518 Section 2.2, alongside
+a Boolean value which is false should c be mapped to nothing.
+*/
+func mapRune(c rune) (rune, bool) {
+	switch c {
+	case 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x85:
+		return ' ', true
+	case 0xAD, 0x34F, 0x1806, 0x180B, 0x180C, 0x180D, 0x200B, 0x2060, 0xFEFF:
+		return c, false
+	}
+
+	switch {
+	case 0xFE00 <= c && c <= 0xFE0F:
+		// variation selectors
+		return c, false
+	case unicode.In(c, unicode.Cc, unicode.Cf):
+		return c, false
+	case unicode.In(c, unicode.Zs, unicode.Zl, unicode.Zp):
+		return ' ', true
+	}
+
+	return c, true
+}
+
+/*
+isProhibitedRune returns a Boolean value indicative of c being prohibited
+per RFC 4518 Section 2.4.  This includes unassigned code points, private
+use code points, non-character code points and the REPLACEMENT CHARACTER.
+*/
+func isProhibitedRune(c rune) bool {
+	switch {
+	case c == utf8.RuneError,
+		0xFDD0 <= c && c <= 0xFDEF,
+		c&0xFFFE == 0xFFFE,
+		unicode.Is(unicode.Co, c):
+		return true
+	}
+
+	return !unicode.In(c, unicode.L, unicode.M, unicode.N,
+		unicode.P, unicode.S, unicode.Z, unicode.Cc, unicode.Cf)
+}
+
+/*
+isInsignificantSpace returns a Boolean value indicative of the rune at
+index i within runes being a SPACE followed by no combining marks, per
+RFC 4518 Section 2.6.1.
+*/
+func isInsignificantSpace(runes []rune, i int) bool {
+	return runes[i] == ' ' && (i+1 == len(runes) || !unicode.Is(unicode.M, runes[i+1]))
+}
+
+/*
+insignificantSpace returns s following the insignificant space handling
+procedure of RFC 4518 Section 2.6.1.
+*/
+func insignificantSpace(s string, kind uint) string {
+	runes := []rune(s)
+
+	var words []string
+	var word []rune
+	for i := range runes {
+		if isInsignificantSpace(runes, i) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+		word = append(word, runes[i])
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	if len(words) == 0 {
+		if kind == ValuePrep {
+			return `  `
+		}
+		return ` `
+	}
+
+	out := join(words, `  `)
+	if kind == ValuePrep || kind == InitialPrep ||
+		(len(runes) > 0 && isInsignificantSpace(runes, 0)) {
+		out = ` ` + out
+	}
+	if kind == ValuePrep || kind == FinalPrep || hasSfx(s, ` `) {
+		out += ` `
+	}
+
+	return out
+}
+
+/*
+removeInsignificant returns s less all characters, not followed by a
+combining mark, for which remove returns true.
+*/
+func removeInsignificant(s string, remove func(rune) bool) string {
+	runes := []rune(s)
+
+	b := newStringBuilder()
+	for i, c := range runes {
+		if !remove(c) || (i+1 < len(runes) && unicode.Is(unicode.M, runes[i+1])) {
+			b.WriteRune(c)
+		}
+	}
+
+	return b.String()
+}
+
+// RFC 4518 Section 2.6.3
+func isTelephoneInsignificant(c rune) bool {
+	switch c {
+	case ' ', 0x2D, 0x58A, 0x2010, 0x2011, 0x2212, 0xFE63, 0xFF0D:
+		return true
+	}
+
+	return false
+}
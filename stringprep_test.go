@@ -0,0 +1,148 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the RFC 4518 preparation of a string value
+for use in caseIgnoreMatch assertions.
+*/
+func ExampleCaseIgnorePrep() {
+	prepared, err := CaseIgnorePrep("  \uFF2Aesse  CORETTA\u200B ", ValuePrep)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%q\n", prepared)
+	// Output: " jesse  coretta "
+}
+
+/*
+This example demonstrates the selection of an alternative [StringPreparer]
+for a [MatchingRule], which is honored by the built-in [AssertionMatcher]
+assigned through the [AssertionMatchers] option.
+*/
+func ExampleMatchingRule_SetStringPreparer() {
+	sch := NewSchema(AssertionMatchers)
+
+	cn := sch.AttributeTypes().Get(`cn`)
+	fmt.Println(cn.EqualityAssertion(`Jesse`, `JESSE`))
+
+	cn.EffectiveEquality().SetStringPreparer(CaseExactPrep)
+	fmt.Println(cn.EqualityAssertion(`Jesse`, `JESSE`))
+	// Output:
+	// <nil>
+	// Values do not match according to prescribed assertion match
+}
+
+func TestStringPreparers(t *testing.T) {
+	for idx, tc := range []struct {
+		prep StringPreparer
+		in   string
+		kind uint
+		want string
+	}{
+		{CaseIgnorePrep, `   `, ValuePrep, `  `},
+		{CaseIgnorePrep, `   `, InitialPrep, ` `},
+		{CaseIgnorePrep, ` a  b `, InitialPrep, ` a  b `},
+		{CaseIgnorePrep, `a b`, InitialPrep, ` a  b`},
+		{CaseIgnorePrep, `a b`, AnyPrep, `a  b`},
+		{CaseIgnorePrep, ` a`, AnyPrep, ` a`},
+		{CaseIgnorePrep, `a`, FinalPrep, `a `},
+		{CaseIgnorePrep, "a\tb\r\nc", ValuePrep, ` a  b  c `},
+		{CaseIgnorePrep, "Stra\u00DFe", ValuePrep, ` strasse `},
+		{CaseIgnorePrep, "\u212B", ValuePrep, " \u00E5 "},
+		{CaseIgnorePrep, "\uFB01le", ValuePrep, ` file `},
+		{CaseIgnorePrep, "a\u00ADb\u034Fc\uFE0Fd", ValuePrep, ` abcd `},
+		{CaseIgnorePrep, "a\u00A0b", ValuePrep, ` a  b `},
+		{CaseIgnorePrep, "a \u0301b", ValuePrep, " a \u0301b "},
+		{CaseExactPrep, `Jesse  Coretta`, ValuePrep, ` Jesse  Coretta `},
+		{CaseExactPrep, "e\u0301", ValuePrep, " \u00E9 "},
+		{NumericStringPrep, ` 15 079 672 281 `, ValuePrep, `15079672281`},
+		{NumericStringPrep, "\uFF11\uFF15", ValuePrep, `15`},
+		{TelephoneNumberPrep, `+1 512-315-0280`, ValuePrep, `+15123150280`},
+		{TelephoneNumberPrep, "+1\u2011512\uFF0D315", ValuePrep, `+1512315`},
+	} {
+		got, err := tc.prep(tc.in, tc.kind)
+		if err != nil {
+			t.Errorf("%s[%d] failed: %v", t.Name(), idx, err)
+		} else if got != tc.want {
+			t.Errorf("%s[%d] failed: want %q, got %q", t.Name(), idx, tc.want, got)
+		}
+	}
+
+	for idx, in := range []string{
+		string([]byte{0xff}),
+		"private\uE000use",
+		"non\uFDD0char",
+		"non\uFFFFchar",
+		"bad\uFFFDchar",
+		"unassigned\U000e0080",
+	} {
+		if _, err := CaseIgnorePrep(in, ValuePrep); !errors.Is(err, ErrInvalidSyntax) {
+			t.Errorf("%s[%d] failed: expected %v, got %v", t.Name(), idx, ErrInvalidSyntax, err)
+		}
+	}
+}
+
+func TestStringPreparer_codecov(t *testing.T) {
+	if prep := RFC4518Preparer(`2.5.13.0`); prep != nil {
+		t.Errorf("%s failed: unexpected preparer", t.Name())
+	}
+	if prep := RFC4518Preparer(`2.5.13.2`); prep == nil {
+		t.Errorf("%s failed: missing preparer", t.Name())
+	}
+
+	var mr MatchingRule
+	if _, err := mr.Prepare(`x`, ValuePrep); !errors.Is(err, ErrNilReceiver) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilReceiver, err)
+	}
+	mr.SetStringPreparer(CaseIgnorePrep)
+
+	sch := NewSchema(AssertionMatchers)
+	for _, name := range []string{
+		`caseIgnoreMatch`,
+		`caseIgnoreSubstringsMatch`,
+		`caseIgnoreListMatch`,
+		`caseIgnoreListSubstringsMatch`,
+		`wordMatch`,
+		`directoryStringFirstComponentMatch`,
+	} {
+		mr = sch.MatchingRules().Get(name)
+		if _, err := mr.Prepare(`x`, ValuePrep); err != nil {
+			t.Errorf("%s[%s] failed: %v", t.Name(), name, err)
+		}
+
+		mr.SetStringPreparer(nil)
+		if _, err := mr.Prepare(`x`, ValuePrep); !errors.Is(err, ErrNilStringPreparer) {
+			t.Errorf("%s[%s] failed: expected %v, got %v", t.Name(), name, ErrNilStringPreparer, err)
+		}
+		if err := mr.Assertion(`( x )`, `x*`); !errors.Is(err, ErrNilStringPreparer) &&
+			!errors.Is(err, ErrInvalidSyntax) {
+			t.Errorf("%s[%s] failed: expected %v, got %v", t.Name(), name, ErrNilStringPreparer, err)
+		}
+
+		failing := func(string, uint) (string, error) { return ``, ErrInvalidSyntax }
+		mr.SetStringPreparer(failing)
+		if err := mr.Assertion(`( x )`, `x*`); !errors.Is(err, ErrInvalidSyntax) {
+			t.Errorf("%s[%s] failed: expected %v, got %v", t.Name(), name, ErrInvalidSyntax, err)
+		}
+	}
+
+	// unprepared substring components must not match
+	prep := func(s string, kind uint) (string, error) {
+		if kind == ValuePrep {
+			return s, nil
+		}
+		return ``, ErrInvalidSyntax
+	}
+	for _, sub := range []string{`a*`, `*a*`, `*a`} {
+		if _, err := matchSubstrings(`abc`, sub, prep); !errors.Is(err, ErrInvalidSyntax) {
+			t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrInvalidSyntax, err)
+		}
+	}
+}
@@ -0,0 +1,850 @@
+package schemax
+
+/*
+syntax.go contains the built-in SyntaxQualifier implementations for the
+syntaxes defined within RFC 4517 Section 3.3.
+*/
+
+import "unicode/utf8"
+
+/*
+rfc4517SyntaxQualifiers maps the numeric OIDs of RFC 4517 syntaxes to
+their respective [SyntaxQualifier] implementations.
+*/
+var rfc4517SyntaxQualifiers map[string]SyntaxQualifier
+
+func init() {
+	const pfx string = `1.3.6.1.4.1.1466.115.121.1.`
+
+	rfc4517SyntaxQualifiers = map[string]SyntaxQualifier{
+		pfx + `3`:  qualifySchemaDescription(func(s string) (err error) { _, err = parseAT(s); return }),
+		pfx + `6`:  stringQualifier(checkBitString),
+		pfx + `7`:  stringQualifier(checkBoolean),
+		pfx + `11`: stringQualifier(checkCountryString),
+		pfx + `12`: stringQualifier(checkDN),
+		pfx + `14`: stringQualifier(checkDeliveryMethod),
+		pfx + `15`: stringQualifier(checkDirectoryString),
+		pfx + `16`: qualifySchemaDescription(func(s string) (err error) { _, err = parseDC(s); return }),
+		pfx + `17`: qualifySchemaDescription(func(s string) (err error) { _, err = parseDS(s); return }),
+		pfx + `21`: stringQualifier(checkEnhancedGuide),
+		pfx + `22`: stringQualifier(checkFacsimileTelephoneNumber),
+		pfx + `23`: qualifyOctets,
+		pfx + `24`: stringQualifier(checkGeneralizedTime),
+		pfx + `25`: stringQualifier(checkGuide),
+		pfx + `26`: stringQualifier(checkIA5String),
+		pfx + `27`: stringQualifier(checkInteger),
+		pfx + `28`: qualifyJPEG,
+		pfx + `30`: qualifySchemaDescription(func(s string) (err error) { _, err = parseMR(s); return }),
+		pfx + `31`: qualifySchemaDescription(func(s string) (err error) { _, err = parseMU(s); return }),
+		pfx + `34`: stringQualifier(checkNameAndOptionalUID),
+		pfx + `35`: qualifySchemaDescription(func(s string) (err error) { _, err = parseNF(s); return }),
+		pfx + `36`: stringQualifier(checkNumericString),
+		pfx + `37`: qualifySchemaDescription(func(s string) (err error) { _, err = parseOC(s); return }),
+		pfx + `38`: stringQualifier(checkOID),
+		pfx + `39`: stringQualifier(checkOtherMailbox),
+		pfx + `40`: qualifyOctets,
+		pfx + `41`: stringQualifier(checkPostalAddress),
+		pfx + `44`: stringQualifier(checkPrintableString),
+		pfx + `50`: stringQualifier(checkTelephoneNumber),
+		pfx + `51`: stringQualifier(checkTeletexTerminalIdentifier),
+		pfx + `52`: stringQualifier(checkTelexNumber),
+		pfx + `53`: stringQualifier(checkUTCTime),
+		pfx + `54`: qualifySchemaDescription(func(s string) (err error) { _, err = parseLS(s); return }),
+		pfx + `58`: stringQualifier(checkSubstringAssertion),
+	}
+}
+
+/*
+RFC4517SyntaxQualifier returns the built-in [SyntaxQualifier] for the RFC
+4517 syntax identified by the numeric OID oid, or nil if no such qualifier
+exists.
+
+Qualifiers accept string and []byte values.  A nil error is returned if
+the value conforms to the ABNF production of the syntax in question, per
+RFC 4517 Section 3.3.  Otherwise, an [ErrInvalidSyntax] error is returned.
+
+Qualifiers are provided for the following syntaxes:
+
+  - Attribute Type Description, DIT Content Rule Description, DIT Structure Rule Description, LDAP Syntax Description, Matching Rule Description, Matching Rule Use Description, Name Form Description and Object Class Description
+  - Bit String, Boolean, Country String, Delivery Method, Directory String, IA5 String, INTEGER, Numeric String, OID, Printable String and Telephone Number
+  - DN and Name And Optional UID
+  - Enhanced Guide and Guide
+  - Facsimile Telephone Number, Fax, JPEG and Octet String
+  - Generalized Time and UTC Time
+  - Other Mailbox, Postal Address, Teletex Terminal Identifier and Telex Number
+  - Substring Assertion
+
+See also the [SyntaxQualifiers] option, which assigns these qualifiers
+automatically.
+*/
+func RFC4517SyntaxQualifier(oid string) SyntaxQualifier {
+	return rfc4517SyntaxQualifiers[oid]
+}
+
+/*
+assignRFC4517SyntaxQualifiers assigns the built-in RFC 4517 qualifiers to
+each applicable [LDAPSyntax] within the receiver instance which does not
+already bear a qualifier.
+*/
+func (r Schema) assignRFC4517SyntaxQualifiers() {
+	syns := r.LDAPSyntaxes()
+	for i := 0; i < syns.Len(); i++ {
+		ls := syns.Index(i)
+		if ls.lDAPSyntax.synQual != nil {
+			continue
+		}
+		if funk, found := rfc4517SyntaxQualifiers[ls.NumericOID()]; found {
+			ls.SetSyntaxQualifier(funk)
+		}
+	}
+}
+
+/*
+assertSyntaxValue returns the string form of x, which must be a string
+or []byte instance.
+*/
+func assertSyntaxValue(x any) (s string, err error) {
+	switch tv := x.(type) {
+	case string:
+		s = tv
+	case []byte:
+		s = string(tv)
+	default:
+		err = ErrInvalidType
+	}
+
+	return
+}
+
+/*
+stringQualifier returns a [SyntaxQualifier] which submits the string form
+of its input value to check.
+*/
+func stringQualifier(check func(string) error) SyntaxQualifier {
+	return func(x any) (err error) {
+		var s string
+		if s, err = assertSyntaxValue(x); err == nil {
+			err = check(s)
+		}
+
+		return
+	}
+}
+
+/*
+qualifySchemaDescription returns a [SyntaxQualifier] which submits the
+string form of its input value to the ANTLR parse function provided.
+*/
+func qualifySchemaDescription(parse func(string) error) SyntaxQualifier {
+	return stringQualifier(func(s string) (err error) {
+		if err = parse(s); err != nil {
+			err = wraperr(ErrInvalidSyntax, `: `+err.Error())
+		}
+		return
+	})
+}
+
+func syntaxErr(reason string) error {
+	return wraperr(ErrInvalidSyntax, `: `+reason)
+}
+
+/*
+qualifyOctets implements the Octet String and Fax syntaxes, which permit
+any non-zero sequence of octets.
+*/
+func qualifyOctets(x any) (err error) {
+	var s string
+	if s, err = assertSyntaxValue(x); err == nil && len(s) == 0 {
+		err = syntaxErr(`empty value`)
+	}
+
+	return
+}
+
+/*
+qualifyJPEG implements the JPEG syntax, which requires a JPEG File
+Interchange Format (JFIF) value, as identified by its leading Start
+Of Image (SOI) marker.
+*/
+func qualifyJPEG(x any) (err error) {
+	var s string
+	if s, err = assertSyntaxValue(x); err == nil {
+		if len(s) < 3 || s[0] != 0xFF || s[1] != 0xD8 || s[2] != 0xFF {
+			err = syntaxErr(`missing JPEG SOI marker`)
+		}
+	}
+
+	return
+}
+
+// BitString = SQUOTE *binary-digit SQUOTE "B"
+func checkBitString(s string) error {
+	if len(s) < 3 || s[0] != '\'' || !hasSfx(s, `'B`) {
+		return syntaxErr(`invalid bit string`)
+	}
+
+	for i := 1; i < len(s)-2; i++ {
+		if s[i] != '0' && s[i] != '1' {
+			return syntaxErr(`invalid binary digit`)
+		}
+	}
+
+	return nil
+}
+
+// Boolean = "TRUE" / "FALSE"
+func checkBoolean(s string) error {
+	if s != `TRUE` && s != `FALSE` {
+		return syntaxErr(`boolean must be TRUE or FALSE`)
+	}
+
+	return nil
+}
+
+// CountryString = 2(PrintableCharacter)
+func checkCountryString(s string) error {
+	if len(s) != 2 {
+		return syntaxErr(`country string must be two characters`)
+	}
+
+	return checkPrintableString(s)
+}
+
+// DeliveryMethod = pdm *( WSP DOLLAR WSP pdm )
+func checkDeliveryMethod(s string) error {
+	for _, pdm := range split(s, `$`) {
+		if !strInSlice(trimS(pdm), []string{`any`, `mhs`, `physical`, `telex`,
+			`teletex`, `g3fax`, `g4fax`, `ia5`, `videotex`, `telephone`}) {
+			return syntaxErr(`invalid delivery method '` + trimS(pdm) + `'`)
+		}
+	}
+
+	return nil
+}
+
+// DirectoryString = 1*UTF8
+func checkDirectoryString(s string) error {
+	if len(s) == 0 {
+		return syntaxErr(`empty value`)
+	} else if !utf8.ValidString(s) {
+		return syntaxErr(`invalid UTF-8`)
+	}
+
+	return nil
+}
+
+// IA5String = *(%x00-7F)
+func checkIA5String(s string) error {
+	for i := 0; i < len(s); i++ {
+		if s[i] > 0x7F {
+			return syntaxErr(`non-IA5 character`)
+		}
+	}
+
+	return nil
+}
+
+// Integer = ( HYPHEN LDIGIT *DIGIT ) / number
+func checkInteger(s string) error {
+	d := trimL(s, `-`)
+	if len(s)-len(d) > 1 || !isDigits(d) ||
+		(len(d) > 1 && d[0] == '0') || (s != d && d == `0`) {
+		return syntaxErr(`invalid integer`)
+	}
+
+	return nil
+}
+
+// NumericString = 1*(DIGIT / SPACE)
+func checkNumericString(s string) error {
+	if len(s) == 0 {
+		return syntaxErr(`empty value`)
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !('0' <= s[i] && s[i] <= '9') && s[i] != ' ' {
+			return syntaxErr(`invalid numeric string`)
+		}
+	}
+
+	return nil
+}
+
+// oid = descr / numericoid
+func checkOID(s string) error {
+	if !isNumericOID(s) && !isDescriptor(s) {
+		return syntaxErr(`invalid OID or descriptor`)
+	}
+
+	return nil
+}
+
+// PrintableString = 1*PrintableCharacter
+func checkPrintableString(s string) error {
+	if len(s) == 0 {
+		return syntaxErr(`empty value`)
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !isPrintableChar(s[i]) {
+			return syntaxErr(`non-printable character`)
+		}
+	}
+
+	return nil
+}
+
+func isPrintableChar(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') ||
+		('0' <= c && c <= '9') || stridx(`'()+,-./:=? `, string(c)) != -1
+}
+
+// TelephoneNumber = PrintableString
+func checkTelephoneNumber(s string) error {
+	return checkPrintableString(s)
+}
+
+// fax-number = telephone-number *( DOLLAR fax-parameter )
+func checkFacsimileTelephoneNumber(s string) error {
+	parts := split(s, `$`)
+	if err := checkTelephoneNumber(parts[0]); err != nil {
+		return err
+	}
+
+	for _, param := range parts[1:] {
+		if !strInSlice(param, []string{`twoDimensional`, `fineResolution`,
+			`unlimitedLength`, `b4Length`, `a3Width`, `b4Width`, `uncompressed`}) {
+			return syntaxErr(`invalid fax parameter '` + param + `'`)
+		}
+	}
+
+	return nil
+}
+
+// telex-number = actual-number DOLLAR country-code DOLLAR answerback
+func checkTelexNumber(s string) error {
+	parts := split(s, `$`)
+	if len(parts) != 3 {
+		return syntaxErr(`telex number requires three components`)
+	}
+
+	for _, part := range parts {
+		if err := checkPrintableString(part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// teletex-id = ttx-term *( DOLLAR ttx-param )
+func checkTeletexTerminalIdentifier(s string) error {
+	parts := split(s, `$`)
+	if err := checkPrintableString(parts[0]); err != nil {
+		return err
+	}
+
+	for _, param := range parts[1:] {
+		idx := stridx(param, `:`)
+		if idx == -1 || !strInSlice(param[:idx], []string{`graphic`,
+			`control`, `misc`, `page`, `private`}) {
+			return syntaxErr(`invalid teletex parameter '` + param + `'`)
+		} else if err := checkEscapedOctets(param[idx+1:], `$\`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PostalAddress = line *( DOLLAR line )
+func checkPostalAddress(s string) error {
+	for _, line := range split(s, `$`) {
+		if len(line) == 0 {
+			return syntaxErr(`empty postal address line`)
+		} else if err := checkEscapedOctets(line, `$\`); err != nil {
+			return err
+		} else if !utf8.ValidString(line) {
+			return syntaxErr(`invalid UTF-8`)
+		}
+	}
+
+	return nil
+}
+
+// mailbox-type DOLLAR mailbox
+func checkOtherMailbox(s string) error {
+	idx := stridx(s, `$`)
+	if idx == -1 || checkPrintableString(s[:idx]) != nil {
+		return syntaxErr(`invalid mailbox type`)
+	} else if len(s[idx+1:]) == 0 {
+		return syntaxErr(`empty mailbox`)
+	}
+
+	return checkIA5String(s[idx+1:])
+}
+
+/*
+checkEscapedOctets returns an error should s contain any of the characters
+within special in unescaped form, or any backslash not followed by the
+hexadecimal encoding of one such character.
+*/
+func checkEscapedOctets(s, special string) error {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			if i+2 >= len(s) {
+				return syntaxErr(`incomplete escape sequence`)
+			}
+			if b := hexByte(s[i+1])<<4 | hexByte(s[i+2]); !isHexByte(s[i+1]) ||
+				!isHexByte(s[i+2]) || stridx(special, string(rune(b))) == -1 {
+				return syntaxErr(`invalid escape sequence`)
+			}
+			i += 2
+		} else if stridx(special, string(s[i])) != -1 {
+			return syntaxErr(`unescaped '` + string(s[i]) + `'`)
+		}
+	}
+
+	return nil
+}
+
+// keystring = leadkeychar *keychar
+func isKeystring(s string) bool {
+	if len(s) == 0 || !isAlpha(rune(s[0])) {
+		return false
+	}
+
+	for i := 1; i < len(s); i++ {
+		if !isAlnum(rune(s[i])) && s[i] != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigits(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !('0' <= s[i] && s[i] <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+checkDigitRange returns a Boolean value indicative of s consisting of
+exactly two (2) digits within the range lo through hi, inclusive.
+*/
+func checkDigitRange(s string, lo, hi int) bool {
+	if len(s) != 2 || !isDigits(s) {
+		return false
+	}
+
+	n, _ := atoi(s)
+	return lo <= n && n <= hi
+}
+
+/*
+GeneralizedTime = century year month day hour
+[ minute [ second / leap-second ] ]
+[ fraction ]
+g-time-zone
+*/
+func checkGeneralizedTime(s string) error {
+	if len(s) < 11 || !isDigits(s[:10]) ||
+		!checkDigitRange(s[4:6], 1, 12) ||
+		!checkDigitRange(s[6:8], 1, 31) ||
+		!checkDigitRange(s[8:10], 0, 23) {
+		return syntaxErr(`invalid generalized time`)
+	}
+
+	rest := s[10:]
+	if len(rest) >= 2 && isDigits(rest[:2]) {
+		if !checkDigitRange(rest[:2], 0, 59) {
+			return syntaxErr(`invalid minute`)
+		}
+		rest = rest[2:]
+		if len(rest) >= 2 && isDigits(rest[:2]) {
+			if !checkDigitRange(rest[:2], 0, 60) {
+				return syntaxErr(`invalid second`)
+			}
+			rest = rest[2:]
+		}
+	}
+
+	if len(rest) > 0 && (rest[0] == '.' || rest[0] == ',') {
+		var n int
+		for n = 1; n < len(rest) && '0' <= rest[n] && rest[n] <= '9'; n++ {
+		}
+		if n == 1 {
+			return syntaxErr(`invalid fraction`)
+		}
+		rest = rest[n:]
+	}
+
+	return checkTimeZone(rest, true)
+}
+
+/*
+UTCTime = year month day hour minute [ second ] [ u-time-zone ]
+*/
+func checkUTCTime(s string) error {
+	if len(s) < 10 || !isDigits(s[:10]) ||
+		!checkDigitRange(s[2:4], 1, 12) ||
+		!checkDigitRange(s[4:6], 1, 31) ||
+		!checkDigitRange(s[6:8], 0, 23) ||
+		!checkDigitRange(s[8:10], 0, 59) {
+		return syntaxErr(`invalid UTC time`)
+	}
+
+	rest := s[10:]
+	if len(rest) >= 2 && isDigits(rest[:2]) {
+		if !checkDigitRange(rest[:2], 0, 59) {
+			return syntaxErr(`invalid second`)
+		}
+		rest = rest[2:]
+	}
+
+	if len(rest) == 0 {
+		return nil
+	}
+
+	return checkTimeZone(rest, false)
+}
+
+/*
+checkTimeZone returns an error should s not be "Z", nor a differential
+of the form (+|-)HHMM.  Generalized Time differentials may also be of
+the form (+|-)HH.
+*/
+func checkTimeZone(s string, generalized bool) error {
+	switch {
+	case s == `Z`:
+		return nil
+	case len(s) == 5 && (s[0] == '+' || s[0] == '-'):
+		if checkDigitRange(s[1:3], 0, 23) && checkDigitRange(s[3:5], 0, 59) {
+			return nil
+		}
+	case generalized && len(s) == 3 && (s[0] == '+' || s[0] == '-'):
+		if checkDigitRange(s[1:3], 0, 23) {
+			return nil
+		}
+	}
+
+	return syntaxErr(`invalid time zone`)
+}
+
+/*
+substring-assertion = [ initial ] any [ final ]
+any = ASTERISK *( substring ASTERISK )
+*/
+func checkSubstringAssertion(s string) error {
+	if err := checkEscapedSubstrings(s); err != nil {
+		return err
+	}
+
+	parts := split(s, `*`)
+	if len(parts) < 2 {
+		return syntaxErr(`substring assertion requires an asterisk`)
+	}
+
+	for i := 1; i < len(parts)-1; i++ {
+		if len(parts[i]) == 0 {
+			return syntaxErr(`empty substring`)
+		}
+	}
+
+	return nil
+}
+
+/*
+checkEscapedSubstrings returns an error should s contain a backslash
+which is not followed by "2A" or "5C" (in any case).
+*/
+func checkEscapedSubstrings(s string) error {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			if i+2 >= len(s) || !strInSlice(uc(s[i+1:i+3]), []string{`2A`, `5C`}) {
+				return syntaxErr(`invalid escape sequence`)
+			}
+			i += 2
+		}
+	}
+
+	return nil
+}
+
+// NameAndOptionalUID = distinguishedName [ SHARP BitString ]
+func checkNameAndOptionalUID(s string) error {
+	if idx := lastIndexByte(s, '#'); idx != -1 && hasSfx(s, `'B`) {
+		if err := checkBitString(s[idx+1:]); err == nil {
+			s = s[:idx]
+		}
+	}
+
+	return checkDN(s)
+}
+
+func lastIndexByte(s string, c byte) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == c {
+			return i
+		}
+	}
+
+	return -1
+}
+
+/*
+checkDN returns an error should s not be an RFC 4514 distinguishedName.
+A zero length DN is valid.
+*/
+func checkDN(s string) (err error) {
+	_, err = splitDN(s)
+	return
+}
+
+/*
+splitDN returns the RDNs of the RFC 4514 distinguishedName s, alongside
+an error.  Each RDN is returned as a slice of attribute type and value
+pairs, the latter of which are returned in their original (escaped) form.
+A zero length DN returns no RDNs.
+*/
+func splitDN(s string) (rdns [][][2]string, err error) {
+	if len(s) == 0 {
+		return
+	}
+
+	var rdn [][2]string
+	for i := 0; i <= len(s); {
+		var ava [2]string
+		if ava, i, err = checkAVA(s, i); err != nil {
+			return
+		}
+		rdn = append(rdn, ava)
+
+		if i == len(s) {
+			break
+		} else if s[i] != ',' && s[i] != '+' {
+			err = syntaxErr(`expected ',' or '+' within DN`)
+			return
+		} else if s[i] == ',' {
+			rdns = append(rdns, rdn)
+			rdn = nil
+		}
+		i++
+	}
+	rdns = append(rdns, rdn)
+
+	return
+}
+
+/*
+checkAVA returns the attributeTypeAndValue which begins at index i within
+s, alongside the index following it and an error.
+*/
+func checkAVA(s string, i int) ([2]string, int, error) {
+	var ava [2]string
+
+	eq := i
+	for eq < len(s) && s[eq] != '=' {
+		eq++
+	}
+
+	if eq == len(s) {
+		return ava, eq, syntaxErr(`missing '=' within DN`)
+	} else if ava[0] = s[i:eq]; !isNumericOID(ava[0]) && !isDescriptor(ava[0]) {
+		return ava, eq, syntaxErr(`invalid attribute type '` + ava[0] + `' within DN`)
+	}
+
+	i = eq + 1
+	if i < len(s) && s[i] == '#' {
+		// hexstring = SHARP 1*hexpair
+		j := i + 1
+		for j+1 < len(s) && isHexByte(s[j]) && isHexByte(s[j+1]) {
+			j += 2
+		}
+		if j == i+1 || (j < len(s) && s[j] != ',' && s[j] != '+') {
+			return ava, j, syntaxErr(`invalid hexstring within DN`)
+		}
+		ava[1] = s[i:j]
+		return ava, j, nil
+	}
+
+	start := i
+	for i < len(s) && s[i] != ',' && s[i] != '+' {
+		switch c := s[i]; {
+		case c == '\\':
+			if i+1 >= len(s) {
+				return ava, i, syntaxErr(`incomplete escape within DN`)
+			} else if stridx(` "#+,;<=>\`, string(s[i+1])) != -1 {
+				i += 2
+			} else if i+2 < len(s) && isHexByte(s[i+1]) && isHexByte(s[i+2]) {
+				i += 3
+			} else {
+				return ava, i, syntaxErr(`invalid escape within DN`)
+			}
+			continue
+		case c == '"', c == ';', c == '<', c == '>', c == 0:
+			return ava, i, syntaxErr(`unescaped '` + string(c) + `' within DN`)
+		case c == '#' && i == start:
+			return ava, i, syntaxErr(`unescaped leading '#' within DN`)
+		case c == ' ' && i == start:
+			return ava, i, syntaxErr(`unescaped leading space within DN`)
+		}
+		i++
+	}
+
+	if i > start && s[i-1] == ' ' {
+		// the space is escaped only if preceded by an odd
+		// number of consecutive backslashes
+		var n int
+		for j := i - 2; j >= start && s[j] == '\\'; j-- {
+			n++
+		}
+		if n%2 == 0 {
+			return ava, i, syntaxErr(`unescaped trailing space within DN`)
+		}
+	}
+
+	ava[1] = s[start:i]
+
+	return ava, i, nil
+}
+
+/*
+guideParser implements recursive descent parsing of the criteria
+production shared by the Guide and Enhanced Guide syntaxes.
+*/
+type guideParser struct {
+	s string
+	i int
+}
+
+func (r *guideParser) skipWSP() {
+	for r.i < len(r.s) && r.s[r.i] == ' ' {
+		r.i++
+	}
+}
+
+func (r *guideParser) accept(c byte) bool {
+	r.skipWSP()
+	if r.i < len(r.s) && r.s[r.i] == c {
+		r.i++
+		return true
+	}
+
+	return false
+}
+
+// criteria = and-term *( BAR and-term )
+func (r *guideParser) criteria() (err error) {
+	if err = r.andTerm(); err == nil && r.accept('|') {
+		err = r.criteria()
+	}
+
+	return
+}
+
+// and-term = term *( AMPERSAND term )
+func (r *guideParser) andTerm() (err error) {
+	if err = r.term(); err == nil && r.accept('&') {
+		err = r.andTerm()
+	}
+
+	return
+}
+
+/*
+term = EXCLAIM term /
+attributetype DOLLAR match-type /
+LPAREN criteria RPAREN /
+true /
+false
+*/
+func (r *guideParser) term() (err error) {
+	switch {
+	case r.accept('!'):
+		return r.term()
+	case r.accept('('):
+		if err = r.criteria(); err == nil && !r.accept(')') {
+			err = syntaxErr(`missing ')' within criteria`)
+		}
+		return
+	}
+
+	r.skipWSP()
+	start := r.i
+	for r.i < len(r.s) && stridx(`$|&)( #`, string(r.s[r.i])) == -1 {
+		r.i++
+	}
+
+	word := r.s[start:r.i]
+	if word == `?true` || word == `?false` {
+		return
+	} else if !isNumericOID(word) && !isDescriptor(word) {
+		return syntaxErr(`invalid attribute type '` + word + `' within criteria`)
+	} else if !r.accept('$') {
+		return syntaxErr(`missing match type within criteria`)
+	}
+
+	start = r.i
+	for r.i < len(r.s) && isAlpha(rune(r.s[r.i])) {
+		r.i++
+	}
+
+	if !strInSlice(r.s[start:r.i], []string{`EQ`, `SUBSTR`, `GE`, `LE`, `APPROX`}) {
+		err = syntaxErr(`invalid match type '` + r.s[start:r.i] + `'`)
+	}
+
+	return
+}
+
+/*
+EnhancedGuide = object-class SHARP WSP criteria WSP
+
+	SHARP WSP subset
+
+subset = "baseobject" / "oneLevel" / "wholeSubtree"
+*/
+func checkEnhancedGuide(s string) error {
+	parts := split(s, `#`)
+	if len(parts) != 3 {
+		return syntaxErr(`enhanced guide requires three components`)
+	} else if err := checkOID(trimS(parts[0])); err != nil {
+		return err
+	} else if !strInSlice(trimS(parts[2]), []string{`baseobject`, `oneLevel`, `wholeSubtree`}) {
+		return syntaxErr(`invalid subset '` + trimS(parts[2]) + `'`)
+	}
+
+	return checkCriteria(parts[1])
+}
+
+// Guide = [ object-class SHARP ] criteria
+func checkGuide(s string) error {
+	if idx := stridx(s, `#`); idx != -1 {
+		if err := checkOID(trimS(s[:idx])); err != nil {
+			return err
+		}
+		s = s[idx+1:]
+	}
+
+	return checkCriteria(s)
+}
+
+func checkCriteria(s string) (err error) {
+	p := &guideParser{s: s}
+	if err = p.criteria(); err == nil {
+		if p.skipWSP(); p.i != len(p.s) {
+			err = syntaxErr(`unexpected content within criteria`)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,222 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+This example demonstrates the automatic assignment of the built-in RFC
+4517 syntax qualifiers through use of the [SyntaxQualifiers] option.
+*/
+func ExampleSyntaxQualifiers() {
+	sch := NewSchema(SyntaxQualifiers)
+
+	gt := sch.LDAPSyntaxes().Get(`1.3.6.1.4.1.1466.115.121.1.24`)
+	fmt.Println(gt.QualifySyntax(`20240229135501Z`))
+	fmt.Println(gt.QualifySyntax(`20241329135501Z`) != nil)
+	// Output:
+	// <nil>
+	// true
+}
+
+/*
+This example demonstrates the manual assignment of a built-in RFC 4517
+syntax qualifier to an [LDAPSyntax] instance.
+*/
+func ExampleRFC4517SyntaxQualifier() {
+	sch := NewSchema()
+
+	oid := `1.3.6.1.4.1.1466.115.121.1.27`
+	in := sch.LDAPSyntaxes().Get(oid)
+	in.SetSyntaxQualifier(RFC4517SyntaxQualifier(oid))
+
+	fmt.Println(in.QualifySyntax(`-42`))
+	// Output: <nil>
+}
+
+func TestRFC4517SyntaxQualifier(t *testing.T) {
+	for num, values := range map[string][2][]any{
+		`3`: {
+			{`( 2.5.4.3 NAME 'cn' SUP name )`},
+			{`( 2.5.4.3 NAME 'cn'`, `bogus`},
+		},
+		`6`: {
+			{`''B`, `'0101'B`, []byte(`'1'B`)},
+			{`0101`, `'0102'B`, `'01'b`},
+		},
+		`7`: {
+			{`TRUE`, `FALSE`},
+			{`true`, `yes`, ``},
+		},
+		`11`: {
+			{`US`, `DE`},
+			{`USA`, `U`, `U!`},
+		},
+		`12`: {
+			{``, `dc=example,dc=com`, `uid=jesse+gidNumber=5042,ou=People,dc=example,dc=com`,
+				`cn=Smith\, John,dc=com`, `cn=\23foo,dc=com`, `cn=foo\20,dc=com`,
+				`1.3.6.1.4.1.1466.0=#04024869`, `cn=L\C3\A9on`, `cn=foo\ `, `cn=foo\\\ `},
+			{`dc=example,`, `dc`, `=foo`, `dc=#zz`, `cn=#foo`, `cn= foo`, `cn=foo `, `cn=foo\\ `,
+				`cn=a"b`, `cn=\`, `cn=\zz`, `c_n=foo`},
+		},
+		`14`: {
+			{`any`, `telephone $ g3fax`},
+			{`carrier pigeon`, `any $`},
+		},
+		`15`: {
+			{`Jesse`, `Léon`},
+			{``, string([]byte{0xff, 0xfe})},
+		},
+		`21`: {
+			{`person#(sn$EQ)#oneLevel`, `organization # !(o$EQ | l$APPROX) & ?true # wholeSubtree`},
+			{`person#(sn$EQ)`, `person#sn$FOO#baseobject`, `person#(sn$EQ#baseobject`,
+				`person#sn$EQ#everywhere`, `!person#sn$EQ#baseobject`, `person#sn#baseobject`,
+				`person#sn$EQ )#baseobject`},
+		},
+		`22`: {
+			{`+1 555 555 1212`, `+1 555 555 1212$twoDimensional$fineResolution`},
+			{`+1 555 555 1212$threeDimensional`, `$twoDimensional`},
+		},
+		`23`: {
+			{[]byte{0x00, 0x01}},
+			{``, 42},
+		},
+		`24`: {
+			{`199412161032Z`, `199412160532-0500`, `20240229135501.5Z`, `2024022913Z`,
+				`2024022913+05`, `20240229135560,123Z`},
+			{`19941216103212`, `199413161032Z`, `199412161032.Z`, `199412161061Z`,
+				`19941216103261Z`, `199412161032+2500`, `1994121610Y`, `199412`},
+		},
+		`25`: {
+			{`sn$EQ`, `person#sn$EQ|cn$SUBSTR`, `?false`},
+			{`sn`, `1person#sn$EQ`, `(sn$EQ`},
+		},
+		`26`: {
+			{`jesse@example.com`, ``},
+			{`Léon`},
+		},
+		`27`: {
+			{`0`, `42`, `-42`},
+			{`-0`, `042`, `--1`, `-`, `4.2`, ``},
+		},
+		`28`: {
+			{[]byte{0xFF, 0xD8, 0xFF, 0xE0}},
+			{[]byte{0x89, 0x50, 0x4E, 0x47}, `JFIF`},
+		},
+		`34`: {
+			{`uid=jesse,dc=example,dc=com`, `uid=jesse,dc=example,dc=com#'0101'B`},
+			{`uid=jesse,d_c=com#'0101'B`, `uid=jesse,dc=example,`},
+		},
+		`36`: {
+			{`15 079 672 281`},
+			{`15-079`, ``},
+		},
+		`38`: {
+			{`2.5.4.3`, `cn`},
+			{`2.5.4.`, `1cn`},
+		},
+		`39`: {
+			{`smtp$jesse@example.com`, `1smtp$jesse@example.com`, `X.400 mail$jesse`},
+			{`smtp`, `$jesse@example.com`, `smtp_x$jesse@example.com`, `smtp$`, `smtp$Léon`},
+		},
+		`40`: {
+			{`octets`},
+			{``},
+		},
+		`41`: {
+			{`1234 Main St.$Anytown, CA 12345$USA`, `\241,000,000 Sweepstakes$PO Box 1000000$Anytown, CA 12345$USA`},
+			{`1234 Main St.$$USA`, `\41bc`, `\2`, `a\b`},
+		},
+		`44`: {
+			{`Jesse (Coretta)`},
+			{`jesse@example.com`, ``},
+		},
+		`50`: {
+			{`+1 512 315 0280`},
+			{`+1 512 315 0280 ext#1`},
+		},
+		`51`: {
+			{`term`, `term$graphic:foo$private:\24bar`},
+			{`term$colour:foo`, `term$page`, `term$misc:\5`, `@term`},
+		},
+		`52`: {
+			{`12345$023$ABCDE`},
+			{`12345$023`, `12345$$ABCDE`},
+		},
+		`53`: {
+			{`9412161032Z`, `9412161032`, `941216103250-0500`},
+			{`9413161032Z`, `941216103270Z`, `9412161032+05`, `94121610`},
+		},
+		`58`: {
+			{`*foo*`, `foo*`, `*bar`, `a*b*c`, `\2Afoo*`, `*\5c*`},
+			{`foo`, `a**b`, `foo\2B*`, `foo*\2`},
+		},
+	} {
+		oid := `1.3.6.1.4.1.1466.115.121.1.` + num
+		funk := RFC4517SyntaxQualifier(oid)
+		if funk == nil {
+			t.Errorf("%s failed: no qualifier for %s", t.Name(), oid)
+			continue
+		}
+
+		for _, value := range values[0] {
+			if err := funk(value); err != nil {
+				t.Errorf("%s[%s] failed: unexpected error for %q: %v", t.Name(), num, value, err)
+			}
+		}
+
+		for _, value := range values[1] {
+			if err := funk(value); err == nil {
+				t.Errorf("%s[%s] failed: expected error for %q, got nil", t.Name(), num, value)
+			} else if _, ok := value.(string); ok && !errors.Is(err, ErrInvalidSyntax) {
+				t.Errorf("%s[%s] failed: expected %v, got %v", t.Name(), num, ErrInvalidSyntax, err)
+			}
+		}
+	}
+}
+
+func TestSyntaxQualifiers_codecov(t *testing.T) {
+	if funk := RFC4517SyntaxQualifier(`1.3.6.1.4.1.56521.999.1`); funk != nil {
+		t.Errorf("%s failed: unexpected qualifier", t.Name())
+	}
+
+	for num, def := range map[string]string{
+		`16`: `( 2.5.6.4 NAME 'organization' AUX ( labeledURIObject ) )`,
+		`17`: `( 1 NAME 'domainStructureRule' FORM domainNameForm )`,
+		`30`: `( 2.5.13.2 NAME 'caseIgnoreMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`,
+		`31`: `( 2.5.13.2 APPLIES ( cn $ sn ) )`,
+		`35`: `( 1.3.6.1.4.1.56521.999.5 NAME 'fakeForm' OC domain MUST dc )`,
+		`37`: `( 2.5.6.6 NAME 'person' SUP top STRUCTURAL MUST ( sn $ cn ) )`,
+		`54`: `( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )`,
+	} {
+		funk := RFC4517SyntaxQualifier(`1.3.6.1.4.1.1466.115.121.1.` + num)
+		if err := funk(def); err != nil {
+			t.Errorf("%s[%s] failed: unexpected error: %v", t.Name(), num, err)
+		}
+		if err := funk(`( bogus`); !errors.Is(err, ErrInvalidSyntax) {
+			t.Errorf("%s[%s] failed: expected %v, got %v", t.Name(), num, ErrInvalidSyntax, err)
+		}
+	}
+
+	if err := RFC4517SyntaxQualifier(`1.3.6.1.4.1.1466.115.121.1.15`)(3.14); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrInvalidType, err)
+	}
+
+	// qualifiers must not be assigned without the option
+	sch := NewSchema()
+	if err := sch.LDAPSyntaxes().Get(`1.3.6.1.4.1.1466.115.121.1.7`).
+		QualifySyntax(`TRUE`); !errors.Is(err, ErrNilSyntaxQualifier) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrNilSyntaxQualifier, err)
+	}
+
+	// pre-existing qualifiers must not be overwritten
+	sch = NewSchema(SyntaxQualifiers)
+	bl := sch.LDAPSyntaxes().Get(`1.3.6.1.4.1.1466.115.121.1.7`)
+	bl.SetSyntaxQualifier(func(any) error { return nil })
+	sch.assignRFC4517SyntaxQualifiers()
+	if err := bl.QualifySyntax(`maybe`); err != nil {
+		t.Errorf("%s failed: qualifier was overwritten: %v", t.Name(), err)
+	}
+}
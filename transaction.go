@@ -0,0 +1,494 @@
+package schemax
+
+/*
+transaction.go contains the Transaction type, which stages changes to a
+Schema for validation and application as a single unit.
+*/
+
+/*
+Transaction operation kinds.
+*/
+const (
+	txPush uint8 = iota
+	txReplace
+	txRemove
+)
+
+/*
+Transaction implements a batch of staged changes to a [Schema], which
+are applied in their entirety or not at all.  Instances of this type are
+produced by way of the [Schema.Begin] method.
+
+Each change is staged upon a private draft of the [Schema], thus errors
+-- such as the push of a duplicate definition, or the removal of one which
+remains in use -- are returned immediately.  Definitions which reference
+staged definitions may be created by way of the draft returned by the
+[Transaction.Schema] method.
+
+No change is made to the [Schema] until [Transaction.Commit] is executed,
+at which point the staged changes are applied to a fresh copy of the
+[Schema], and the result is validated in its entirety: each definition
+must be compliant, and each definition referenced by another (e.g.: by
+way of SUP, MUST or FORM clauses) must be present.  Only upon success
+does the [Schema] adopt the contents of that copy.  The write lock of the
+[Schema] is held throughout, thus readers observe either all of the
+changes or none of them.  Following a failed validation, the [Schema]
+remains untouched and the receiver remains open.
+
+As the [Schema] adopts copies of its definitions, instances obtained from
+the [Schema] prior to a commit no longer reside within it, and should be
+retrieved anew.  Execution of
+[Transaction.Rollback] discards all staged changes.
+
+Instances of this type are not safe for concurrent use.
+*/
+type Transaction struct {
+	*transaction
+}
+
+type transaction struct {
+	schema Schema
+	draft  Schema
+	ops    []txOp
+	done   bool
+}
+
+/*
+txOp describes a single staged change.  The def field contains a copy of
+the definition bound to the draft, or the definition to be removed.  The
+src field contains the source reported to observers upon the push of def.
+*/
+type txOp struct {
+	kind uint8
+	def  Definition
+	src  uint
+}
+
+/*
+Begin returns a new instance of [Transaction] for use in staging changes
+to the receiver instance.  A zero instance is returned if the receiver
+is nil.
+*/
+func (r Schema) Begin() (tx Transaction) {
+	if !r.IsZero() {
+		tx = Transaction{&transaction{
+			schema: r,
+			draft:  r.Clone(),
+		}}
+	}
+
+	return
+}
+
+/*
+IsZero returns a Boolean value indicative of a nil receiver state.
+*/
+func (r Transaction) IsZero() bool {
+	return r.transaction == nil
+}
+
+/*
+Schema returns the draft [Schema] upon which changes are staged, which is
+useful for the creation of definitions which reference staged definitions,
+e.g.:
+
+	at := tx.Schema().NewAttributeType().
+		SetNumericOID(`1.3.6.1.4.1.56521.999.1`).
+		SetName(`stagedType`).
+		SetSuperType(`stagedSuperType`).
+		SetStringer()
+
+Changes made to the draft directly are not staged, and are not applied
+by [Transaction.Commit].
+*/
+func (r Transaction) Schema() (sch Schema) {
+	if !r.IsZero() {
+		sch = r.transaction.draft
+	}
+
+	return
+}
+
+/*
+Len returns the integer number of changes staged within the receiver.
+*/
+func (r Transaction) Len() (l int) {
+	if !r.IsZero() {
+		l = len(r.transaction.ops)
+	}
+
+	return
+}
+
+/*
+Push stages the push of a copy of def into the appropriate collection.
+*/
+func (r Transaction) Push(def Definition) (err error) {
+	if err = r.check(def); err != nil {
+		return
+	}
+
+	draft := r.transaction.draft
+	if draft.Exists(def) {
+		err = wraperr(ErrNotUnique, ": "+def.Type()+` `+defIdentity(def))
+		return
+	}
+
+	c := rebind(def, draft)
+	if err = draft.collectionOf(c).Push(c); err == nil {
+		r.stage(txOp{kind: txPush, def: c, src: PushSource})
+	}
+
+	return
+}
+
+/*
+Replace stages the replacement of the definition bearing the identity of
+def with a copy of def.  As with [Schema.Replace], the [AllowOverride]
+option must be in effect.
+*/
+func (r Transaction) Replace(def Definition) (err error) {
+	if err = r.check(def); err != nil {
+		return
+	}
+
+	draft := r.transaction.draft
+	if !draft.Options().Positive(AllowOverride) {
+		err = ErrOverrideNotAllowed
+		return
+	} else if !draft.Exists(def) {
+		err = wraperr(ErrNilDef, ": "+def.Type()+` `+defIdentity(def))
+		return
+	}
+
+	c := rebind(def, draft)
+	draft.Replace(c)
+	r.stage(txOp{kind: txReplace, def: c})
+
+	return
+}
+
+/*
+Remove stages the removal of the definition bearing the identity of def.
+See [Schema.Remove] for details.
+*/
+func (r Transaction) Remove(def Definition) (err error) {
+	if err = r.check(def); err != nil {
+		return
+	}
+
+	if err = r.transaction.draft.Remove(def); err == nil {
+		r.stage(txOp{kind: txRemove, def: def})
+	}
+
+	return
+}
+
+/*
+ParseRaw stages the push of each definition parsed from raw.  Should any
+part of raw fail to parse or incorporate, no definition is staged.  See
+[Schema.ParseRaw] for details.
+*/
+func (r Transaction) ParseRaw(raw []byte) (err error) {
+	if err = r.check(nil); err != nil {
+		return
+	}
+
+	// Parse into a copy of the draft, which is adopted
+	// only upon success.
+	draft := r.transaction.draft.Clone()
+	colls := []Definitions{
+		draft.LDAPSyntaxes(),
+		draft.MatchingRules(),
+		draft.AttributeTypes(),
+		draft.MatchingRuleUses(),
+		draft.ObjectClasses(),
+		draft.DITContentRules(),
+		draft.NameForms(),
+		draft.DITStructureRules(),
+	}
+
+	var before []int
+	for _, defs := range colls {
+		before = append(before, defs.Len())
+	}
+
+	if err = draft.ParseRaw(raw); err != nil {
+		return
+	}
+
+	r.transaction.draft = draft
+	for i, defs := range colls {
+		members := collectionDefinitions(defs)
+		for j := before[i]; j < len(members); j++ {
+			r.stage(txOp{kind: txPush, def: members[j], src: ParseSource})
+		}
+	}
+
+	return
+}
+
+/*
+Commit validates and applies all changes staged within the receiver, in
+the order in which they were staged.  See [Transaction] for details.
+
+Upon success, the receiver is closed.
+*/
+func (r Transaction) Commit() (err error) {
+	if err = r.check(nil); err != nil {
+		return
+	}
+
+	var events []ChangeEvent
+	sch := r.transaction.schema
+	if events, err = r.commit(sch); err == nil {
+		r.close()
+		sch.notify(events...)
+	}
+
+	return
+}
+
+/*
+commit applies all staged changes to a fresh copy of sch and, following
+the successful validation of that copy, adopts its contents into sch.  The
+write lock of sch is held throughout, thus no reader observes a partially
+applied batch, and no other writer intervenes.  The events to be delivered
+to the observers of sch are returned.
+*/
+func (r Transaction) commit(sch Schema) (events []ChangeEvent, err error) {
+	unlock, ok := sch.lock()
+	defer unlock()
+
+	if !ok {
+		err = ErrReadOnlySchema
+		return
+	}
+
+	// Apply all changes to a fresh copy, thereby honoring
+	// any modification of the schema since Begin.
+	stage := sch.clone()
+	if sch.observed() {
+		stage.Observe(func(event ChangeEvent) {
+			events = append(events, event)
+		})
+	}
+
+	for _, op := range r.transaction.ops {
+		if err = op.apply(stage); err != nil {
+			return
+		}
+	}
+
+	if err = stage.verify(); err == nil {
+		sch.adopt(stage)
+	}
+
+	return
+}
+
+/*
+Rollback discards all changes staged within the receiver, and closes the
+receiver.  The associated [Schema] is not modified.
+*/
+func (r Transaction) Rollback() (err error) {
+	if err = r.check(nil); err == nil {
+		r.close()
+	}
+
+	return
+}
+
+/*
+check returns an error if the receiver is nil or closed, or if def is
+non-nil and unsuitable for staging.  A nil def is not checked.
+*/
+func (r Transaction) check(def Definition) (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+	} else if r.transaction.done {
+		err = ErrTransactionDone
+	} else if def != nil {
+		if def.IsZero() {
+			err = ErrNilInput
+		} else if r.transaction.draft.collectionOf(def) == nil {
+			err = ErrInvalidType
+		}
+	}
+
+	return
+}
+
+func (r Transaction) stage(op txOp) {
+	r.transaction.ops = append(r.transaction.ops, op)
+}
+
+func (r Transaction) close() {
+	r.transaction.done = true
+	r.transaction.ops = nil
+	r.transaction.draft = Schema{}
+}
+
+/*
+apply applies the receiver to sch.
+*/
+func (r txOp) apply(sch Schema) (err error) {
+	switch r.kind {
+	case txPush:
+		err = sch.pushDefinition(rebind(r.def, sch), r.src)
+	case txReplace:
+		if sch.member(r.def) == nil {
+			err = wraperr(ErrNilDef, ": "+r.def.Type()+` `+defIdentity(r.def))
+			break
+		}
+		sch.Replace(rebind(r.def, sch))
+	case txRemove:
+		err = sch.Remove(r.def)
+	}
+
+	return
+}
+
+/*
+adopt substitutes the members of each collection of the receiver with
+those of the corresponding collection of stage, and associates each such
+member with the receiver.  As all references held by the members of stage
+point to their peers within stage, the adopted definitions are complete.
+The caller shall hold the write lock.
+*/
+func (r Schema) adopt(stage Schema) {
+	headerMutex.Lock()
+	defer headerMutex.Unlock()
+
+	for _, pair := range [][2]Definitions{
+		{r.LDAPSyntaxes(), stage.LDAPSyntaxes()},
+		{r.MatchingRules(), stage.MatchingRules()},
+		{r.AttributeTypes(), stage.AttributeTypes()},
+		{r.MatchingRuleUses(), stage.MatchingRuleUses()},
+		{r.ObjectClasses(), stage.ObjectClasses()},
+		{r.DITContentRules(), stage.DITContentRules()},
+		{r.NameForms(), stage.NameForms()},
+		{r.DITStructureRules(), stage.DITStructureRules()},
+	} {
+		stk := pair[0].cast()
+		defs := collectionDefinitions(pair[1])
+		for _, def := range defs {
+			bindDefinition(def, r)
+		}
+
+		L := stk.Len()
+		for i := 0; i < L && i < len(defs); i++ {
+			stk.Replace(defs[i], i)
+		}
+		for i := L - 1; i >= len(defs); i-- {
+			stk.Remove(i)
+		}
+
+		// The index must reflect the replacements prior
+		// to any push, which is subject to a uniqueness
+		// check.
+		if idx := collectionIndexOf(stk); idx != nil {
+			idx.reset()
+		}
+		for i := L; i < len(defs); i++ {
+			stk.Push(defs[i])
+		}
+	}
+}
+
+/*
+rebind returns a copy of def, all references of which are rewired to
+point to their counterparts within sch.
+*/
+func rebind(def Definition, sch Schema) (c Definition) {
+	c = cloneDefinition(def)
+
+	runlock := sch.rlock()
+	defer runlock()
+
+	rewireDefinition(c, sch)
+
+	return
+}
+
+/*
+verify returns an error if any definition within the receiver instance
+is not compliant, or references a definition not present within the
+receiver instance.  Non-compliance is described by way of [ComplianceReport],
+while missing references are described as [UnknownReferenceViolation]
+instances.
+*/
+func (r Schema) verify() error {
+	var (
+		report ComplianceReport
+		errs   []error
+	)
+
+	for _, def := range r.collectionMembers() {
+		if !def.Compliant() {
+			var rep ComplianceReport
+			if c, ok := def.(Checker); ok {
+				rep = c.Check()
+			}
+
+			if !rep.IsZero() {
+				report = append(report, rep...)
+			} else {
+				errs = append(errs, wraperr(ErrDefNonCompliant,
+					": "+def.Type()+` `+defIdentity(def)))
+			}
+		}
+
+		eachReference(def, func(clause string, ref Definition) {
+			if !ref.IsZero() && !r.Exists(ref) {
+				report.add(def, UnknownReferenceViolation, clause, defIdentity(ref))
+			}
+		})
+	}
+
+	return joinErr(append(errs, report.Err())...)
+}
+
+/*
+eachReference executes fn for each definition referenced directly by def,
+alongside the name of the referencing clause.  Zero references may be
+submitted to fn.
+*/
+func eachReference(def Definition, fn func(string, Definition)) {
+	members := func(clause string, defs Definitions) {
+		for _, ref := range collectionDefinitions(defs) {
+			fn(clause, ref)
+		}
+	}
+
+	switch tv := def.(type) {
+	case MatchingRule:
+		fn(`SYNTAX`, tv.Syntax())
+	case AttributeType:
+		fn(`SUP`, tv.SuperType())
+		fn(`EQUALITY`, tv.Equality())
+		fn(`ORDERING`, tv.Ordering())
+		fn(`SUBSTR`, tv.Substring())
+		fn(`SYNTAX`, tv.Syntax())
+	case MatchingRuleUse:
+		fn(`NUMERICOID`, tv.matchingRuleUse.OID)
+		members(`APPLIES`, tv.Applies())
+	case ObjectClass:
+		members(`SUP`, tv.SuperClasses())
+		members(`MUST`, tv.Must())
+		members(`MAY`, tv.May())
+	case DITContentRule:
+		fn(`NUMERICOID`, tv.dITContentRule.OID)
+		members(`AUX`, tv.Aux())
+		members(`MUST`, tv.Must())
+		members(`MAY`, tv.May())
+		members(`NOT`, tv.Not())
+	case NameForm:
+		fn(`OC`, tv.nameForm.Structural)
+		members(`MUST`, tv.Must())
+		members(`MAY`, tv.May())
+	case DITStructureRule:
+		fn(`FORM`, tv.Form())
+		members(`SUP`, tv.SuperRules())
+	}
+}
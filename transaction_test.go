@@ -0,0 +1,335 @@
+package schemax
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+/*
+This example demonstrates the use of a [Transaction] to apply a batch of
+changes to a [Schema] as a single unit.  Nothing is applied until the
+batch is committed.
+*/
+func ExampleSchema_Begin() {
+	sch := NewSchema()
+	tx := sch.Begin()
+
+	if err := tx.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.98.1
+		NAME 'txAttr'
+		SUP name )
+
+objectClass ( 1.3.6.1.4.1.56521.999.98.2
+		NAME 'txClass'
+		SUP top
+		AUXILIARY
+		MAY txAttr )`)); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(tx.Len(), sch.ObjectClasses().Contains(`txClass`))
+	if err := tx.Commit(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(sch.ObjectClasses().Get(`txClass`).May().Contains(`txAttr`))
+	// Output:
+	// 2 false
+	// true
+}
+
+func TestTransaction_Commit(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+	want := sch.Counters()
+	tx := sch.Begin()
+
+	if err := tx.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.98.3
+		NAME 'txSuper'
+		SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	// Reference a staged definition by way of the draft.
+	at := tx.Schema().NewAttributeType().
+		SetNumericOID(`1.3.6.1.4.1.56521.999.98.4`).
+		SetName(`txSub`).
+		SetSuperType(`txSuper`).
+		SetStringer()
+	if err := tx.Push(at); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if err = tx.Push(at); !errors.Is(err, ErrNotUnique) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrNotUnique, err)
+	}
+
+	rep := sch.AttributeTypes().Get(`name`).Clone()
+	rep.attributeType.Desc = `replaced`
+	if err := tx.Replace(rep.SetStringer()); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	if err := tx.Remove(sch.ObjectClasses().Get(`uidObject`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if err = tx.Remove(sch.AttributeTypes().Get(`name`)); err == nil {
+		t.Errorf("%s failed: removal of referenced definition staged", t.Name())
+	}
+
+	if tx.Len() != 4 {
+		t.Errorf("%s failed: want 4 staged changes, got %d", t.Name(), tx.Len())
+	}
+
+	// Nothing is applied prior to Commit.
+	if sch.Counters() != want || sch.AttributeTypes().Get(`name`).Description() == `replaced` {
+		t.Fatalf("%s failed: staged changes applied prematurely", t.Name())
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	sub := sch.AttributeTypes().Get(`txSub`)
+	if sub.IsZero() || sub.Schema() != sch {
+		t.Errorf("%s failed: pushed definition not associated with schema", t.Name())
+	} else if sub.SuperType().attributeType != sch.AttributeTypes().Get(`txSuper`).attributeType {
+		t.Errorf("%s failed: pushed definition not rewired", t.Name())
+	} else if sch.AttributeTypes().Get(`name`).Description() != `replaced` {
+		t.Errorf("%s failed: replacement not applied", t.Name())
+	} else if sch.ObjectClasses().Contains(`uidObject`) {
+		t.Errorf("%s failed: removal not applied", t.Name())
+	}
+
+	if err := tx.Commit(); !errors.Is(err, ErrTransactionDone) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrTransactionDone, err)
+	} else if err = tx.Push(at); !errors.Is(err, ErrTransactionDone) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrTransactionDone, err)
+	}
+}
+
+func TestTransaction_Rollback(t *testing.T) {
+	sch := NewSchema()
+	want := sch.Counters()
+	tx := sch.Begin()
+
+	if err := tx.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.98.5
+		NAME 'rollbackAttr'
+		SUP name )`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if err = tx.Remove(sch.ObjectClasses().Get(`uidObject`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if sch.Counters() != want || !sch.ObjectClasses().Contains(`uidObject`) {
+		t.Errorf("%s failed: schema modified following rollback", t.Name())
+	} else if err = tx.Commit(); !errors.Is(err, ErrTransactionDone) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrTransactionDone, err)
+	} else if err = tx.Rollback(); !errors.Is(err, ErrTransactionDone) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrTransactionDone, err)
+	}
+}
+
+/*
+A ParseRaw which fails partway through stages nothing, in contrast to
+[Schema.ParseRaw], which retains all definitions incorporated prior to
+the failure.
+*/
+func TestTransaction_ParseRaw(t *testing.T) {
+	sch := NewSchema()
+	want := sch.Counters()
+	tx := sch.Begin()
+
+	raw := []byte(`attributeType ( 1.3.6.1.4.1.56521.999.98.6
+		NAME 'partialAttr'
+		SUP name )
+
+objectClass ( 1.3.6.1.4.1.56521.999.98.7
+		NAME 'partialClass'
+		SUP nonExistentClass
+		AUXILIARY )`)
+
+	if err := tx.ParseRaw(raw); err == nil {
+		t.Fatalf("%s failed: expected error", t.Name())
+	} else if tx.Len() != 0 || tx.Schema().AttributeTypes().Contains(`partialAttr`) {
+		t.Errorf("%s failed: partial parse staged", t.Name())
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if sch.Counters() != want {
+		t.Errorf("%s failed: schema modified by failed parse", t.Name())
+	}
+}
+
+func TestTransaction_validation(t *testing.T) {
+	sch := NewSchema(AllowOverride)
+	want := sch.Counters()
+
+	// A missing reference is caught at Commit, and the
+	// transaction remains open.
+	tx := sch.Begin()
+	at := NewAttributeType().
+		SetNumericOID(`1.3.6.1.4.1.56521.999.98.8`).
+		SetName(`danglingAttr`).
+		SetSyntax(sch.LDAPSyntaxes().Get(`1.3.6.1.4.1.1466.115.121.1.15`)).
+		SetEquality(NewMatchingRule().
+			SetNumericOID(`1.3.6.1.4.1.56521.999.98.9`).
+			SetName(`missingMatch`).
+			SetSyntax(sch.LDAPSyntaxes().Get(`1.3.6.1.4.1.1466.115.121.1.15`)))
+	if err := tx.Push(at.SetStringer()); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	err := tx.Commit()
+	var v ComplianceViolation
+	if !errors.As(err, &v) || v.Code != UnknownReferenceViolation || v.Clause != `EQUALITY` {
+		t.Fatalf("%s failed: unexpected error %v", t.Name(), err)
+	} else if !errors.Is(err, ErrDefNonCompliant) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrDefNonCompliant, err)
+	} else if sch.Counters() != want || sch.AttributeTypes().Contains(`danglingAttr`) {
+		t.Errorf("%s failed: schema modified following failed commit", t.Name())
+	} else if err = tx.Rollback(); err != nil {
+		t.Errorf("%s failed: transaction closed following failed commit: %v", t.Name(), err)
+	}
+
+	// A removal staged upon a stale draft fails upon Commit
+	// if the live schema has since gained a dependent.
+	tx = sch.Begin()
+	if err = tx.Remove(sch.ObjectClasses().Get(`uidObject`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if err = sch.ParseObjectClass(`( 1.3.6.1.4.1.56521.999.98.10
+		NAME 'uidSubclass'
+		SUP uidObject
+		AUXILIARY )`); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	if err = tx.Commit(); err == nil {
+		t.Errorf("%s failed: expected error", t.Name())
+	} else if !sch.ObjectClasses().Contains(`uidObject`) {
+		t.Errorf("%s failed: schema modified following failed commit", t.Name())
+	}
+
+	// A replacement which fails validation leaves the
+	// original definition in place.
+	tx = sch.Begin()
+	rep := sch.AttributeTypes().Get(`cn`).Clone()
+	rep.attributeType.Desc = `replaced`
+	rep.attributeType.SuperType = NewAttributeType().
+		SetNumericOID(`1.3.6.1.4.1.56521.999.98.11`).
+		SetName(`missingSuper`).
+		SetSyntax(sch.LDAPSyntaxes().Get(`1.3.6.1.4.1.1466.115.121.1.15`))
+	if err = tx.Replace(rep.SetStringer()); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if err = tx.Commit(); !errors.As(err, &v) || v.Clause != `SUP` {
+		t.Errorf("%s failed: unexpected error %v", t.Name(), err)
+	} else if sch.AttributeTypes().Get(`cn`).Description() == `replaced` {
+		t.Errorf("%s failed: replacement applied following failed commit", t.Name())
+	}
+}
+
+/*
+The Schema adopts a commit in a single step, thus a reader observes either
+all of the staged changes or none of them.
+*/
+func TestTransaction_atomic(t *testing.T) {
+	sch := NewSchema()
+	tx := sch.Begin()
+	for idx, name := range []string{`atomicA`, `atomicB`} {
+		if err := tx.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.98.2` +
+			itoa(idx) + ` NAME '` + name + `' SUP name )`)); err != nil {
+			t.Fatalf("%s failed: %v", t.Name(), err)
+		}
+	}
+	if err := tx.Remove(sch.ObjectClasses().Get(`uidObject`)); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		done atomic.Bool
+		torn atomic.Bool
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for !done.Load() {
+			runlock := sch.rlock()
+			a := !sch.AttributeTypes().lookup(`atomicA`).IsZero()
+			b := !sch.AttributeTypes().lookup(`atomicB`).IsZero()
+			u := !sch.ObjectClasses().lookup(`uidObject`).IsZero()
+			runlock()
+			if a != b || a == u {
+				torn.Store(true)
+			}
+		}
+	}()
+
+	err := tx.Commit()
+	done.Store(true)
+	wg.Wait()
+
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if torn.Load() {
+		t.Errorf("%s failed: partially applied commit observed", t.Name())
+	} else if !sch.AttributeTypes().Contains(`atomicB`) || sch.ObjectClasses().Contains(`uidObject`) {
+		t.Errorf("%s failed: changes not adopted", t.Name())
+	} else if sup := sch.AttributeTypes().Get(`atomicA`).SuperType(); sup != sch.AttributeTypes().Get(`name`) {
+		t.Errorf("%s failed: adopted definition not bound to schema", t.Name())
+	} else if sup.Schema() != sch {
+		t.Errorf("%s failed: adopted definition associated with stage", t.Name())
+	}
+
+	if err := (txOp{kind: txReplace, def: NewAttributeType()}).apply(sch); !errors.Is(err, ErrNilDef) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrNilDef, err)
+	}
+}
+
+func TestTransaction_codecov(t *testing.T) {
+	var tx Transaction
+	_ = tx.Schema()
+	_ = tx.Len()
+	for idx, err := range []error{
+		tx.Push(NewAttributeType()),
+		tx.Replace(NewAttributeType()),
+		tx.Remove(NewAttributeType()),
+		tx.ParseRaw(nil),
+		tx.Commit(),
+		tx.Rollback(),
+	} {
+		if !errors.Is(err, ErrNilReceiver) {
+			t.Errorf("%s[%d] failed: want %v, got %v", t.Name(), idx, ErrNilReceiver, err)
+		}
+	}
+
+	var zero Schema
+	if !zero.Begin().IsZero() {
+		t.Errorf("%s failed: non-zero transaction from zero schema", t.Name())
+	}
+
+	sch := NewSchema()
+	tx = sch.Begin()
+	if err := tx.Push(AttributeType{}); !errors.Is(err, ErrNilInput) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrNilInput, err)
+	} else if err = tx.Push(sch.ObjectClasses().Get(`top`)); !errors.Is(err, ErrNotUnique) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrNotUnique, err)
+	} else if err = tx.Replace(sch.AttributeTypes().Get(`cn`)); !errors.Is(err, ErrOverrideNotAllowed) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrOverrideNotAllowed, err)
+	}
+
+	tx = NewSchema(AllowOverride).Begin()
+	if err := tx.Replace(NewAttributeType().SetNumericOID(`1.3.6.1.4.1.56521.999.98.13`)); !errors.Is(err, ErrNilDef) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrNilDef, err)
+	}
+
+	if err := sch.Snapshot().Begin().Commit(); !errors.Is(err, ErrReadOnlySchema) {
+		t.Errorf("%s failed: want %v, got %v", t.Name(), ErrReadOnlySchema, err)
+	}
+
+	eachReference(nil, func(string, Definition) {
+		t.Errorf("%s failed: unexpected reference", t.Name())
+	})
+}
@@ -96,11 +96,32 @@ const (
 	// This may include subordinate rules.
 	AllowReindexedStructureRules
 
+	// ContinueOnError will cause the ParseRaw, ParseFile and
+	// ParseDirectory methods of a Schema to continue past any
+	// definition that fails to parse or marshal, as opposed to
+	// stopping at the first failure.  All failures encountered
+	// are returned in aggregated form, each being an instance
+	// of ParseError which bears the source file, line number,
+	// definition type and identifier of the offending item.
+	ContinueOnError
+
+	// SyntaxQualifiers will cause the built-in SyntaxQualifier
+	// implementations for the RFC 4517 syntaxes to be assigned
+	// to each applicable LDAPSyntax as it is loaded through the
+	// LoadRFC4517Syntaxes method (or NewSchema). Syntaxes that
+	// already bear a qualifier are left alone.
+	SyntaxQualifiers
+
+	// AssertionMatchers will cause the built-in AssertionMatcher
+	// implementations for the RFC 4517 matching rules to be
+	// assigned to each applicable MatchingRule as it is loaded
+	// through the LoadRFC4517MatchingRules method (or NewSchema).
+	// Matching rules that already bear a matcher are left alone.
+	// The appropriate RFC 4518 StringPreparer is also assigned
+	// to each rule that operates upon prepared strings.
+	AssertionMatchers
+
 	// As-of-yet unused bit settings
-	//_                    //    16
-	//_                    //    32
-	//_                    //    64
-	//_                    //   128
 	//_                    //   256
 	//_                    //   512
 	//_                    //  1024
@@ -134,6 +155,20 @@ indicated.
 */
 type AssertionMatcher func(any, any) error
 
+/*
+StringPreparer is an optional closure function or method signature which
+may be honored by the end user for the preparation of string values prior
+to an assertion match with respect to a [MatchingRule] instance, such as
+that described by RFC 4518.
+
+The uint input value indicates the nature of the string value, and shall
+be one of [ValuePrep], [InitialPrep], [AnyPrep] or [FinalPrep].
+
+See [CaseIgnorePrep], [CaseExactPrep], [NumericStringPrep] and
+[TelephoneNumberPrep] for built-in instances of this type.
+*/
+type StringPreparer func(string, uint) (string, error)
+
 /*
 ValueQualifier is an optional closure function of method signature which
 may be honored by the end user for enhanced value interrogation of any
@@ -613,6 +648,7 @@ type matchingRule struct {
 	schema   Schema
 	stringer Stringer
 	assMatch AssertionMatcher
+	prep     StringPreparer
 	data     any
 }
 
@@ -793,7 +829,8 @@ type objectClass struct {
 Counters is a simple struct type defined to store the current number
 of definition instances within an instance of [Schema].
 
-Instances of this type are NOT thread-safe.
+Instances of this type are plain values which are not updated following
+their creation by way of the [Schema.Counters] method.
 */
 type Counters struct {
 	LS int
@@ -1025,6 +1062,22 @@ type Definitions interface {
 	cast() stackage.Stack
 }
 
+/*
+Checker is an interface type satisfied by all [Definition] and
+[Definitions] qualifiers, as well as by [Schema], allowing access to
+the [ComplianceReport] describing the manners in which an instance
+fails to comply with relevant RFCs, such as RFC 4512.
+
+This interface is distinct from [Definition] and [Definitions] so as
+not to burden externally-defined implementations of those types.
+*/
+type Checker interface {
+	// Check returns a ComplianceReport describing the manners
+	// in which the receiver fails to comply with relevant RFCs,
+	// such as RFC 4512.
+	Check() ComplianceReport
+}
+
 /*
 TODO: Deprecated: get rid of me using Options.HangingIndents
 */
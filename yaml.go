@@ -0,0 +1,347 @@
+package schemax
+
+/*
+yaml.go contains facilities for the use of YAML as an alternative schema
+source format to RFC 4512 text.
+*/
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+/*
+yamlDocument contains a single YAML document -- which describes either a
+definition or an objectIdentifier directive -- alongside the name of the
+file and the number of the line on which it begins.
+*/
+type yamlDocument struct {
+	Type   string
+	Node   *yaml.Node
+	Layout definitionLayout
+	File   string
+	Line   int
+}
+
+/*
+objectIdentifierLayout is the YAML object layout of an objectIdentifier
+directive, which registers a [Macros] entry, e.g.:
+
+	type: objectIdentifier
+	name: myOrg
+	oid: 1.3.6.1.4.1.56521.999
+*/
+type objectIdentifierLayout struct {
+	Type string `yaml:"type"`
+	Name string `yaml:"name"`
+	OID  string `yaml:"oid"`
+}
+
+/*
+ParseYAML returns an error following an attempt to parse raw, which must
+contain one or more YAML documents, each of which describes a single
+definition, e.g.:
+
+	type: objectIdentifier
+	name: myOrg
+	oid: 1.3.6.1.4.1.56521.999
+	---
+	type: attributeType
+	oid: myOrg:1
+	names: [ myAttr ]
+	sup: name
+	---
+	type: objectClass
+	oid: myOrg:2
+	names: [ myClass ]
+	sup: [ top ]
+	kind: AUXILIARY
+	may: [ myAttr, cn ]
+
+The fields of each document are those described by the MarshalJSON method
+of the relevant definition type, such as [AttributeType.MarshalJSON]. The
+"type" field is required.  References to other definitions may be made by
+name or numeric OID.
+
+Documents of type "objectIdentifier" register a [Macros] entry, and are
+processed before all definitions.  The "oid" value of any definition may
+make use of such a macro.
+
+Definitions are written to the receiver instance in the manner described
+by the [Schema.ParseRaw] method, thus the order of documents within raw is
+not significant.  Each definition is validated through the Compliant method
+of the relevant type.
+
+Failures are returned as [ParseError] instances.  Parsing ceases upon the
+first error unless the [ContinueOnError] option is in effect, in which case
+all failures are returned.
+*/
+func (r Schema) ParseYAML(raw []byte) error {
+	return joinErr(r.parseYAML(raw, ``)...)
+}
+
+/*
+ParseYAMLFile returns an error following an attempt to parse file in the
+manner described by the [Schema.ParseYAML] method. Only files ending in
+".yaml" or ".yml" will be considered.
+*/
+func (r Schema) ParseYAMLFile(file string) (err error) {
+	if !hasSfx(file, `.yaml`) && !hasSfx(file, `.yml`) {
+		err = mkerr("Filename '" + file + "' does not end in '.yaml' or '.yml'; will not parse")
+		return
+	}
+
+	var raw []byte
+	if raw, err = os.ReadFile(file); err == nil {
+		err = joinErr(r.parseYAML(raw, file)...)
+	}
+
+	return
+}
+
+/*
+parseYAML parses the YAML documents within raw, returning all failures as
+[ParseError] instances.  Unless the [ContinueOnError] option is in effect,
+parsing ceases upon the first failure.  The file input value is only used
+for the purpose of error reporting.
+*/
+func (r Schema) parseYAML(raw []byte, file string) (errs []error) {
+	if r.IsZero() {
+		errs = []error{ErrNilReceiver}
+		return
+	}
+
+	tolerant := r.Options().Positive(ContinueOnError)
+
+	docs, errs := scanYAMLDocuments(raw, file)
+	if len(errs) > 0 && !tolerant {
+		return
+	}
+
+	for _, typ := range append([]string{`objectIdentifier`}, definitionTypes...) {
+		var group []yamlDocument
+		for _, doc := range docs {
+			if doc.Type == typ {
+				group = append(group, doc)
+			}
+		}
+
+		var failed []error
+		ordered, ferrs := r.orderYAMLDocuments(typ, group)
+		for idx, err := range ferrs {
+			failed = append(failed, group[idx].parseError(err))
+		}
+
+		for _, doc := range ordered {
+			if err := r.incorporateYAML(doc); err != nil {
+				failed = append(failed, doc.parseError(err))
+			}
+		}
+
+		sortParseErrors(failed)
+		errs = append(errs, failed...)
+		if len(errs) > 0 && !tolerant {
+			errs = errs[:1]
+			return
+		}
+	}
+
+	sortParseErrors(errs)
+
+	return
+}
+
+/*
+scanYAMLDocuments returns the YAML documents found within raw, alongside
+any failures encountered.  Empty documents are silently ignored.
+*/
+func scanYAMLDocuments(raw []byte, file string) (docs []yamlDocument, errs []error) {
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	for {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			// the decoder cannot recover from
+			// malformed content.
+			errs = append(errs, ParseError{File: file, Err: err})
+			break
+		}
+
+		if len(node.Content) == 0 || node.Content[0].ShortTag() == `!!null` {
+			continue
+		}
+
+		doc := yamlDocument{
+			Node: node.Content[0],
+			File: file,
+			Line: node.Content[0].Line,
+		}
+
+		var hdr struct {
+			Type string `yaml:"type"`
+		}
+
+		if doc.Node.Kind != yaml.MappingNode {
+			err = wraperr(ErrUnrecognizedContent, `: expected mapping`)
+		} else if err = doc.Node.Decode(&hdr); err == nil {
+			if doc.Type = hdr.Type; doc.Type != `objectIdentifier` {
+				if doc.Layout = newLayout(doc.Type); doc.Layout == nil {
+					err = wraperr(ErrUnrecognizedContent, `: unknown type '`+doc.Type+`'`)
+				} else {
+					err = doc.Node.Decode(doc.Layout)
+				}
+			}
+		}
+
+		if err != nil {
+			errs = append(errs, doc.parseError(err))
+			continue
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return
+}
+
+/*
+orderYAMLDocuments returns docs, all of which must be of the specified
+type, ordered by dependency.  Any documents which could not be ordered
+are absent from the return slice, and their errors are keyed by index.
+Documents of types not subject to ordering are returned as-is.
+*/
+func (r Schema) orderYAMLDocuments(typ string, docs []yamlDocument) (ordered []yamlDocument, errs map[int]error) {
+	layouts := make([]definitionLayout, len(docs))
+	for i, doc := range docs {
+		layouts[i] = doc.Layout
+	}
+
+	var order []int
+	order, errs = r.layoutOrder(typ, layouts)
+	for _, idx := range order {
+		ordered = append(ordered, docs[idx])
+	}
+
+	return
+}
+
+/*
+incorporateYAML returns an error following an attempt to write the
+definition -- or objectIdentifier directive -- described by doc to the
+receiver instance.
+*/
+func (r Schema) incorporateYAML(doc yamlDocument) (err error) {
+	if doc.Type == `objectIdentifier` {
+		var oi objectIdentifierLayout
+		if err = doc.Node.Decode(&oi); err == nil {
+			if len(oi.Name) == 0 || len(oi.OID) == 0 {
+				err = wraperr(ErrIncompleteDefinition, `: name and oid are required`)
+			} else {
+				err = r.parseObjectIdentifier(oi.Name + ` ` + oi.OID)
+			}
+		}
+		return
+	}
+
+	def := r.newDefinition(doc.Type)
+	if err = marshalLayout(def, doc.Layout); err != nil {
+		return
+	} else if r.Exists(def) {
+		err = wraperr(ErrDuplicateDef, `: `+doc.Type+` `+defIdentity(def))
+		return
+	}
+
+	if def.Compliant() {
+		r.pushDefinition(def, ParseSource)
+	}
+
+	return
+}
+
+/*
+parseError returns a [ParseError] describing err with respect to the
+receiver instance.
+*/
+func (r yamlDocument) parseError(err error) ParseError {
+	perr := ParseError{
+		File: r.File,
+		Line: r.Line,
+		Type: r.Type,
+		Err:  err,
+	}
+
+	if r.Layout != nil {
+		dm := r.Layout.definitionMap()
+		for _, key := range []string{`NUMERICOID`, `RULEID`} {
+			if id := dm[key]; len(id) > 0 {
+				perr.ID = id[0]
+			}
+		}
+		if name := dm[`NAME`]; len(name) > 0 {
+			perr.Name = name[0]
+		}
+	}
+
+	return perr
+}
+
+/*
+WriteYAML returns an error following an attempt to write the contents of
+the receiver instance to w as a stream of YAML documents, each of which
+describes a single definition.  The output is suitable for use with the
+[Schema.ParseYAML] method.
+
+All [Macros] are written first as objectIdentifier documents, ordered by
+name.  All definitions are then written in order of type precedence and,
+within each type, in order of appearance.  References to other definitions
+are written by name where possible, else by numeric OID.
+*/
+func (r Schema) WriteYAML(w io.Writer) (err error) {
+	if r.IsZero() {
+		err = ErrNilReceiver
+		return
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+
+	keys := r.Macros().Keys()
+	sort.Strings(keys)
+	for _, k := range keys {
+		oid, _ := r.Macros().Resolve(k)
+		if err = enc.Encode(objectIdentifierLayout{
+			Type: `objectIdentifier`,
+			Name: k,
+			OID:  oid,
+		}); err != nil {
+			return
+		}
+	}
+
+	for _, defs := range []Definitions{
+		r.LDAPSyntaxes(),
+		r.MatchingRules(),
+		r.AttributeTypes(),
+		r.MatchingRuleUses(),
+		r.ObjectClasses(),
+		r.DITContentRules(),
+		r.NameForms(),
+		r.DITStructureRules(),
+	} {
+		for _, def := range collectionDefinitions(defs) {
+			if err = enc.Encode(defLayout(def)); err != nil {
+				return
+			}
+		}
+	}
+
+	err = enc.Close()
+
+	return
+}
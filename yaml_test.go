@@ -0,0 +1,226 @@
+package schemax
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+This example demonstrates the parsing of YAML documents, each of which
+describes a single definition.  Macros registered by objectIdentifier
+documents may be used within the "oid" field of any definition.
+*/
+func ExampleSchema_ParseYAML() {
+	sch := NewSchema()
+
+	raw := []byte(`type: objectClass
+oid: fakeMacro:2
+names: [ fakeClass ]
+sup: [ top ]
+kind: AUXILIARY
+may: [ fakeAttr, cn ]
+---
+type: attributeType
+oid: fakeMacro:1
+names: [ fakeAttr ]
+desc: Fake attribute
+sup: name
+---
+type: objectIdentifier
+name: fakeMacro
+oid: 1.3.6.1.4.1.56521.999.86`)
+
+	if err := sch.ParseYAML(raw); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(sch.ObjectClasses().Get(`fakeClass`))
+	// Output: ( 1.3.6.1.4.1.56521.999.86.2 NAME 'fakeClass' SUP top AUXILIARY MAY ( fakeAttr $ cn ) )
+}
+
+/*
+This example demonstrates the YAML encoding of select definitions.
+*/
+func ExampleSchema_WriteYAML() {
+	sch := NewEmptySchema()
+	if err := sch.ParseRaw([]byte(`ldapSyntax ( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )
+matchingRule ( 2.5.13.2 NAME 'caseIgnoreMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )`)); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := sch.WriteYAML(&buf); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Print(buf.String())
+	// Output:
+	// type: ldapSyntax
+	// oid: 1.3.6.1.4.1.1466.115.121.1.15
+	// desc: Directory String
+	// ---
+	// type: matchingRule
+	// oid: 2.5.13.2
+	// names:
+	//   - caseIgnoreMatch
+	// syntax: 1.3.6.1.4.1.1466.115.121.1.15
+}
+
+func TestSchema_YAML(t *testing.T) {
+	sch := NewSchema()
+	sch.Macros().Set(`fakeMacro`, `1.3.6.1.4.1.56521.999`)
+	if err := sch.ParseRaw([]byte(`attributeType ( 1.3.6.1.4.1.56521.999.86.3
+	NAME 'fakeMUBAttr'
+	EQUALITY caseIgnoreMatch
+	SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{64}
+	X-ORIGIN 'Testing' )`)); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := sch.WriteYAML(&buf); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	tempDir, err := os.MkdirTemp("", "schemax_yaml")
+	if err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+
+	file := filepath.Join(tempDir, `schema.yaml`)
+	if err = os.WriteFile(file, buf.Bytes(), 0644); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	imported := NewEmptySchema()
+	if err = imported.ParseYAMLFile(file); err != nil {
+		t.Errorf("%s failed: %v", t.Name(), err)
+		return
+	}
+
+	if diff := sch.Diff(imported); !diff.IsZero() {
+		t.Errorf("%s failed: unexpected differences:\n%s", t.Name(), diff)
+		return
+	}
+
+	if got, want := imported.Counters(), sch.Counters(); got != want {
+		t.Errorf("%s failed: counters mismatch; want %v, got %v", t.Name(), want, got)
+		return
+	}
+
+	if res, _ := imported.Macros().Resolve(`fakeMacro`); res != `1.3.6.1.4.1.56521.999` {
+		t.Errorf("%s failed: macros not imported", t.Name())
+		return
+	}
+}
+
+func TestSchema_ParseYAML_errors(t *testing.T) {
+	raw := []byte(`type: attributeType
+oid: 1.3.6.1.4.1.56521.999.86.4
+names: [ fakeAttr ]
+sup: bogusAttr
+---
+type: bogusType
+oid: 1.3.6.1.4.1.56521.999.86.5
+---
+type: objectClass
+oid: 1.3.6.1.4.1.56521.999.86.6
+names: [ fakeClass ]
+sup: [ fakeOtherClass ]
+---
+type: objectClass
+oid: 1.3.6.1.4.1.56521.999.86.7
+names: [ fakeOtherClass ]
+sup: [ fakeClass ]
+---
+type: attributeType
+oid: 2.5.4.3
+names: [ cn ]
+sup: name`)
+
+	if err := NewSchema().ParseYAML(raw); !errors.Is(err, ErrUnrecognizedContent) {
+		t.Errorf("%s failed: expected %v, got %v", t.Name(), ErrUnrecognizedContent, err)
+		return
+	}
+
+	err := NewSchema(ContinueOnError).ParseYAML(raw)
+	for _, want := range []error{
+		ErrAttributeTypeNotFound,
+		ErrUnrecognizedContent,
+		ErrCircularDependency,
+		ErrDuplicateDef,
+	} {
+		if !errors.Is(err, want) {
+			t.Errorf("%s failed: expected %v, got %v", t.Name(), want, err)
+			return
+		}
+	}
+
+	var perr ParseError
+	if !errors.As(err, &perr) || perr.Line != 1 || perr.Name != `fakeAttr` {
+		t.Errorf("%s failed: unexpected first error: %#v", t.Name(), perr)
+		return
+	}
+}
+
+func TestYAML_codecov(t *testing.T) {
+	var sch Schema
+	if err := sch.ParseYAML(nil); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+	if err := sch.WriteYAML(&bytes.Buffer{}); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	sch = NewSchema()
+	if err := sch.ParseYAMLFile(`schema.json`); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+	if err := sch.ParseYAMLFile(`/nonexistent.yml`); err == nil {
+		t.Errorf("%s failed: expected error, got nil", t.Name())
+	}
+
+	for idx, raw := range []string{
+		`type: [`,
+		`- not a mapping`,
+		`type: attributeType
+oid: [ 1.2.3 ]`,
+		`type: objectIdentifier
+name: fakeMacro`,
+		`type: dITStructureRule
+ruleid: 30
+names: [ fakeRule ]
+form: domainNameForm
+sup: [ 99 ]`,
+		`type: objectIdentifier
+name: fakeMacro
+oid: bogusMacro:1`,
+		`type: matchingRule
+oid: 1.3.6.1.4.1.56521.999.86.8
+names: [ fakeMatch ]`,
+	} {
+		if err := NewSchema().ParseYAML([]byte(raw)); err == nil {
+			t.Errorf("%s[%d] failed: expected error, got nil", t.Name(), idx)
+		}
+	}
+
+	if err := sch.ParseYAML([]byte("---\n---\n")); err != nil {
+		t.Errorf("%s failed: unexpected error: %v", t.Name(), err)
+	}
+
+	if l := defLayout(nil); l != nil {
+		t.Errorf("%s failed: expected nil layout", t.Name())
+	}
+}